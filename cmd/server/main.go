@@ -14,14 +14,21 @@ import (
     "google.golang.org/grpc/reflection"
 
     pb "product-catalog-service/proto/product/v1"
+    sweepdiscounts "product-catalog-service/internal/app/product/usecases/sweep_discounts"
+    backfillpriceperiods "product-catalog-service/internal/app/product/usecases/backfill_price_periods"
+    runrecategorizations "product-catalog-service/internal/app/product/usecases/run_recategorizations"
+    "product-catalog-service/internal/pkg/scheduler"
     "product-catalog-service/internal/services"
     "product-catalog-service/internal/transport/grpc/product"
 )
 
 const (
-    defaultGRPCPort      = "50051"
-    spannerEmulatorHost  = "localhost:9010" // Make sure docker-compose is running Spanner emulator
-    spannerDatabase      = "projects/test-project/instances/test-instance/databases/product_catalog"
+    defaultGRPCPort       = "50051"
+    spannerEmulatorHost   = "localhost:9010" // Make sure docker-compose is running Spanner emulator
+    spannerDatabase       = "projects/test-project/instances/test-instance/databases/product_catalog"
+    discountSweepInterval = time.Minute
+    searchSyncInterval    = 5 * time.Second
+    recategorizationInterval = 10 * time.Second
 )
 
 func main() {
@@ -39,6 +46,54 @@ func main() {
     // --- Initialize all services (DI container) ---
     opts := services.NewOptions(ctx, client)
 
+    // --- Start background jobs ---
+    jobsCtx, stopJobs := context.WithCancel(ctx)
+    defer stopJobs()
+
+    // Products written before price periods existed are not found by
+    // price filters and ordering until their timeline is backfilled. The
+    // backfill skips products that have one; a failed run is resumed on the
+    // next start.
+    go func() {
+        result, err := opts.BackfillPricePeriods.Execute(jobsCtx, backfillpriceperiods.Request{})
+        if err != nil {
+            log.Printf("price period backfill: %v", err)
+            return
+        }
+        if result.Products > 0 {
+            log.Printf("price period backfill: wrote the timeline of %d products", result.Products)
+        }
+    }()
+
+    // Discount windows starting or ending change prices without a command;
+    // the sweep publishes those changes through the outbox.
+    // It resumes from its stored cursor, so restarts skip no transition.
+    go scheduler.Every(jobsCtx, "discount sweep", discountSweepInterval, func(ctx context.Context) error {
+        _, err := opts.SweepDiscounts.Execute(ctx, sweepdiscounts.Request{})
+        return err
+    })
+
+    // Recategorization jobs move products in batches; a job interrupted by
+    // a crash resumes from its last committed batch on the next tick.
+    go scheduler.Every(jobsCtx, "recategorization", recategorizationInterval, func(ctx context.Context) error {
+        _, err := opts.RunRecategorizations.Execute(ctx, runrecategorizations.Request{})
+        return err
+    })
+
+    // The embedded search index follows product changes through the outbox.
+    if opts.SearchIndex != nil {
+        if err := opts.SearchIndex.Load(ctx); err != nil {
+            log.Fatalf("failed to load search index: %v", err)
+        }
+        go scheduler.Every(jobsCtx, "search index sync", searchSyncInterval, opts.SearchIndex.Sync)
+    }
+
+    // Suggestions are always served from memory to keep type-ahead fast.
+    if err := opts.SuggestIndex.Load(ctx); err != nil {
+        log.Fatalf("failed to load suggestion index: %v", err)
+    }
+    go scheduler.Every(jobsCtx, "suggestion index sync", searchSyncInterval, opts.SuggestIndex.Sync)
+
     // --- Initialize gRPC server ---
     grpcServer := grpc.NewServer()
 
@@ -50,11 +105,32 @@ func main() {
         opts.DeactivateProduct,
         opts.ApplyDiscount,
         opts.RemoveDiscount,
+        opts.SchedulePrice,
+        opts.CancelScheduledPrice,
+        opts.RecategorizeProducts,
+        opts.AddVariant,
+        opts.UpdateVariant,
+        opts.RemoveVariant,
         opts.GetProduct,
         opts.ListProducts,
+        opts.GetPriceHistory,
+        opts.BatchGetProducts,
+        opts.SearchProducts,
+        opts.SuggestProducts,
+        opts.GetRecategorization,
     )
     pb.RegisterProductServiceServer(grpcServer, handler)
 
+    // --- Register CategoryService handler ---
+    categoryHandler := product.NewCategoryHandler(
+        opts.CreateCategory,
+        opts.UpdateCategory,
+        opts.DeleteCategory,
+        opts.GetCategory,
+        opts.ListCategories,
+    )
+    pb.RegisterCategoryServiceServer(grpcServer, categoryHandler)
+
     // Enable reflection for debugging with grpcurl or Evans CLI
     reflection.Register(grpcServer)
 
@@ -72,6 +148,7 @@ func main() {
         signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
         <-sigCh
         log.Println("Shutting down gRPC server...")
+        stopJobs()
         grpcServer.GracefulStop()
     }()
 
@@ -0,0 +1,37 @@
+package contracts
+
+import (
+	"context"
+	"time"
+)
+
+// CategoryRecord is a read-model representation of a category row.
+type CategoryRecord struct {
+	CategoryID string
+	Name       string
+	Slug       string
+	// ParentID is empty for root categories.
+	ParentID  string
+	SortOrder int64
+	// AttributeSchema is sorted by key.
+	AttributeSchema []AttributeDefinitionRecord
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// AttributeDefinitionRecord is a read-model representation of an attribute
+// a category declares for its products. AllowedValues is only set for enum
+// attributes.
+type AttributeDefinitionRecord struct {
+	Key           string
+	Type          string
+	Required      bool
+	AllowedValues []string
+}
+
+// CategoryReadModel defines query-side access to categories. The category
+// tree is small and always read whole.
+type CategoryReadModel interface {
+	// ListCategories returns every category in no particular order.
+	ListCategories(ctx context.Context) ([]*CategoryRecord, error)
+}
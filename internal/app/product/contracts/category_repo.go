@@ -0,0 +1,55 @@
+package contracts
+
+import (
+	"context"
+
+	"cloud.google.com/go/spanner"
+	"product-catalog-service/internal/app/product/domain"
+	"product-catalog-service/internal/pkg/committer"
+)
+
+// CategoryRepo defines the write-side repository interface for Category
+// aggregates. Implementations must return mutations instead of applying them.
+type CategoryRepo interface {
+	// InsertMut returns a mutation to insert a new category.
+	// Returns nil if category is nil.
+	InsertMut(c *domain.Category) *spanner.Mutation
+
+	// UpdateMut returns a mutation to update changed fields of a category.
+	// Returns nil if no changes are dirty.
+	UpdateMut(c *domain.Category) *spanner.Mutation
+
+	// AttributeSchemaMuts returns mutations that write the changed
+	// attribute definitions of a category and delete the removed ones.
+	// Returns nil if no definition is dirty.
+	AttributeSchemaMuts(c *domain.Category) []*spanner.Mutation
+
+	// DeleteMut returns a mutation to delete a category.
+	// Returns nil if category is nil.
+	DeleteMut(c *domain.Category) *spanner.Mutation
+
+	// FindByID loads a category aggregate by ID with its attribute schema.
+	// Returns domain.ErrCategoryNotFound if it does not exist.
+	FindByID(ctx context.Context, id string) (*domain.Category, error)
+
+	// FindBySlug loads a category aggregate by slug.
+	// Returns domain.ErrCategoryNotFound if it does not exist.
+	FindBySlug(ctx context.Context, slug string) (*domain.Category, error)
+
+	// SlugCheck returns a check, run in the committing transaction, that
+	// loads the category with the given slug and passes it to check. It
+	// fails with domain.ErrCategoryNotFound if there is none.
+	SlugCheck(slug string, check func(c *domain.Category) error) committer.Check
+
+	// AncestorsCheck returns a check, run in the committing transaction,
+	// that reads the IDs of the ancestors of parentID, nearest first, and
+	// passes them to check. The walk stops at the root or at an ID seen
+	// before. It fails with domain.ErrCategoryNotFound if a category on the
+	// way up does not exist.
+	AncestorsCheck(parentID string, check func(ancestors []string) error) committer.Check
+
+	// UnusedCheck returns a check, run in the committing transaction, that
+	// fails with domain.ErrCategoryInUse when a category has c as parent or
+	// a product, archived ones included, references its slug.
+	UnusedCheck(c *domain.Category) committer.Check
+}
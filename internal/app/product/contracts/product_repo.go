@@ -2,9 +2,11 @@ package contracts
 
 import (
 	"context"
+	"time"
 
 	"cloud.google.com/go/spanner"
 	"product-catalog-service/internal/app/product/domain"
+	"product-catalog-service/internal/pkg/committer"
 )
 
 // ProductRepo defines the write-side repository interface for Product aggregates.
@@ -19,8 +21,73 @@ type ProductRepo interface {
 	// Returns nil if no changes are dirty.
 	UpdateMut(p *domain.Product) *spanner.Mutation
 
+	// DiscountMuts returns mutations that write the changed discounts of
+	// a product and delete the removed ones. Must be added to the plan
+	// after InsertMut/UpdateMut.
+	// Returns nil if no discount is dirty.
+	DiscountMuts(p *domain.Product) []*spanner.Mutation
+
+	// ScheduledPriceMuts returns mutations that replace the stored scheduled
+	// prices of a product. Must be added to the plan after InsertMut/UpdateMut.
+	// Returns nil if scheduled prices are not dirty.
+	ScheduledPriceMuts(p *domain.Product) []*spanner.Mutation
+
+	// AttributeMuts returns mutations that write the changed attributes of
+	// a product and delete the removed ones. Must be added to the plan
+	// after InsertMut/UpdateMut.
+	// Returns nil if no attribute is dirty.
+	AttributeMuts(p *domain.Product) []*spanner.Mutation
+
+	// VariantMuts returns mutations that write the added and changed
+	// variants of a product and delete the removed ones. Must be added to
+	// the plan after InsertMut/UpdateMut.
+	// Returns nil if no variant is dirty.
+	VariantMuts(p *domain.Product) []*spanner.Mutation
+
+	// PriceHistoryMut returns a mutation appending the pricing state of a
+	// product to the append-only price history.
+	// Returns nil if no pricing field (base price, discounts, scheduled
+	// prices) is dirty.
+	PriceHistoryMut(p *domain.Product) *spanner.Mutation
+
+	// PricePeriodMuts returns mutations that replace the materialized
+	// effective price timeline of a product, which listings filter and sort
+	// by. Must be added to the plan after InsertMut/UpdateMut.
+	// Returns nil if no pricing field is dirty.
+	PricePeriodMuts(p *domain.Product) []*spanner.Mutation
+
 	// FindByID loads a product aggregate by ID.
 	// Returns domain error if not found.
 	FindByID(ctx context.Context, id string) (*domain.Product, error)
+
+	// FindVariantBySKU returns the IDs of the product and variant with the
+	// given SKU. Returns domain.ErrVariantNotFound if no variant has it.
+	FindVariantBySKU(ctx context.Context, sku string) (productID, variantID string, err error)
+
+	// SKUCheck returns a check, run in the committing transaction, that
+	// fails with domain.ErrSKUTaken when a variant of a product other than
+	// productID has the SKU.
+	SKUCheck(sku, productID string) committer.Check
+
+	// FindPriceTransitions returns up to limit price transitions after the
+	// given one and at or before until, in (At, ProductID) order.
+	FindPriceTransitions(ctx context.Context, after PriceTransition, until time.Time, limit int) ([]PriceTransition, error)
+
+	// FindIDsWithoutPricePeriods returns up to limit IDs of products after
+	// the given ID that have no materialized price timeline, in product_id
+	// order.
+	FindIDsWithoutPricePeriods(ctx context.Context, after string, limit int) ([]string, error)
+
+	// FindIDsByCategory returns up to limit IDs of products in the given
+	// category, archived ones included, in product_id order.
+	FindIDsByCategory(ctx context.Context, category string, limit int) ([]string, error)
 }
 
+// PriceTransition is a point in time at which the effective price of a
+// product may change without a command: a discount starting, a discount
+// ending (the microsecond after its end date) or a scheduled price taking
+// effect. Transitions are ordered by At, then ProductID.
+type PriceTransition struct {
+	ProductID string
+	At        time.Time
+}
@@ -0,0 +1,48 @@
+package contracts
+
+import "context"
+
+// SearchHit is a product matching a search query.
+type SearchHit struct {
+	ProductID string
+	// Score is the relevance of the product; higher is better. Scores are
+	// only comparable within one search.
+	Score float64
+}
+
+// ProductSearcher finds products by keyword over their name, description
+// and category.
+type ProductSearcher interface {
+	// SearchProducts returns the active, unarchived products matching any
+	// word of query, most relevant first with ties broken by product_id,
+	// skipping offset and returning up to limit hits. more reports whether
+	// further hits exist. Results may briefly lag behind writes.
+	SearchProducts(ctx context.Context, query string, offset, limit int) (hits []SearchHit, more bool, err error)
+}
+
+// SuggestionKind is what a suggestion completes.
+type SuggestionKind string
+
+const (
+	SuggestionName     SuggestionKind = "name"
+	SuggestionCategory SuggestionKind = "category"
+)
+
+// Suggestion is a product name or category completing a prefix.
+type Suggestion struct {
+	Text string
+	Kind SuggestionKind
+	// ProductCount is the number of active products with this name or in
+	// this category.
+	ProductCount int
+	// Typos is the number of edits the prefix needed to match.
+	Typos int
+}
+
+// ProductSuggester completes prefixes typed into a search box.
+type ProductSuggester interface {
+	// SuggestProducts returns up to limit names and categories of active,
+	// unarchived products completing prefix, best first. Small typos are
+	// tolerated. Results may briefly lag behind writes.
+	SuggestProducts(ctx context.Context, prefix string, limit int) ([]Suggestion, error)
+}
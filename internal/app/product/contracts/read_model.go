@@ -2,8 +2,11 @@ package contracts
 
 import (
 	"context"
+	"errors"
 	"math/big"
 	"time"
+
+	"product-catalog-service/internal/pkg/filter"
 )
 
 // ProductRecord is a read-model representation of a product row.
@@ -14,30 +17,280 @@ type ProductRecord struct {
 	Description string
 	Category  string
 
-	BasePriceNumerator   int64
-	BasePriceDenominator int64
+	// BasePrice is the exact base price.
+	BasePrice *big.Rat
+	Currency  string
+
+	Discounts []DiscountRecord
+
+	// ScheduledPrices are future base prices not yet applied.
+	ScheduledPrices []ScheduledPriceRecord
+
+	// Attributes are the typed product attributes by key.
+	Attributes map[string]AttributeRecord
 
-	// DiscountPercent is expressed as a rational number (e.g. 20% == 20/100).
-	DiscountPercent *big.Rat
-	DiscountStart   *time.Time
-	DiscountEnd     *time.Time
+	// Variants are the sellable versions of the product ordered by ID.
+	Variants []VariantRecord
 
 	Status string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// ArchivedAt is nil unless the product is archived.
+	ArchivedAt *time.Time
+}
+
+// ProductFields selects the groups of ProductRecord fields a read fills in.
+// ProductID is always filled.
+type ProductFields uint8
+
+const (
+	ProductFieldName ProductFields = 1 << iota
+	ProductFieldDescription
+	ProductFieldCategory
+	ProductFieldStatus
+	// ProductFieldPricing covers BasePrice, Currency, Discounts and
+	// ScheduledPrices.
+	ProductFieldPricing
+	// ProductFieldTimestamps covers CreatedAt, UpdatedAt and ArchivedAt.
+	ProductFieldTimestamps
+	ProductFieldAttributes
+	ProductFieldVariants
+
+	AllProductFields = ProductFieldName | ProductFieldDescription | ProductFieldCategory |
+		ProductFieldStatus | ProductFieldPricing | ProductFieldTimestamps | ProductFieldAttributes |
+		ProductFieldVariants
+)
+
+// Has reports whether all of the given fields are selected.
+func (f ProductFields) Has(fields ProductFields) bool {
+	return f&fields == fields
+}
+
+// ProductFilter selects the products returned by ReadModel.ListProducts.
+type ProductFilter struct {
+	// Category limits the products to one category when set.
+	Category *string
+	// Categories limits the products to any of the given categories when
+	// not nil, e.g. a category and its descendants.
+	Categories []string
+	// Statuses limits the products to the given statuses; empty means any.
+	Statuses []string
+	// IncludeArchived returns archived products too; they are skipped
+	// otherwise, whatever Statuses says.
+	IncludeArchived bool
+	// ProductIDs limits the products to the given IDs when not nil.
+	ProductIDs []string
+	// Expr is an additional parsed filter expression; nil matches all.
+	Expr filter.Expr
+	// MinPrice and MaxPrice bound the effective price at AsOf, inclusive;
+	// nil means unbounded.
+	MinPrice *big.Rat
+	MaxPrice *big.Rat
+	// AsOf is the instant discount-dependent filters and prices are
+	// evaluated at.
+	AsOf time.Time
+}
+
+// ProductSortField is a field ReadModel.ListProducts can order by.
+type ProductSortField string
+
+const (
+	ProductSortID        ProductSortField = "product_id"
+	ProductSortName      ProductSortField = "name"
+	ProductSortCreatedAt ProductSortField = "created_at"
+	ProductSortUpdatedAt ProductSortField = "updated_at"
+	// ProductSortPrice orders by the effective price at the filter's AsOf.
+	ProductSortPrice ProductSortField = "price"
+)
+
+// ProductOrder is the order of ReadModel.ListProducts results. Ties are
+// broken by product_id in the same direction. The zero value orders by
+// product_id.
+type ProductOrder struct {
+	Field      ProductSortField
+	Descending bool
+}
+
+// String returns the order in AIP-132 form, e.g. "name desc".
+func (o ProductOrder) String() string {
+	field := o.Field
+	if field == "" {
+		field = ProductSortID
+	}
+	if o.Descending {
+		return string(field) + " desc"
+	}
+	return string(field)
+}
+
+// ErrInvalidPageToken is returned when a page token or cursor is
+// malformed, expired or was issued for a different listing.
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// ErrSnapshotExpired is returned when a later page of a listing is read
+// after the snapshot of its first page was garbage collected.
+var ErrSnapshotExpired = errors.New("listing snapshot expired")
+
+// ProductPage is a page of ReadModel.ListProducts results.
+type ProductPage struct {
+	Records []*ProductRecord
+	// NextCursor resumes the listing after Records; empty on the last page.
+	// It is an internal position to be wrapped in a page token.
+	NextCursor string
+	// ReadTime is the timestamp of the snapshot the page was read at.
+	ReadTime time.Time
+}
+
+// ProductFacets selects the facets counted by ReadModel.CountProductFacets.
+type ProductFacets struct {
+	Category bool
+	Status   bool
+	// Discount counts the products with and without a discount valid at
+	// the filter's AsOf.
+	Discount bool
+	// PriceBuckets are the ascending lower bounds of effective price
+	// buckets at the filter's AsOf; the last bucket is unbounded and
+	// cheaper products are not counted. Empty skips the price facet.
+	PriceBuckets []*big.Rat
+}
+
+// FacetCount is the number of products with a facet value.
+type FacetCount struct {
+	Value string
+	Count int64
+}
+
+// PriceBucketCount is the number of products with an effective price in
+// [From, To); To is nil for the last bucket.
+type PriceBucketCount struct {
+	From  *big.Rat
+	To    *big.Rat
+	Count int64
+}
+
+// FacetCounts are the counts of ReadModel.CountProductFacets. Only the
+// selected facets are filled in.
+type FacetCounts struct {
+	// Categories and Statuses are ordered by count, most first, then value.
+	Categories    []FacetCount
+	Statuses      []FacetCount
+	Discounted    int64
+	NotDiscounted int64
+	// PriceBuckets holds one entry per bucket, empty ones included.
+	PriceBuckets []PriceBucketCount
+}
+
+// DiscountRecord is a read-model representation of a product discount row.
+type DiscountRecord struct {
+	DiscountID string
+	// Kind is one of "percentage", "fixed_amount" or "price_override".
+	Kind string
+	// Percent is expressed as a rational number (e.g. 20% == 20/100).
+	// It is nil for amount-based kinds.
+	Percent *big.Rat
+	// Amount holds the amount off or the override price for amount-based
+	// kinds; nil otherwise.
+	Amount *big.Rat
+
+	Start     time.Time
+	End       time.Time
+	Priority  int
+	Exclusive bool
+}
+
+// ScheduledPriceRecord is a read-model representation of a scheduled
+// base price row.
+type ScheduledPriceRecord struct {
+	ScheduledPriceID string
+	Price            *big.Rat
+	EffectiveFrom    time.Time
+}
+
+// AttributeRecord is a read-model representation of a product attribute
+// row. Text holds string and enum values, Number number and measurement
+// values; Unit is only set for measurements.
+type AttributeRecord struct {
+	// Type is one of "string", "number", "bool", "enum" or "measurement".
+	Type   string
+	Text   string
+	Number *big.Rat
+	Bool   bool
+	Unit   string
+}
+
+// VariantRecord is a read-model representation of a product variant row.
+// PriceOverride is nil when the variant sells at the product price.
+type VariantRecord struct {
+	VariantID     string
+	SKU           string
+	Options       map[string]string
+	PriceOverride *big.Rat
+	// Status is "active" or "inactive".
+	Status string
+}
+
+// PriceHistoryRecord is a read-model representation of a price history
+// entry: the pricing state of a product from RecordedAt until the next entry.
+type PriceHistoryRecord struct {
+	EntryID    string
+	RecordedAt time.Time
+	// Reason lists the changed pricing fields, e.g. "base_price,discount".
+	Reason string
+	// Pricing holds the base price, currency, discounts and scheduled
+	// prices; other product fields are empty.
+	Pricing ProductRecord
 }
 
 // ReadModel defines interfaces for query-side data access.
 type ReadModel interface {
+	CategoryReadModel
+	RecategorizationReadModel
+
 	// GetProductByID returns a single product by ID or an error
-	// if it does not exist or the read fails.
-	GetProductByID(ctx context.Context, id string) (*ProductRecord, error)
+	// if it does not exist or the read fails. Only the selected fields
+	// are read.
+	GetProductByID(ctx context.Context, id string, fields ProductFields) (*ProductRecord, error)
+
+	// GetProductsByIDs returns the products with the given IDs in request
+	// order, read from one snapshot. records[i] is nil when ids[i] does not
+	// exist. Only the selected fields are read.
+	GetProductsByIDs(ctx context.Context, ids []string, fields ProductFields) (records []*ProductRecord, err error)
 
 	// ListActiveProducts returns active products, optionally filtered by category,
-	// using simple cursor-based pagination.
+	// using simple cursor-based pagination. Only the selected fields are read.
 	ListActiveProducts(
 		ctx context.Context,
 		category *string,
 		pageSize int,
 		pageToken string,
+		fields ProductFields,
 	) (records []*ProductRecord, nextPageToken string, err error)
+
+	// ListProducts returns a page of products matching the filter in any
+	// status and in the given order, starting after cursor (empty for the
+	// first page). Cursors carry the sort key and are only valid for the
+	// same order. A non-zero readTime reads the snapshot at that time, so
+	// all pages of a listing see the same data; ErrSnapshotExpired is
+	// returned once it is too old. Only the selected fields are read.
+	ListProducts(
+		ctx context.Context,
+		filter ProductFilter,
+		order ProductOrder,
+		pageSize int,
+		cursor string,
+		readTime time.Time,
+		fields ProductFields,
+	) (*ProductPage, error)
+
+	// CountProductFacets counts the products matching the filter, in any
+	// status, by the selected facets. A non-zero readTime counts at the
+	// snapshot of that time, like ListProducts.
+	CountProductFacets(ctx context.Context, filter ProductFilter, facets ProductFacets, readTime time.Time) (*FacetCounts, error)
+
+	// GetPriceHistory returns the price history entries of a product in
+	// force during [from, to], in recorded order. The first entry may have
+	// been recorded before from.
+	GetPriceHistory(ctx context.Context, productID string, from, to time.Time) ([]*PriceHistoryRecord, error)
 }
 
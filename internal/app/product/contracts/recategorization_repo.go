@@ -0,0 +1,59 @@
+package contracts
+
+import (
+	"context"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"product-catalog-service/internal/app/product/domain"
+)
+
+// RecategorizationRepo defines the write-side repository interface for
+// recategorization jobs. Implementations must return mutations instead of
+// applying them.
+type RecategorizationRepo interface {
+	// InsertMut returns a mutation to insert a new job.
+	// Returns nil if job is nil.
+	InsertMut(r *domain.Recategorization) *spanner.Mutation
+
+	// UpdateMut returns a mutation to update changed fields of a job.
+	// Returns nil if no changes are dirty.
+	UpdateMut(r *domain.Recategorization) *spanner.Mutation
+
+	// BatchMut returns a mutation recording the job's latest batch. The
+	// commit fails if that batch was recorded before, e.g. by another
+	// runner. Returns nil if the job has no batch.
+	BatchMut(r *domain.Recategorization, movedProducts int) *spanner.Mutation
+
+	// FindByID loads a job by ID.
+	// Returns domain.ErrRecategorizationNotFound if it does not exist.
+	FindByID(ctx context.Context, id string) (*domain.Recategorization, error)
+
+	// FindIDsByStatus returns the IDs of the jobs in the given status,
+	// oldest first.
+	FindIDsByStatus(ctx context.Context, status domain.RecategorizationStatus) ([]string, error)
+}
+
+// RecategorizationRecord is a read-model representation of a
+// recategorization job row.
+type RecategorizationRecord struct {
+	JobID         string
+	FromCategory  string
+	ToCategory    string
+	Status        string
+	Batches       int64
+	MovedProducts int64
+	// Failure is empty unless Status is "failed".
+	Failure   string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// FinishedAt is nil while the job is running.
+	FinishedAt *time.Time
+}
+
+// RecategorizationReadModel defines query-side access to recategorization jobs.
+type RecategorizationReadModel interface {
+	// GetRecategorization returns a job by ID or
+	// domain.ErrRecategorizationNotFound.
+	GetRecategorization(ctx context.Context, id string) (*RecategorizationRecord, error)
+}
@@ -0,0 +1,28 @@
+package contracts
+
+import (
+	"context"
+	"time"
+
+	"cloud.google.com/go/spanner"
+)
+
+// SweepCursor is the position of a background sweep: the last price
+// transition it committed.
+type SweepCursor struct {
+	Name      string
+	Position  PriceTransition
+	UpdatedAt time.Time
+}
+
+// SweepCursorRepo defines the repository interface for sweep cursors.
+// Implementations must return mutations instead of applying them.
+type SweepCursorRepo interface {
+	// SaveMut returns a mutation to store a cursor.
+	// Returns nil if cursor is nil.
+	SaveMut(c *SweepCursor) *spanner.Mutation
+
+	// FindByName loads a cursor by name.
+	// Returns nil and no error if the sweep never committed a position.
+	FindByName(ctx context.Context, name string) (*SweepCursor, error)
+}
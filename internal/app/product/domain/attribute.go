@@ -0,0 +1,208 @@
+package domain
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// AttributeType is the type of a product attribute value.
+type AttributeType string
+
+const (
+	AttributeString      AttributeType = "string"
+	AttributeNumber      AttributeType = "number"
+	AttributeBool        AttributeType = "bool"
+	AttributeEnum        AttributeType = "enum"
+	AttributeMeasurement AttributeType = "measurement"
+)
+
+// Limits on product attributes, matching the column sizes.
+const (
+	MaxAttributes           = 100
+	MaxAttributeKeyLength   = 64
+	MaxAttributeValueLength = 1000
+	MaxEnumValueLength      = 100
+	MaxAttributeUnitLength  = 16
+)
+
+// FieldAttributes is marked dirty whenever an attribute changes; each
+// changed attribute is also marked as AttributeField(key).
+const FieldAttributes = "attributes"
+
+var attributeKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// AttributeField returns the change tracking field of an attribute.
+func AttributeField(key string) string {
+	return FieldAttributes + "." + key
+}
+
+// ValidAttributeKey reports whether s can be used as an attribute key, e.g.
+// "color" or "screen_size".
+func ValidAttributeKey(s string) bool {
+	return len(s) <= MaxAttributeKeyLength && attributeKeyPattern.MatchString(s)
+}
+
+// AttributeValue is a typed attribute value. Strings and enum values are
+// held as text, numbers and measurements as exact decimals; measurements
+// also carry their unit, e.g. 42 "cm".
+type AttributeValue struct {
+	typ    AttributeType
+	text   string
+	number *big.Rat
+	flag   bool
+	unit   string
+}
+
+// NewStringAttribute creates a free-text attribute value.
+func NewStringAttribute(s string) (AttributeValue, error) {
+	if s == "" {
+		return AttributeValue{}, fmt.Errorf("%w: string value is required", ErrInvalidAttribute)
+	}
+	if len([]rune(s)) > MaxAttributeValueLength {
+		return AttributeValue{}, fmt.Errorf("%w: string value must be at most %d characters", ErrInvalidAttribute, MaxAttributeValueLength)
+	}
+	return AttributeValue{typ: AttributeString, text: s}, nil
+}
+
+// NewNumberAttribute creates a numeric attribute value.
+func NewNumberAttribute(n *big.Rat) (AttributeValue, error) {
+	if err := checkAttributeNumber(n); err != nil {
+		return AttributeValue{}, err
+	}
+	return AttributeValue{typ: AttributeNumber, number: new(big.Rat).Set(n)}, nil
+}
+
+// NewBoolAttribute creates a boolean attribute value.
+func NewBoolAttribute(b bool) AttributeValue {
+	return AttributeValue{typ: AttributeBool, flag: b}
+}
+
+// NewEnumAttribute creates an attribute value picked from a fixed set, e.g.
+// a size. The allowed values are not checked here.
+func NewEnumAttribute(v string) (AttributeValue, error) {
+	if strings.TrimSpace(v) == "" {
+		return AttributeValue{}, fmt.Errorf("%w: enum value is required", ErrInvalidAttribute)
+	}
+	if len([]rune(v)) > MaxEnumValueLength {
+		return AttributeValue{}, fmt.Errorf("%w: enum value must be at most %d characters", ErrInvalidAttribute, MaxEnumValueLength)
+	}
+	return AttributeValue{typ: AttributeEnum, text: v}, nil
+}
+
+// NewMeasurementAttribute creates a numeric attribute value with a unit.
+func NewMeasurementAttribute(n *big.Rat, unit string) (AttributeValue, error) {
+	if err := checkAttributeNumber(n); err != nil {
+		return AttributeValue{}, err
+	}
+	unit = strings.TrimSpace(unit)
+	if unit == "" {
+		return AttributeValue{}, fmt.Errorf("%w: measurement unit is required", ErrInvalidAttribute)
+	}
+	if len(unit) > MaxAttributeUnitLength {
+		return AttributeValue{}, fmt.Errorf("%w: measurement unit must be at most %d characters", ErrInvalidAttribute, MaxAttributeUnitLength)
+	}
+	return AttributeValue{typ: AttributeMeasurement, number: new(big.Rat).Set(n), unit: unit}, nil
+}
+
+// ParseAttribute creates an attribute value of the given type from its text
+// form: a decimal for numbers and measurements, "true" or "false" for bools.
+// unit is only used by measurements.
+func ParseAttribute(typ AttributeType, value, unit string) (AttributeValue, error) {
+	switch typ {
+	case AttributeString:
+		return NewStringAttribute(value)
+	case AttributeEnum:
+		return NewEnumAttribute(value)
+	case AttributeBool:
+		switch value {
+		case "true":
+			return NewBoolAttribute(true), nil
+		case "false":
+			return NewBoolAttribute(false), nil
+		}
+		return AttributeValue{}, fmt.Errorf("%w: bool value must be true or false, got %q", ErrInvalidAttribute, value)
+	case AttributeNumber, AttributeMeasurement:
+		n, ok := new(big.Rat).SetString(strings.TrimSpace(value))
+		if !ok {
+			return AttributeValue{}, fmt.Errorf("%w: invalid number %q", ErrInvalidAttribute, value)
+		}
+		if typ == AttributeNumber {
+			return NewNumberAttribute(n)
+		}
+		return NewMeasurementAttribute(n, unit)
+	default:
+		return AttributeValue{}, fmt.Errorf("%w: unknown type %q", ErrInvalidAttribute, typ)
+	}
+}
+
+func (v AttributeValue) Type() AttributeType { return v.typ }
+
+// Text returns the value of a string or enum attribute.
+func (v AttributeValue) Text() string { return v.text }
+
+// Number returns a copy of the value of a number or measurement attribute,
+// nil for other types.
+func (v AttributeValue) Number() *big.Rat {
+	if v.number == nil {
+		return nil
+	}
+	return new(big.Rat).Set(v.number)
+}
+
+// Bool returns the value of a bool attribute.
+func (v AttributeValue) Bool() bool { return v.flag }
+
+// Unit returns the unit of a measurement attribute.
+func (v AttributeValue) Unit() string { return v.unit }
+
+// String returns the value in text form, e.g. "red", "42 cm" or "true".
+func (v AttributeValue) String() string {
+	switch v.typ {
+	case AttributeNumber:
+		return ExactString(v.number)
+	case AttributeMeasurement:
+		return ExactString(v.number) + " " + v.unit
+	case AttributeBool:
+		if v.flag {
+			return "true"
+		}
+		return "false"
+	default:
+		return v.text
+	}
+}
+
+// Equal reports whether both values have the same type and value.
+func (v AttributeValue) Equal(other AttributeValue) bool {
+	if v.typ != other.typ || v.text != other.text || v.flag != other.flag || v.unit != other.unit {
+		return false
+	}
+	if v.number == nil || other.number == nil {
+		return v.number == other.number
+	}
+	return v.number.Cmp(other.number) == 0
+}
+
+// checkAttributeNumber rejects numbers that cannot be stored exactly.
+func checkAttributeNumber(n *big.Rat) error {
+	if n == nil {
+		return fmt.Errorf("%w: number value is required", ErrInvalidAttribute)
+	}
+	if err := CheckDecimalScale(n); err != nil {
+		return fmt.Errorf("%w: number must have at most %d integer digits and %d decimal places", ErrInvalidAttribute, MaxIntegerDigits, MaxScale)
+	}
+	return nil
+}
+
+// sortedAttributeKeys returns the keys of attrs in order.
+func sortedAttributeKeys(attrs map[string]AttributeValue) []string {
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MaxAllowedValues bounds the values of an enum attribute definition.
+const MaxAllowedValues = 200
+
+// FieldAttributeSchema is marked dirty whenever a category's attribute
+// schema changes; each changed definition is also marked as
+// AttributeSchemaField(key).
+const FieldAttributeSchema = "attribute_schema"
+
+// AttributeSchemaField returns the change tracking field of an attribute
+// definition.
+func AttributeSchemaField(key string) string {
+	return FieldAttributeSchema + "." + key
+}
+
+// AttributeDefinition declares an attribute of the products in a category:
+// its type, whether products must set it and, for enums, the values they
+// may pick from.
+type AttributeDefinition struct {
+	key           string
+	typ           AttributeType
+	required      bool
+	allowedValues []string
+}
+
+// NewAttributeDefinition creates an attribute definition. Enums need at
+// least one allowed value; other types take none.
+func NewAttributeDefinition(key string, typ AttributeType, required bool, allowedValues []string) (AttributeDefinition, error) {
+	if !ValidAttributeKey(key) {
+		return AttributeDefinition{}, fmt.Errorf("%w: key %q must be lowercase letters, digits and underscores starting with a letter, at most %d characters",
+			ErrInvalidCategory, key, MaxAttributeKeyLength)
+	}
+	switch typ {
+	case AttributeString, AttributeNumber, AttributeBool, AttributeMeasurement:
+		if len(allowedValues) > 0 {
+			return AttributeDefinition{}, fmt.Errorf("%w: %q: only enum attributes take allowed values", ErrInvalidCategory, key)
+		}
+	case AttributeEnum:
+		if len(allowedValues) == 0 {
+			return AttributeDefinition{}, fmt.Errorf("%w: %q: enum attributes need allowed values", ErrInvalidCategory, key)
+		}
+		if len(allowedValues) > MaxAllowedValues {
+			return AttributeDefinition{}, fmt.Errorf("%w: %q: at most %d allowed values", ErrInvalidCategory, key, MaxAllowedValues)
+		}
+	default:
+		return AttributeDefinition{}, fmt.Errorf("%w: %q: unknown type %q", ErrInvalidCategory, key, typ)
+	}
+
+	seen := make(map[string]bool, len(allowedValues))
+	values := make([]string, 0, len(allowedValues))
+	for _, v := range allowedValues {
+		if _, err := NewEnumAttribute(v); err != nil {
+			return AttributeDefinition{}, fmt.Errorf("%w: %q: allowed value %q must be 1 to %d characters", ErrInvalidCategory, key, v, MaxEnumValueLength)
+		}
+		if seen[v] {
+			return AttributeDefinition{}, fmt.Errorf("%w: %q: allowed value %q is listed twice", ErrInvalidCategory, key, v)
+		}
+		seen[v] = true
+		values = append(values, v)
+	}
+
+	return AttributeDefinition{key: key, typ: typ, required: required, allowedValues: values}, nil
+}
+
+func (d AttributeDefinition) Key() string         { return d.key }
+func (d AttributeDefinition) Type() AttributeType { return d.typ }
+func (d AttributeDefinition) Required() bool      { return d.required }
+
+// AllowedValues returns a copy of the values an enum attribute may take,
+// in the order they were declared.
+func (d AttributeDefinition) AllowedValues() []string {
+	out := make([]string, len(d.allowedValues))
+	copy(out, d.allowedValues)
+	return out
+}
+
+// Equal reports whether both definitions declare the same attribute.
+func (d AttributeDefinition) Equal(other AttributeDefinition) bool {
+	if d.key != other.key || d.typ != other.typ || d.required != other.required ||
+		len(d.allowedValues) != len(other.allowedValues) {
+		return false
+	}
+	for i := range d.allowedValues {
+		if d.allowedValues[i] != other.allowedValues[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// violation describes how v breaks the definition, or returns "" when it
+// conforms.
+func (d AttributeDefinition) violation(v AttributeValue) string {
+	if v.Type() != d.typ {
+		return fmt.Sprintf("must be of type %s, got %s", d.typ, v.Type())
+	}
+	if d.typ != AttributeEnum {
+		return ""
+	}
+	for _, allowed := range d.allowedValues {
+		if v.Text() == allowed {
+			return ""
+		}
+	}
+	return fmt.Sprintf("must be one of %s, got %q", strings.Join(d.allowedValues, ", "), v.Text())
+}
+
+// AttributeViolation is a field-level problem with a product attribute.
+// Field is the attribute's field path, e.g. "attributes.size".
+type AttributeViolation struct {
+	Field       string
+	Description string
+}
+
+// AttributeSchemaError lists every attribute of a product that does not
+// match its category's schema. It matches ErrAttributeSchemaViolation.
+type AttributeSchemaError struct {
+	Category   string
+	Violations []AttributeViolation
+}
+
+func (e *AttributeSchemaError) Error() string {
+	parts := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		parts[i] = v.Field + " " + v.Description
+	}
+	return fmt.Sprintf("%v for category %q: %s", ErrAttributeSchemaViolation, e.Category, strings.Join(parts, "; "))
+}
+
+func (e *AttributeSchemaError) Unwrap() error { return ErrAttributeSchemaViolation }
+
+// sortDefinitions orders definitions by key.
+func sortDefinitions(defs []AttributeDefinition) {
+	sort.Slice(defs, func(i, j int) bool { return defs[i].key < defs[j].key })
+}
@@ -0,0 +1,354 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// MaxCategoryNameLength and MaxCategorySlugLength bound category names and
+// slugs, matching the column sizes.
+const (
+	MaxCategoryNameLength = 100
+	MaxCategorySlugLength = 100
+)
+
+// Field names for category change tracking. FieldName is shared with Product.
+const (
+	FieldParentID  = "parent_id"
+	FieldSortOrder = "sort_order"
+)
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// Category is the aggregate root for the category tree. Products reference
+// a category by its slug, which never changes.
+type Category struct {
+	id        string
+	name      string
+	slug      string
+	parentID  string
+	sortOrder int64
+	// attributeSchema is sorted by key.
+	attributeSchema []AttributeDefinition
+
+	createdAt time.Time
+	updatedAt time.Time
+
+	changes *ChangeTracker
+	events  []DomainEvent
+}
+
+// NewCategory constructs a new Category. An empty slug is derived from the
+// name. parentID is empty for root categories; the caller checks it exists.
+func NewCategory(id, name, slug, parentID string, sortOrder int64, now time.Time) (*Category, error) {
+	name = strings.TrimSpace(name)
+	if err := validateCategoryName(name); err != nil {
+		return nil, err
+	}
+	if slug == "" {
+		slug = Slugify(name)
+	}
+	if !ValidSlug(slug) {
+		return nil, fmt.Errorf("%w: slug must be lowercase letters, digits and single dashes, at most %d characters", ErrInvalidCategory, MaxCategorySlugLength)
+	}
+	if parentID == id {
+		return nil, ErrCategoryCycle
+	}
+
+	c := &Category{
+		id:        id,
+		name:      name,
+		slug:      slug,
+		parentID:  parentID,
+		sortOrder: sortOrder,
+		createdAt: now,
+		updatedAt: now,
+		changes:   NewChangeTracker(),
+	}
+
+	c.events = append(c.events, CategoryCreatedEvent{
+		baseEvent:  baseEvent{occurredAt: now},
+		CategoryID: c.id,
+		Slug:       c.slug,
+		ParentID:   c.parentID,
+	})
+
+	return c, nil
+}
+
+// RehydrateCategory reconstructs a Category from persisted state.
+// It does not emit events or mark fields as dirty.
+func RehydrateCategory(
+	id string,
+	name string,
+	slug string,
+	parentID string,
+	sortOrder int64,
+	attributeSchema []AttributeDefinition,
+	createdAt time.Time,
+	updatedAt time.Time,
+) *Category {
+	schema := make([]AttributeDefinition, len(attributeSchema))
+	copy(schema, attributeSchema)
+	sortDefinitions(schema)
+	return &Category{
+		id:              id,
+		name:            name,
+		slug:            slug,
+		parentID:        parentID,
+		sortOrder:       sortOrder,
+		attributeSchema: schema,
+		createdAt:       createdAt,
+		updatedAt:       updatedAt,
+		changes:         NewChangeTracker(),
+	}
+}
+
+func (c *Category) ID() string           { return c.id }
+func (c *Category) Name() string         { return c.name }
+func (c *Category) Slug() string         { return c.slug }
+func (c *Category) ParentID() string     { return c.parentID }
+func (c *Category) SortOrder() int64     { return c.sortOrder }
+func (c *Category) CreatedAt() time.Time { return c.createdAt }
+func (c *Category) UpdatedAt() time.Time { return c.updatedAt }
+
+func (c *Category) Changes() *ChangeTracker { return c.changes }
+
+// AttributeSchema returns a copy of the category's attribute definitions,
+// sorted by key.
+func (c *Category) AttributeSchema() []AttributeDefinition {
+	out := make([]AttributeDefinition, len(c.attributeSchema))
+	copy(out, c.attributeSchema)
+	return out
+}
+
+// AttributeDefinition returns the definition of the attribute with the given
+// key.
+func (c *Category) AttributeDefinition(key string) (AttributeDefinition, bool) {
+	for _, d := range c.attributeSchema {
+		if d.key == key {
+			return d, true
+		}
+	}
+	return AttributeDefinition{}, false
+}
+
+// UpdateDetails changes the name and sort order. An empty name keeps the
+// current one.
+func (c *Category) UpdateDetails(name string, sortOrder int64, now time.Time) error {
+	changed := false
+
+	name = strings.TrimSpace(name)
+	if name != "" && name != c.name {
+		if err := validateCategoryName(name); err != nil {
+			return err
+		}
+		c.name = name
+		c.changes.MarkDirty(FieldName)
+		changed = true
+	}
+	if sortOrder != c.sortOrder {
+		c.sortOrder = sortOrder
+		c.changes.MarkDirty(FieldSortOrder)
+		changed = true
+	}
+
+	if changed {
+		c.recordUpdate(now)
+	}
+	return nil
+}
+
+// SetAttributeSchema replaces the category's attribute definitions. Only
+// definitions that are added, changed or removed are marked dirty. Products
+// already in the category are checked against the new schema the next time
+// they are changed or activated.
+func (c *Category) SetAttributeSchema(defs []AttributeDefinition, now time.Time) error {
+	if len(defs) > MaxAttributes {
+		return fmt.Errorf("%w: a category declares at most %d attributes", ErrInvalidCategory, MaxAttributes)
+	}
+	next := make(map[string]AttributeDefinition, len(defs))
+	for _, d := range defs {
+		if d.key == "" {
+			return fmt.Errorf("%w: attribute definition without key", ErrInvalidCategory)
+		}
+		if _, ok := next[d.key]; ok {
+			return fmt.Errorf("%w: attribute %q is declared twice", ErrInvalidCategory, d.key)
+		}
+		next[d.key] = d
+	}
+
+	changed := false
+	for _, old := range c.attributeSchema {
+		if d, ok := next[old.key]; !ok || !d.Equal(old) {
+			c.changes.MarkDirty(AttributeSchemaField(old.key))
+			changed = true
+		}
+	}
+	for _, d := range defs {
+		if _, ok := c.AttributeDefinition(d.key); !ok {
+			c.changes.MarkDirty(AttributeSchemaField(d.key))
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	schema := make([]AttributeDefinition, len(defs))
+	copy(schema, defs)
+	sortDefinitions(schema)
+	c.attributeSchema = schema
+	c.changes.MarkDirty(FieldAttributeSchema)
+	c.recordUpdate(now)
+	return nil
+}
+
+// ValidateAttributes checks product attributes against the category's
+// schema: required attributes must be set and declared attributes must
+// have the declared type and, for enums, an allowed value. Attributes the
+// schema does not declare are accepted. All violations are reported
+// together in an *AttributeSchemaError, ordered by key.
+func (c *Category) ValidateAttributes(attrs map[string]AttributeValue) error {
+	var violations []AttributeViolation
+	for _, d := range c.attributeSchema {
+		v, ok := attrs[d.key]
+		if !ok {
+			if d.required {
+				violations = append(violations, AttributeViolation{Field: AttributeField(d.key), Description: "is required"})
+			}
+			continue
+		}
+		if msg := d.violation(v); msg != "" {
+			violations = append(violations, AttributeViolation{Field: AttributeField(d.key), Description: msg})
+		}
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	return &AttributeSchemaError{Category: c.slug, Violations: violations}
+}
+
+// recordUpdate sets updatedAt and adds a CategoryUpdatedEvent unless one is
+// already pending, so a command raises a single event.
+func (c *Category) recordUpdate(now time.Time) {
+	c.updatedAt = now
+	for _, e := range c.events {
+		switch e.(type) {
+		case CategoryCreatedEvent, CategoryUpdatedEvent:
+			return
+		}
+	}
+	c.events = append(c.events, CategoryUpdatedEvent{
+		baseEvent:  baseEvent{occurredAt: now},
+		CategoryID: c.id,
+	})
+}
+
+// MoveTo re-parents the category. parentID is empty to make it a root;
+// ancestors are the IDs of the new parent's ancestors, nearest first, used
+// to reject moving a category under itself or one of its descendants.
+func (c *Category) MoveTo(parentID string, ancestors []string, now time.Time) error {
+	if parentID == c.parentID {
+		return nil
+	}
+	if err := c.ValidateParent(parentID, ancestors); err != nil {
+		return err
+	}
+
+	old := c.parentID
+	c.parentID = parentID
+	c.updatedAt = now
+	c.changes.MarkDirty(FieldParentID)
+	c.events = append(c.events, CategoryMovedEvent{
+		baseEvent:   baseEvent{occurredAt: now},
+		CategoryID:  c.id,
+		OldParentID: old,
+		NewParentID: parentID,
+	})
+	return nil
+}
+
+// ValidateParent checks that parentID, whose ancestors are given nearest
+// first, is neither the category itself nor one of its descendants.
+func (c *Category) ValidateParent(parentID string, ancestors []string) error {
+	if parentID == c.id {
+		return ErrCategoryCycle
+	}
+	for _, id := range ancestors {
+		if id == c.id {
+			return ErrCategoryCycle
+		}
+	}
+	return nil
+}
+
+// Delete records the category's removal. The caller checks it has no
+// children and no products.
+func (c *Category) Delete(now time.Time) {
+	c.updatedAt = now
+	c.events = append(c.events, CategoryDeletedEvent{
+		baseEvent:  baseEvent{occurredAt: now},
+		CategoryID: c.id,
+		Slug:       c.slug,
+	})
+}
+
+// DomainEvents returns a copy of pending events.
+func (c *Category) DomainEvents() []DomainEvent {
+	out := make([]DomainEvent, len(c.events))
+	copy(out, c.events)
+	return out
+}
+
+// ClearDomainEvents removes all pending events. Usually called after persistence.
+func (c *Category) ClearDomainEvents() {
+	c.events = nil
+}
+
+// ValidSlug reports whether s can be used as a category slug.
+func ValidSlug(s string) bool {
+	return len(s) <= MaxCategorySlugLength && slugPattern.MatchString(s)
+}
+
+// Slugify derives a slug from a category name: ASCII letters and digits are
+// lowercased, apostrophes are dropped and every other run of characters
+// becomes a single dash, e.g. "Men's Home & Garden" becomes
+// "mens-home-garden". The result is empty when the name has no ASCII
+// letters or digits; such names need an explicit slug.
+func Slugify(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		if r == '\'' || r == '’' {
+			continue
+		}
+		dash = true
+	}
+	s := b.String()
+	if len(s) > MaxCategorySlugLength {
+		s = strings.TrimRight(s[:MaxCategorySlugLength], "-")
+	}
+	return s
+}
+
+func validateCategoryName(name string) error {
+	if name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidCategory)
+	}
+	if len([]rune(name)) > MaxCategoryNameLength {
+		return fmt.Errorf("%w: name must be at most %d characters", ErrInvalidCategory, MaxCategoryNameLength)
+	}
+	return nil
+}
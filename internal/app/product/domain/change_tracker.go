@@ -1,5 +1,10 @@
 package domain
 
+import (
+	"sort"
+	"strings"
+)
+
 // ChangeTracker tracks which fields of an aggregate have been modified.
 // It is used by repositories to build targeted update mutations.
 type ChangeTracker struct {
@@ -26,6 +31,21 @@ func (ct *ChangeTracker) Dirty(field string) bool {
 	return ct.dirtyFields[field]
 }
 
+// DirtyWithPrefix returns the dirty fields starting with prefix, sorted.
+func (ct *ChangeTracker) DirtyWithPrefix(prefix string) []string {
+	if ct == nil {
+		return nil
+	}
+	var fields []string
+	for field, dirty := range ct.dirtyFields {
+		if dirty && strings.HasPrefix(field, prefix) {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
 // Clear resets all dirty flags. Typically called after a successful commit.
 func (ct *ChangeTracker) Clear() {
 	if ct == nil {
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Currency is an ISO 4217 currency code, e.g. "USD".
+type Currency string
+
+// DefaultCurrency is used for prices stored without an explicit currency.
+const DefaultCurrency Currency = "USD"
+
+// currencyMinorUnits maps supported currencies to the number of digits
+// after the decimal separator of their minor unit.
+var currencyMinorUnits = map[Currency]int{
+	"USD": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"CHF": 2,
+	"CAD": 2,
+	"AUD": 2,
+	"SEK": 2,
+	"PLN": 2,
+	"CNY": 2,
+	"INR": 2,
+	"JPY": 0,
+	"KRW": 0,
+	"BHD": 3,
+	"KWD": 3,
+	"JOD": 3,
+}
+
+// ParseCurrency validates a currency code. An empty code yields DefaultCurrency.
+func ParseCurrency(code string) (Currency, error) {
+	if code == "" {
+		return DefaultCurrency, nil
+	}
+	c := Currency(strings.ToUpper(code))
+	if _, ok := currencyMinorUnits[c]; !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedCurrency, code)
+	}
+	return c, nil
+}
+
+// MinorUnits returns the number of decimal digits of the currency minor unit.
+// Unknown currencies fall back to 2 digits.
+func (c Currency) MinorUnits() int {
+	if n, ok := currencyMinorUnits[c]; ok {
+		return n
+	}
+	return 2
+}
+
+// RoundingMode defines how exact prices are rounded to minor units.
+type RoundingMode string
+
+const (
+	// RoundHalfEven rounds to the nearest minor unit, ties to even (banker's rounding).
+	RoundHalfEven RoundingMode = "half_even"
+	// RoundHalfUp rounds to the nearest minor unit, ties away from zero.
+	RoundHalfUp RoundingMode = "half_up"
+	// RoundDown truncates towards zero.
+	RoundDown RoundingMode = "down"
+)
@@ -6,20 +6,61 @@ import (
 	"time"
 )
 
-// Discount is a value object that represents a percentage-based
-// discount with a validity period.
+// DiscountKind defines how a discount changes the price.
+type DiscountKind string
+
+const (
+	// DiscountKindPercentage takes a percentage off the price.
+	DiscountKindPercentage DiscountKind = "percentage"
+	// DiscountKindFixedAmount takes a fixed amount off the price (e.g. 5 off).
+	DiscountKindFixedAmount DiscountKind = "fixed_amount"
+	// DiscountKindPriceOverride sets the price to a fixed amount.
+	DiscountKindPriceOverride DiscountKind = "price_override"
+)
+
+// DiscountField returns the change tracking field of a discount.
+func DiscountField(id string) string {
+	return FieldDiscount + "." + id
+}
+
+// Discount is a value object that represents a discount with a validity
+// period. It is either percentage-based, a fixed amount off, or a price
+// override.
 //
 // Percentage is represented as a rational number, e.g. 20% == 20/100.
+//
+// A product may hold several discounts at once. Priority decides the
+// order in which they are considered (higher first) and an exclusive
+// discount is never combined with any other discount.
 type Discount struct {
+	id         string
+	kind       DiscountKind
 	percentage *big.Rat
+	amount     *Money
 	startAt    time.Time
 	endAt      time.Time
+	priority   int
+	exclusive  bool
 }
 
 // NewDiscount creates a discount with the given percentage and time window.
 // Percentage must be between 0 and 1 inclusive.
 // start must be before end.
+//
+// The discount is stackable, has priority 0 and no identifier.
 func NewDiscount(percentage *big.Rat, start, end time.Time) (*Discount, error) {
+	return NewStackedDiscount("", percentage, start, end, 0, false)
+}
+
+// NewStackedDiscount creates an identified discount that takes part in
+// discount combination with the given priority and exclusivity.
+func NewStackedDiscount(
+	id string,
+	percentage *big.Rat,
+	start, end time.Time,
+	priority int,
+	exclusive bool,
+) (*Discount, error) {
 	if percentage == nil {
 		return nil, fmt.Errorf("discount percentage is required")
 	}
@@ -35,13 +76,125 @@ func NewDiscount(percentage *big.Rat, start, end time.Time) (*Discount, error) {
 	}
 
 	return &Discount{
+		id:         id,
+		kind:       DiscountKindPercentage,
 		percentage: new(big.Rat).Set(percentage),
 		startAt:    start,
 		endAt:      end,
+		priority:   priority,
+		exclusive:  exclusive,
+	}, nil
+}
+
+// NewFixedAmountDiscount creates a discount that takes amount off the price.
+// Amount must be greater than 0.
+func NewFixedAmountDiscount(
+	id string,
+	amount *Money,
+	start, end time.Time,
+	priority int,
+	exclusive bool,
+) (*Discount, error) {
+	if amount == nil {
+		return nil, fmt.Errorf("discount amount is required")
+	}
+	if amount.Rat().Sign() <= 0 {
+		return nil, fmt.Errorf("discount amount must be > 0")
+	}
+	return newAmountDiscount(id, DiscountKindFixedAmount, amount, start, end, priority, exclusive)
+}
+
+// NewPriceOverrideDiscount creates a discount that sets the price to price.
+// Price must not be negative.
+func NewPriceOverrideDiscount(
+	id string,
+	price *Money,
+	start, end time.Time,
+	priority int,
+	exclusive bool,
+) (*Discount, error) {
+	if price == nil {
+		return nil, fmt.Errorf("override price is required")
+	}
+	if price.Rat().Sign() < 0 {
+		return nil, fmt.Errorf("override price must be >= 0")
+	}
+	return newAmountDiscount(id, DiscountKindPriceOverride, price, start, end, priority, exclusive)
+}
+
+// RehydrateDiscount reconstructs a discount of any kind from persisted state.
+// percentage is used by percentage discounts, amount by the other kinds.
+func RehydrateDiscount(
+	id string,
+	kind DiscountKind,
+	percentage *big.Rat,
+	amount *Money,
+	start, end time.Time,
+	priority int,
+	exclusive bool,
+) (*Discount, error) {
+	switch kind {
+	case DiscountKindPercentage, "":
+		return NewStackedDiscount(id, percentage, start, end, priority, exclusive)
+	case DiscountKindFixedAmount:
+		return NewFixedAmountDiscount(id, amount, start, end, priority, exclusive)
+	case DiscountKindPriceOverride:
+		return NewPriceOverrideDiscount(id, amount, start, end, priority, exclusive)
+	default:
+		return nil, fmt.Errorf("unknown discount kind: %s", kind)
+	}
+}
+
+func newAmountDiscount(
+	id string,
+	kind DiscountKind,
+	amount *Money,
+	start, end time.Time,
+	priority int,
+	exclusive bool,
+) (*Discount, error) {
+	if end.Before(start) {
+		return nil, ErrInvalidDiscountPeriod
+	}
+
+	return &Discount{
+		id:        id,
+		kind:      kind,
+		amount:    NewMoneyFromRat(amount.Rat()),
+		startAt:   start,
+		endAt:     end,
+		priority:  priority,
+		exclusive: exclusive,
 	}, nil
 }
 
+// ID returns the discount identifier within its product.
+func (d *Discount) ID() string {
+	if d == nil {
+		return ""
+	}
+	return d.id
+}
+
+// Kind returns how the discount changes the price.
+func (d *Discount) Kind() DiscountKind {
+	if d == nil {
+		return ""
+	}
+	return d.kind
+}
+
+// Amount returns the amount off for fixed-amount discounts or the target
+// price for overrides. It is nil for percentage discounts.
+func (d *Discount) Amount() *Money {
+	if d == nil || d.amount == nil {
+		return nil
+	}
+	return NewMoneyFromRat(d.amount.Rat())
+}
+
 // Percentage returns an immutable copy of the percentage.
+// It is nil for fixed-amount and override discounts.
 func (d *Discount) Percentage() *big.Rat {
 	if d == nil || d.percentage == nil {
 		return nil
@@ -65,6 +218,22 @@ func (d *Discount) EndAt() time.Time {
 	return d.endAt
 }
 
+// Priority returns the discount priority. Higher values win.
+func (d *Discount) Priority() int {
+	if d == nil {
+		return 0
+	}
+	return d.priority
+}
+
+// Exclusive reports whether the discount must be applied alone.
+func (d *Discount) Exclusive() bool {
+	if d == nil {
+		return false
+	}
+	return d.exclusive
+}
+
 // IsValidAt returns true if the discount is valid at the given time.
 func (d *Discount) IsValidAt(t time.Time) bool {
 	if d == nil {
@@ -78,4 +247,3 @@ func (d *Discount) IsValidAt(t time.Time) bool {
 	}
 	return true
 }
-
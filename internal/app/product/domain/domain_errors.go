@@ -5,7 +5,28 @@ import "errors"
 // Domain error placeholders.
 
 var (
-	ErrProductNotActive      = errors.New("product not active")
-	ErrInvalidDiscountPeriod = errors.New("invalid discount period")
+	ErrProductNotActive         = errors.New("product not active")
+	ErrInvalidDiscountPeriod    = errors.New("invalid discount period")
+	ErrDiscountNotFound         = errors.New("discount not found")
+	ErrUnsupportedCurrency      = errors.New("unsupported currency")
+	ErrMoneyOverflow            = errors.New("money amount out of range")
+	ErrPriceScale               = errors.New("money amount has too many decimal places")
+	ErrProductArchived          = errors.New("product archived")
+	ErrInvalidPriceSchedule     = errors.New("invalid price schedule")
+	ErrScheduledPriceNotFound   = errors.New("scheduled price not found")
+	ErrCategoryNotFound         = errors.New("category not found")
+	ErrInvalidCategory          = errors.New("invalid category")
+	ErrUnknownCategory          = errors.New("unknown category")
+	ErrCategoryCycle            = errors.New("category cannot be moved under itself")
+	ErrCategoryInUse            = errors.New("category has subcategories or products")
+	ErrCategorySlugTaken        = errors.New("category slug already in use")
+	ErrInvalidRecategorization  = errors.New("invalid recategorization")
+	ErrRecategorizationNotFound = errors.New("recategorization job not found")
+	ErrRecategorizationFinished = errors.New("recategorization job finished")
+	ErrInvalidAttribute         = errors.New("invalid attribute")
+	ErrAttributeSchemaViolation = errors.New("attributes do not match category schema")
+	ErrInvalidVariant           = errors.New("invalid variant")
+	ErrVariantNotFound          = errors.New("variant not found")
+	ErrSKUTaken                 = errors.New("sku already in use")
 )
 
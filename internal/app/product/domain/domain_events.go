@@ -44,12 +44,156 @@ type ProductDeactivatedEvent struct {
 // DiscountAppliedEvent is raised when a discount is added or changed.
 type DiscountAppliedEvent struct {
 	baseEvent
-	ProductID string
+	ProductID  string
+	DiscountID string
 }
 
-// DiscountRemovedEvent is raised when the product discount is removed.
+// DiscountRemovedEvent is raised when a product discount is removed.
+// An empty DiscountID means all discounts were cleared.
 type DiscountRemovedEvent struct {
+	baseEvent
+	ProductID  string
+	DiscountID string
+}
+
+// DiscountExpiredEvent is raised when a discount whose window has ended
+// is cleared from the product.
+type DiscountExpiredEvent struct {
+	baseEvent
+	ProductID  string
+	DiscountID string
+}
+
+// EffectivePriceChangedEvent is raised when the effective price changes
+// without a price command, e.g. when a discount window starts or ends.
+// Prices are exact decimal strings (see Money.String).
+type EffectivePriceChangedEvent struct {
+	baseEvent
+	ProductID string
+	Currency  string
+	OldPrice  string
+	NewPrice  string
+}
+
+// ScheduledPriceAddedEvent is raised when a future base price is scheduled
+// or rescheduled.
+type ScheduledPriceAddedEvent struct {
+	baseEvent
+	ProductID        string
+	ScheduledPriceID string
+	Price            string
+	EffectiveFrom    time.Time
+}
+
+// ScheduledPriceCancelledEvent is raised when a scheduled base price is
+// cancelled before taking effect.
+type ScheduledPriceCancelledEvent struct {
+	baseEvent
+	ProductID        string
+	ScheduledPriceID string
+}
+
+// ScheduledPriceEffectiveEvent is raised when a scheduled base price takes
+// effect and replaces the base price.
+type ScheduledPriceEffectiveEvent struct {
+	baseEvent
+	ProductID        string
+	ScheduledPriceID string
+	Currency         string
+	OldPrice         string
+	NewPrice         string
+	EffectiveFrom    time.Time
+}
+
+// VariantAddedEvent is raised when a variant is added to a product.
+// PriceOverride is empty when the variant sells at the product price.
+type VariantAddedEvent struct {
+	baseEvent
+	ProductID     string
+	VariantID     string
+	SKU           string
+	Options       map[string]string
+	PriceOverride string
+	Status        string
+}
+
+// VariantUpdatedEvent is raised when a variant's SKU, options, price
+// override or status changes. It carries the variant's new state.
+type VariantUpdatedEvent struct {
+	baseEvent
+	ProductID     string
+	VariantID     string
+	SKU           string
+	Options       map[string]string
+	PriceOverride string
+	Status        string
+}
+
+// VariantRemovedEvent is raised when a variant is removed from a product.
+type VariantRemovedEvent struct {
 	baseEvent
 	ProductID string
+	VariantID string
+	SKU       string
+}
+
+// CategoryCreatedEvent is raised when a category is created.
+type CategoryCreatedEvent struct {
+	baseEvent
+	CategoryID string
+	Slug       string
+	ParentID   string
+}
+
+// CategoryUpdatedEvent is raised when a category's name, sort order or
+// attribute schema changes.
+type CategoryUpdatedEvent struct {
+	baseEvent
+	CategoryID string
+}
+
+// CategoryMovedEvent is raised when a category gets a new parent. An empty
+// parent ID means the root.
+type CategoryMovedEvent struct {
+	baseEvent
+	CategoryID  string
+	OldParentID string
+	NewParentID string
 }
 
+// CategoryDeletedEvent is raised when a category is deleted.
+type CategoryDeletedEvent struct {
+	baseEvent
+	CategoryID string
+	Slug       string
+}
+
+// RecategorizationStartedEvent is raised when a job moving every product of
+// a category to another is started.
+type RecategorizationStartedEvent struct {
+	baseEvent
+	JobID        string
+	FromCategory string
+	ToCategory   string
+}
+
+// RecategorizationCompletedEvent is raised when no product is left in the
+// source category of a recategorization job.
+type RecategorizationCompletedEvent struct {
+	baseEvent
+	JobID         string
+	FromCategory  string
+	ToCategory    string
+	MovedProducts int64
+}
+
+// RecategorizationFailedEvent is raised when a recategorization job stops
+// before every product is moved.
+type RecategorizationFailedEvent struct {
+	baseEvent
+	JobID         string
+	FromCategory  string
+	ToCategory    string
+	MovedProducts int64
+	Failure       string
+}
@@ -3,15 +3,20 @@ package domain
 import (
 	"fmt"
 	"math/big"
+	"strings"
 )
 
 // Money is a simple value object that wraps *big.Rat to represent
 // monetary values with arbitrary precision.
 //
+// The value is kept exact; rounding to the currency minor unit only
+// happens when a price is presented (see Round and Decimal).
+//
 // It is intentionally small and focused – all business rules live
 // on the Product aggregate or domain services.
 type Money struct {
-	value *big.Rat
+	value    *big.Rat
+	currency Currency
 }
 
 // NewMoneyFromFraction creates Money from integer numerator/denominator.
@@ -24,6 +29,16 @@ func NewMoneyFromFraction(numerator, denominator int64) (*Money, error) {
 	return &Money{value: r}, nil
 }
 
+// NewMoneyFromString parses an exact amount given either as a decimal
+// string ("15.99") or as a fraction ("7996/500").
+func NewMoneyFromString(s string) (*Money, error) {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(s))
+	if !ok {
+		return nil, fmt.Errorf("invalid money amount: %q", s)
+	}
+	return &Money{value: r}, nil
+}
+
 // NewMoneyFromRat wraps a cloned *big.Rat as Money.
 func NewMoneyFromRat(r *big.Rat) *Money {
 	if r == nil {
@@ -46,7 +61,7 @@ func (m *Money) MultiplyBy(ratio *big.Rat) *Money {
 		return nil
 	}
 	out := new(big.Rat).Mul(m.value, ratio)
-	return &Money{value: out}
+	return &Money{value: out, currency: m.currency}
 }
 
 // Subtract subtracts other from this Money and returns a new Money.
@@ -55,7 +70,7 @@ func (m *Money) Subtract(other *Money) *Money {
 		return nil
 	}
 	out := new(big.Rat).Sub(m.value, other.value)
-	return &Money{value: out}
+	return &Money{value: out, currency: m.currency}
 }
 
 // Compare compares this Money with other.
@@ -73,12 +88,174 @@ func (m *Money) Compare(other *Money) int {
 	return m.value.Cmp(other.value)
 }
 
-// Fraction returns the internal numerator and denominator representation.
-// This is primarily used by infrastructure mapping to the database schema.
-func (m *Money) Fraction() (numerator, denominator int64) {
+// Fraction returns the reduced numerator and denominator as int64.
+// Returns ErrMoneyOverflow if either does not fit, instead of truncating.
+func (m *Money) Fraction() (numerator, denominator int64, err error) {
+	if m == nil || m.value == nil {
+		return 0, 1, nil
+	}
+	if !m.value.Num().IsInt64() || !m.value.Denom().IsInt64() {
+		return 0, 0, ErrMoneyOverflow
+	}
+	return m.value.Num().Int64(), m.value.Denom().Int64(), nil
+}
+
+// String returns the exact value: a decimal string when the value has a
+// finite decimal expansion ("15.992"), otherwise a fraction ("1/3").
+func (m *Money) String() string {
+	if m == nil || m.value == nil {
+		return ""
+	}
+	return ExactString(m.value)
+}
+
+// CheckStorable reports whether the value can be stored without loss:
+// at most MaxScale decimal places and MaxIntegerDigits integer digits.
+func (m *Money) CheckStorable() error {
 	if m == nil || m.value == nil {
-		return 0, 1
+		return nil
 	}
-	return m.value.Num().Int64(), m.value.Denom().Int64()
+	return CheckDecimalScale(m.value)
 }
 
+// WithCurrency returns a copy of this Money in the given currency.
+func (m *Money) WithCurrency(c Currency) *Money {
+	if m == nil {
+		return nil
+	}
+	return &Money{value: m.Rat(), currency: c}
+}
+
+// Currency returns the currency of this Money, DefaultCurrency if unset.
+func (m *Money) Currency() Currency {
+	if m == nil || m.currency == "" {
+		return DefaultCurrency
+	}
+	return m.currency
+}
+
+// MinorUnits rounds the value to the currency minor unit and returns it
+// as an integer count of minor units (e.g. 15.99 USD == 1599).
+func (m *Money) MinorUnits(mode RoundingMode) *big.Int {
+	if m == nil || m.value == nil {
+		return new(big.Int)
+	}
+
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(m.Currency().MinorUnits())), nil)
+	scaled := new(big.Rat).Mul(m.value, new(big.Rat).SetInt(scale))
+
+	q, r := new(big.Int).QuoRem(scaled.Num(), scaled.Denom(), new(big.Int))
+	if r.Sign() == 0 || mode == RoundDown {
+		return q
+	}
+
+	// compare the remainder with half a unit: 2*|r| vs denominator
+	twice := new(big.Int).Abs(r)
+	twice.Lsh(twice, 1)
+	cmp := twice.Cmp(scaled.Denom())
+
+	roundAway := cmp > 0 ||
+		(cmp == 0 && mode == RoundHalfUp) ||
+		(cmp == 0 && q.Bit(0) == 1) // half-even: round to the even neighbour
+	if roundAway {
+		q.Add(q, big.NewInt(int64(scaled.Num().Sign())))
+	}
+	return q
+}
+
+// Round returns a new Money rounded to the currency minor unit.
+func (m *Money) Round(mode RoundingMode) *Money {
+	if m == nil || m.value == nil {
+		return nil
+	}
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(m.Currency().MinorUnits())), nil)
+	return &Money{
+		value:    new(big.Rat).SetFrac(m.MinorUnits(mode), scale),
+		currency: m.currency,
+	}
+}
+
+// Decimal formats the value rounded to the currency minor unit,
+// e.g. "15.99" for USD or "1600" for JPY.
+func (m *Money) Decimal(mode RoundingMode) string {
+	minor := m.MinorUnits(mode)
+	digits := m.Currency().MinorUnits()
+
+	sign := ""
+	if minor.Sign() < 0 {
+		sign = "-"
+		minor = new(big.Int).Neg(minor)
+	}
+
+	s := minor.String()
+	if digits == 0 {
+		return sign + s
+	}
+	if len(s) <= digits {
+		s = strings.Repeat("0", digits-len(s)+1) + s
+	}
+	return sign + s[:len(s)-digits] + "." + s[len(s)-digits:]
+}
+
+// MaxScale and MaxIntegerDigits bound the values that can be persisted.
+// They match the precision of the storage NUMERIC type (38 digits, 9 after
+// the decimal point).
+const (
+	MaxScale         = 9
+	MaxIntegerDigits = 29
+)
+
+// CheckDecimalScale returns ErrPriceScale if r has more than MaxScale
+// decimal places (including non-terminating values such as 1/3), and
+// ErrMoneyOverflow if its integer part has more than MaxIntegerDigits digits.
+func CheckDecimalScale(r *big.Rat) error {
+	scale, ok := decimalScale(r)
+	if !ok || scale > MaxScale {
+		return ErrPriceScale
+	}
+	limit := new(big.Int).Exp(big.NewInt(10), big.NewInt(MaxIntegerDigits), nil)
+	intPart := new(big.Int).Quo(r.Num(), r.Denom())
+	if intPart.CmpAbs(limit) >= 0 {
+		return ErrMoneyOverflow
+	}
+	return nil
+}
+
+// ExactString formats r as a decimal string when it terminates,
+// otherwise as a reduced fraction "num/den".
+func ExactString(r *big.Rat) string {
+	if scale, ok := decimalScale(r); ok {
+		return r.FloatString(scale)
+	}
+	return r.String()
+}
+
+// decimalScale returns the number of decimal places needed to write r
+// exactly, and false if the decimal expansion does not terminate.
+func decimalScale(r *big.Rat) (int, bool) {
+	den := new(big.Int).Set(r.Denom())
+	two, five := big.NewInt(2), big.NewInt(5)
+	twos, fives := 0, 0
+	mod := new(big.Int)
+	for {
+		q, m := new(big.Int).QuoRem(den, two, mod)
+		if m.Sign() != 0 {
+			break
+		}
+		den, twos = q, twos+1
+	}
+	for {
+		q, m := new(big.Int).QuoRem(den, five, mod)
+		if m.Sign() != 0 {
+			break
+		}
+		den, fives = q, fives+1
+	}
+	if den.Cmp(big.NewInt(1)) != 0 {
+		return 0, false
+	}
+	if twos > fives {
+		return twos, true
+	}
+	return fives, true
+}
@@ -1,6 +1,10 @@
 package domain
 
-import "time"
+import (
+	"fmt"
+	"sort"
+	"time"
+)
 
 // ProductStatus represents the lifecycle state of a product.
 type ProductStatus string
@@ -14,13 +18,14 @@ const (
 
 // Field names for change tracking.
 const (
-	FieldName        = "name"
-	FieldDescription = "description"
-	FieldCategory    = "category"
-	FieldBasePrice   = "base_price"
-	FieldDiscount    = "discount"
-	FieldStatus      = "status"
-	FieldArchivedAt  = "archived_at"
+	FieldName            = "name"
+	FieldDescription     = "description"
+	FieldCategory        = "category"
+	FieldBasePrice       = "base_price"
+	FieldDiscount        = "discount"
+	FieldScheduledPrices = "scheduled_prices"
+	FieldStatus          = "status"
+	FieldArchivedAt      = "archived_at"
 )
 
 // Product is the aggregate root for product-related behavior.
@@ -30,10 +35,20 @@ type Product struct {
 	description string
 	category    string
 	basePrice   *Money
-	discount    *Discount
+	discounts   []*Discount
 	status      ProductStatus
 	archivedAt  *time.Time
 
+	// scheduledPrices are future base prices ordered by effective time.
+	scheduledPrices []*ScheduledPrice
+
+	// attributes are typed properties such as brand, color or size, keyed
+	// by attribute key.
+	attributes map[string]AttributeValue
+
+	// variants are the sellable versions of the product ordered by ID.
+	variants []*Variant
+
 	createdAt time.Time
 	updatedAt time.Time
 
@@ -85,24 +100,30 @@ func RehydrateProduct(
 	description string,
 	category string,
 	basePrice *Money,
-	discount *Discount,
+	discounts []*Discount,
+	scheduledPrices []*ScheduledPrice,
+	attributes map[string]AttributeValue,
+	variants []*Variant,
 	status ProductStatus,
 	archivedAt *time.Time,
 	createdAt time.Time,
 	updatedAt time.Time,
 ) *Product {
 	return &Product{
-		id:          id,
-		name:        name,
-		description: description,
-		category:    category,
-		basePrice:   basePrice,
-		discount:    discount,
-		status:      status,
-		archivedAt:  archivedAt,
-		createdAt:   createdAt,
-		updatedAt:   updatedAt,
-		changes:     NewChangeTracker(),
+		id:              id,
+		name:            name,
+		description:     description,
+		category:        category,
+		basePrice:       basePrice,
+		discounts:       discounts,
+		scheduledPrices: sortScheduledPrices(scheduledPrices),
+		attributes:      attributes,
+		variants:        sortVariants(variants),
+		status:          status,
+		archivedAt:      archivedAt,
+		createdAt:       createdAt,
+		updatedAt:       updatedAt,
+		changes:         NewChangeTracker(),
 	}
 }
 
@@ -113,7 +134,81 @@ func (p *Product) Name() string        { return p.name }
 func (p *Product) Description() string { return p.description }
 func (p *Product) Category() string    { return p.category }
 func (p *Product) BasePrice() *Money   { return p.basePrice }
-func (p *Product) Discount() *Discount { return p.discount }
+
+// Currency returns the currency the product is priced in.
+func (p *Product) Currency() Currency { return p.basePrice.Currency() }
+
+// Discounts returns a copy of the discounts attached to the product,
+// regardless of their validity window.
+func (p *Product) Discounts() []*Discount {
+	out := make([]*Discount, len(p.discounts))
+	copy(out, p.discounts)
+	return out
+}
+
+// ScheduledPrices returns a copy of the future base prices ordered by
+// effective time, including any that are due but not yet applied.
+func (p *Product) ScheduledPrices() []*ScheduledPrice {
+	out := make([]*ScheduledPrice, len(p.scheduledPrices))
+	copy(out, p.scheduledPrices)
+	return out
+}
+
+// Attributes returns a copy of the product attributes.
+func (p *Product) Attributes() map[string]AttributeValue {
+	out := make(map[string]AttributeValue, len(p.attributes))
+	for k, v := range p.attributes {
+		out[k] = v
+	}
+	return out
+}
+
+// Attribute returns the attribute with the given key, if set.
+func (p *Product) Attribute(key string) (AttributeValue, bool) {
+	v, ok := p.attributes[key]
+	return v, ok
+}
+
+// Discount returns the discount with the given ID.
+func (p *Product) Discount(id string) (*Discount, bool) {
+	for _, d := range p.discounts {
+		if d.ID() == id {
+			return d, true
+		}
+	}
+	return nil, false
+}
+
+// Variants returns a copy of the product variants ordered by ID.
+func (p *Product) Variants() []*Variant {
+	out := make([]*Variant, len(p.variants))
+	copy(out, p.variants)
+	return out
+}
+
+// Variant returns the variant with the given ID.
+func (p *Product) Variant(id string) (*Variant, bool) {
+	for _, v := range p.variants {
+		if v.ID() == id {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// BasePriceAt returns the base price in force at the given time: the latest
+// scheduled price effective at that time, otherwise the current base price.
+func (p *Product) BasePriceAt(at time.Time) *Money {
+	price := p.basePrice
+	for _, s := range p.scheduledPrices {
+		if !s.IsEffectiveAt(at) {
+			break
+		}
+		price = s.Price().WithCurrency(p.Currency())
+	}
+	return price
+}
+
 func (p *Product) Status() ProductStatus {
 	return p.status
 }
@@ -145,14 +240,71 @@ func (p *Product) UpdateDetails(name, description, category string, now time.Tim
 	}
 
 	if changed {
-		p.updatedAt = now
-		p.events = append(p.events, ProductUpdatedEvent{
-			baseEvent: baseEvent{occurredAt: now},
-			ProductID: p.id,
-		})
+		p.recordUpdate(now)
 	}
 }
 
+// UpdateAttributes sets the given attributes, replacing values of the same
+// key, and removes the attributes listed in remove. Unknown keys in remove
+// are ignored. Only attributes whose value changes are marked dirty.
+func (p *Product) UpdateAttributes(set map[string]AttributeValue, remove []string, now time.Time) error {
+	if p.status == ProductStatusArchived {
+		return ErrProductArchived
+	}
+
+	removed := make(map[string]bool, len(remove))
+	for _, key := range remove {
+		if _, ok := set[key]; ok {
+			return fmt.Errorf("%w: %q is both set and removed", ErrInvalidAttribute, key)
+		}
+		removed[key] = true
+	}
+	for _, key := range sortedAttributeKeys(set) {
+		if !ValidAttributeKey(key) {
+			return fmt.Errorf("%w: key %q must be lowercase letters, digits and underscores starting with a letter, at most %d characters",
+				ErrInvalidAttribute, key, MaxAttributeKeyLength)
+		}
+		if set[key].Type() == "" {
+			return fmt.Errorf("%w: %q has no value", ErrInvalidAttribute, key)
+		}
+	}
+
+	next := make(map[string]AttributeValue, len(p.attributes)+len(set))
+	for k, v := range p.attributes {
+		if !removed[k] {
+			next[k] = v
+		}
+	}
+	for k, v := range set {
+		next[k] = v
+	}
+	if len(next) > MaxAttributes {
+		return fmt.Errorf("%w: a product has at most %d attributes", ErrInvalidAttribute, MaxAttributes)
+	}
+
+	changed := false
+	for k := range removed {
+		if _, ok := p.attributes[k]; ok {
+			p.changes.MarkDirty(AttributeField(k))
+			changed = true
+		}
+	}
+	for k, v := range set {
+		if old, ok := p.attributes[k]; !ok || !old.Equal(v) {
+			p.changes.MarkDirty(AttributeField(k))
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	p.attributes = next
+	p.changes.MarkDirty(FieldAttributes)
+	p.recordUpdate(now)
+	return nil
+}
+
 // Activate switches product to active state.
 func (p *Product) Activate(now time.Time) {
 	if p.status == ProductStatusActive {
@@ -204,7 +356,9 @@ func (p *Product) Archive(now time.Time) {
 	p.changes.MarkDirty(FieldArchivedAt)
 }
 
-// ApplyDiscount applies or replaces a discount.
+// ApplyDiscount adds a discount to the product. A discount with the same
+// non-empty ID as an existing one replaces it; other discounts are kept
+// and combined by the pricing calculator.
 func (p *Product) ApplyDiscount(discount *Discount, now time.Time) error {
 	if p.status != ProductStatusActive {
 		return ErrProductNotActive
@@ -213,29 +367,367 @@ func (p *Product) ApplyDiscount(discount *Discount, now time.Time) error {
 		return ErrInvalidDiscountPeriod
 	}
 
-	p.discount = discount
+	replaced := false
+	if discount.ID() != "" {
+		for i, d := range p.discounts {
+			if d.ID() == discount.ID() {
+				p.discounts[i] = discount
+				replaced = true
+				break
+			}
+		}
+	}
+	if !replaced {
+		p.discounts = append(p.discounts, discount)
+	}
+
 	p.updatedAt = now
 	p.changes.MarkDirty(FieldDiscount)
+	p.changes.MarkDirty(DiscountField(discount.ID()))
 
 	p.events = append(p.events, DiscountAppliedEvent{
-		baseEvent: baseEvent{occurredAt: now},
-		ProductID: p.id,
+		baseEvent:  baseEvent{occurredAt: now},
+		ProductID:  p.id,
+		DiscountID: discount.ID(),
 	})
 
 	return nil
 }
 
-// RemoveDiscount clears current discount if any.
-func (p *Product) RemoveDiscount(now time.Time) {
-	if p.discount == nil {
-		return
+// RemoveDiscount removes the discount with the given ID.
+// An empty ID clears all discounts of the product.
+func (p *Product) RemoveDiscount(discountID string, now time.Time) error {
+	if discountID == "" {
+		if len(p.discounts) == 0 {
+			return nil
+		}
+		for _, d := range p.discounts {
+			p.changes.MarkDirty(DiscountField(d.ID()))
+		}
+		p.discounts = nil
+	} else {
+		idx := -1
+		for i, d := range p.discounts {
+			if d.ID() == discountID {
+				idx = i
+				break
+			}
+		}
+		if idx < 0 {
+			return ErrDiscountNotFound
+		}
+		p.discounts = append(p.discounts[:idx:idx], p.discounts[idx+1:]...)
+		p.changes.MarkDirty(DiscountField(discountID))
 	}
 
-	p.discount = nil
 	p.updatedAt = now
 	p.changes.MarkDirty(FieldDiscount)
 
 	p.events = append(p.events, DiscountRemovedEvent{
+		baseEvent:  baseEvent{occurredAt: now},
+		ProductID:  p.id,
+		DiscountID: discountID,
+	})
+
+	return nil
+}
+
+// ExpireDiscounts clears the discounts whose window ended before now and
+// returns them. A DiscountExpiredEvent is raised for each one.
+func (p *Product) ExpireDiscounts(now time.Time) []*Discount {
+	var expired []*Discount
+	kept := make([]*Discount, 0, len(p.discounts))
+	for _, d := range p.discounts {
+		if d.EndAt().Before(now) {
+			expired = append(expired, d)
+			continue
+		}
+		kept = append(kept, d)
+	}
+	if len(expired) == 0 {
+		return nil
+	}
+
+	p.discounts = kept
+	p.updatedAt = now
+	p.changes.MarkDirty(FieldDiscount)
+	for _, d := range expired {
+		p.changes.MarkDirty(DiscountField(d.ID()))
+	}
+
+	for _, d := range expired {
+		p.events = append(p.events, DiscountExpiredEvent{
+			baseEvent:  baseEvent{occurredAt: now},
+			ProductID:  p.id,
+			DiscountID: d.ID(),
+		})
+	}
+
+	return expired
+}
+
+// RecordEffectivePriceChange raises an EffectivePriceChangedEvent if the
+// effective price moved from oldPrice to a different newPrice.
+// Prices are computed by the pricing calculator; the aggregate only records
+// the change.
+func (p *Product) RecordEffectivePriceChange(oldPrice, newPrice *Money, now time.Time) {
+	if oldPrice == nil || newPrice == nil || oldPrice.Compare(newPrice) == 0 {
+		return
+	}
+
+	p.events = append(p.events, EffectivePriceChangedEvent{
+		baseEvent: baseEvent{occurredAt: now},
+		ProductID: p.id,
+		Currency:  string(newPrice.Currency()),
+		OldPrice:  oldPrice.String(),
+		NewPrice:  newPrice.String(),
+	})
+}
+
+// SchedulePrice schedules a future base price. A scheduled price with the
+// same non-empty ID is replaced. The price takes the product currency.
+func (p *Product) SchedulePrice(scheduled *ScheduledPrice, now time.Time) error {
+	if p.status == ProductStatusArchived {
+		return ErrProductArchived
+	}
+	if scheduled == nil || !scheduled.EffectiveFrom().After(now) {
+		return fmt.Errorf("%w: effective_from must be in the future", ErrInvalidPriceSchedule)
+	}
+
+	kept := make([]*ScheduledPrice, 0, len(p.scheduledPrices)+1)
+	for _, s := range p.scheduledPrices {
+		if scheduled.ID() != "" && s.ID() == scheduled.ID() {
+			continue
+		}
+		if s.EffectiveFrom().Equal(scheduled.EffectiveFrom()) {
+			return fmt.Errorf("%w: a price is already scheduled at %s",
+				ErrInvalidPriceSchedule, scheduled.EffectiveFrom().Format(time.RFC3339))
+		}
+		kept = append(kept, s)
+	}
+	p.scheduledPrices = sortScheduledPrices(append(kept, scheduled))
+
+	p.updatedAt = now
+	p.changes.MarkDirty(FieldScheduledPrices)
+
+	p.events = append(p.events, ScheduledPriceAddedEvent{
+		baseEvent:        baseEvent{occurredAt: now},
+		ProductID:        p.id,
+		ScheduledPriceID: scheduled.ID(),
+		Price:            scheduled.Price().String(),
+		EffectiveFrom:    scheduled.EffectiveFrom(),
+	})
+
+	return nil
+}
+
+// CancelScheduledPrice removes a scheduled price that has not been applied.
+func (p *Product) CancelScheduledPrice(scheduledPriceID string, now time.Time) error {
+	idx := -1
+	for i, s := range p.scheduledPrices {
+		if s.ID() == scheduledPriceID {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return ErrScheduledPriceNotFound
+	}
+	p.scheduledPrices = append(p.scheduledPrices[:idx:idx], p.scheduledPrices[idx+1:]...)
+
+	p.updatedAt = now
+	p.changes.MarkDirty(FieldScheduledPrices)
+
+	p.events = append(p.events, ScheduledPriceCancelledEvent{
+		baseEvent:        baseEvent{occurredAt: now},
+		ProductID:        p.id,
+		ScheduledPriceID: scheduledPriceID,
+	})
+
+	return nil
+}
+
+// ApplyDueScheduledPrices makes the scheduled prices effective at now the
+// base price, in effective order, and returns them. A
+// ScheduledPriceEffectiveEvent is raised for each one.
+func (p *Product) ApplyDueScheduledPrices(now time.Time) []*ScheduledPrice {
+	var due []*ScheduledPrice
+	for len(p.scheduledPrices) > 0 && p.scheduledPrices[0].IsEffectiveAt(now) {
+		due = append(due, p.scheduledPrices[0])
+		p.scheduledPrices = p.scheduledPrices[1:]
+	}
+	if len(due) == 0 {
+		return nil
+	}
+
+	for _, s := range due {
+		oldPrice := p.basePrice
+		p.basePrice = s.Price().WithCurrency(p.Currency())
+		p.events = append(p.events, ScheduledPriceEffectiveEvent{
+			baseEvent:        baseEvent{occurredAt: now},
+			ProductID:        p.id,
+			ScheduledPriceID: s.ID(),
+			Currency:         string(p.Currency()),
+			OldPrice:         oldPrice.String(),
+			NewPrice:         p.basePrice.String(),
+			EffectiveFrom:    s.EffectiveFrom(),
+		})
+	}
+
+	p.updatedAt = now
+	p.changes.MarkDirty(FieldBasePrice)
+	p.changes.MarkDirty(FieldScheduledPrices)
+
+	return due
+}
+
+// AddVariant adds a variant. Its SKU and options must differ from those of
+// the other variants; a price override takes the product currency.
+func (p *Product) AddVariant(v *Variant, now time.Time) error {
+	if p.status == ProductStatusArchived {
+		return ErrProductArchived
+	}
+	if v == nil {
+		return fmt.Errorf("%w: variant is required", ErrInvalidVariant)
+	}
+	if _, ok := p.Variant(v.ID()); ok {
+		return fmt.Errorf("%w: variant %q already exists", ErrInvalidVariant, v.ID())
+	}
+	if len(p.variants) >= MaxVariants {
+		return fmt.Errorf("%w: a product has at most %d variants", ErrInvalidVariant, MaxVariants)
+	}
+	if err := p.checkVariantUnique(v); err != nil {
+		return err
+	}
+
+	v = v.withCurrency(p.Currency())
+	p.variants = sortVariants(append(p.variants, v))
+
+	p.updatedAt = now
+	p.changes.MarkDirty(FieldVariants)
+	p.changes.MarkDirty(VariantField(v.ID()))
+
+	p.events = append(p.events, VariantAddedEvent{
+		baseEvent:     baseEvent{occurredAt: now},
+		ProductID:     p.id,
+		VariantID:     v.ID(),
+		SKU:           v.SKU(),
+		Options:       v.Options(),
+		PriceOverride: v.PriceOverride().String(),
+		Status:        string(v.Status()),
+	})
+
+	return nil
+}
+
+// UpdateVariant replaces the variant with the same ID. Nothing is recorded
+// when the variant is unchanged.
+func (p *Product) UpdateVariant(v *Variant, now time.Time) error {
+	if p.status == ProductStatusArchived {
+		return ErrProductArchived
+	}
+	if v == nil {
+		return fmt.Errorf("%w: variant is required", ErrInvalidVariant)
+	}
+	idx := -1
+	for i, existing := range p.variants {
+		if existing.ID() == v.ID() {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return ErrVariantNotFound
+	}
+	if err := p.checkVariantUnique(v); err != nil {
+		return err
+	}
+
+	v = v.withCurrency(p.Currency())
+	if p.variants[idx].Equal(v) {
+		return nil
+	}
+	p.variants[idx] = v
+
+	p.updatedAt = now
+	p.changes.MarkDirty(FieldVariants)
+	p.changes.MarkDirty(VariantField(v.ID()))
+
+	p.events = append(p.events, VariantUpdatedEvent{
+		baseEvent:     baseEvent{occurredAt: now},
+		ProductID:     p.id,
+		VariantID:     v.ID(),
+		SKU:           v.SKU(),
+		Options:       v.Options(),
+		PriceOverride: v.PriceOverride().String(),
+		Status:        string(v.Status()),
+	})
+
+	return nil
+}
+
+// RemoveVariant removes the variant with the given ID.
+func (p *Product) RemoveVariant(variantID string, now time.Time) error {
+	if p.status == ProductStatusArchived {
+		return ErrProductArchived
+	}
+	idx := -1
+	for i, v := range p.variants {
+		if v.ID() == variantID {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return ErrVariantNotFound
+	}
+	removed := p.variants[idx]
+	p.variants = append(p.variants[:idx:idx], p.variants[idx+1:]...)
+
+	p.updatedAt = now
+	p.changes.MarkDirty(FieldVariants)
+	p.changes.MarkDirty(VariantField(variantID))
+
+	p.events = append(p.events, VariantRemovedEvent{
+		baseEvent: baseEvent{occurredAt: now},
+		ProductID: p.id,
+		VariantID: variantID,
+		SKU:       removed.SKU(),
+	})
+
+	return nil
+}
+
+// checkVariantUnique rejects a variant sharing its SKU or options with
+// another variant of the product. SKUs are also unique across products,
+// which the caller checks.
+func (p *Product) checkVariantUnique(v *Variant) error {
+	for _, other := range p.variants {
+		if other.ID() == v.ID() {
+			continue
+		}
+		if other.SKU() == v.SKU() {
+			return fmt.Errorf("%w: %q", ErrSKUTaken, v.SKU())
+		}
+		if other.OptionKey() == v.OptionKey() {
+			return fmt.Errorf("%w: variant %q already has options %s", ErrInvalidVariant, other.ID(), v.OptionKey())
+		}
+	}
+	return nil
+}
+
+// recordUpdate bumps updatedAt and raises a ProductUpdatedEvent unless a
+// pending event already announces the product's creation or update, so
+// one command raises one event.
+func (p *Product) recordUpdate(now time.Time) {
+	p.updatedAt = now
+	for _, e := range p.events {
+		switch e.(type) {
+		case ProductCreatedEvent, ProductUpdatedEvent:
+			return
+		}
+	}
+	p.events = append(p.events, ProductUpdatedEvent{
 		baseEvent: baseEvent{occurredAt: now},
 		ProductID: p.id,
 	})
@@ -253,3 +745,18 @@ func (p *Product) ClearDomainEvents() {
 	p.events = nil
 }
 
+// sortVariants orders variants by ID.
+func sortVariants(variants []*Variant) []*Variant {
+	sort.SliceStable(variants, func(i, j int) bool {
+		return variants[i].ID() < variants[j].ID()
+	})
+	return variants
+}
+
+// sortScheduledPrices orders scheduled prices by effective time.
+func sortScheduledPrices(prices []*ScheduledPrice) []*ScheduledPrice {
+	sort.SliceStable(prices, func(i, j int) bool {
+		return prices[i].EffectiveFrom().Before(prices[j].EffectiveFrom())
+	})
+	return prices
+}
@@ -0,0 +1,184 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// RecategorizationStatus is the state of a recategorization job.
+type RecategorizationStatus string
+
+const (
+	RecategorizationRunning   RecategorizationStatus = "running"
+	RecategorizationSucceeded RecategorizationStatus = "succeeded"
+	RecategorizationFailed    RecategorizationStatus = "failed"
+)
+
+// Field names for recategorization change tracking.
+const (
+	FieldProgress = "progress"
+)
+
+// Recategorization is the aggregate root of a job moving every product from
+// one category to another in batches. Products are moved by the job runner;
+// the aggregate tracks the committed batches.
+type Recategorization struct {
+	id            string
+	fromCategory  string
+	toCategory    string
+	status        RecategorizationStatus
+	batches       int64
+	movedProducts int64
+	failure       string
+
+	createdAt  time.Time
+	updatedAt  time.Time
+	finishedAt *time.Time
+
+	changes *ChangeTracker
+	events  []DomainEvent
+}
+
+// NewRecategorization starts a job moving the products of fromCategory to
+// toCategory. fromCategory may be any stored value, including one with no
+// category; the caller checks toCategory exists.
+func NewRecategorization(id, fromCategory, toCategory string, now time.Time) (*Recategorization, error) {
+	if fromCategory == "" || toCategory == "" {
+		return nil, fmt.Errorf("%w: from and to categories are required", ErrInvalidRecategorization)
+	}
+	if fromCategory == toCategory {
+		return nil, fmt.Errorf("%w: from and to categories are the same", ErrInvalidRecategorization)
+	}
+
+	r := &Recategorization{
+		id:           id,
+		fromCategory: fromCategory,
+		toCategory:   toCategory,
+		status:       RecategorizationRunning,
+		createdAt:    now,
+		updatedAt:    now,
+		changes:      NewChangeTracker(),
+	}
+
+	r.events = append(r.events, RecategorizationStartedEvent{
+		baseEvent:    baseEvent{occurredAt: now},
+		JobID:        r.id,
+		FromCategory: r.fromCategory,
+		ToCategory:   r.toCategory,
+	})
+
+	return r, nil
+}
+
+// RehydrateRecategorization reconstructs a job from persisted state.
+// It does not emit events or mark fields as dirty.
+func RehydrateRecategorization(
+	id string,
+	fromCategory string,
+	toCategory string,
+	status RecategorizationStatus,
+	batches int64,
+	movedProducts int64,
+	failure string,
+	createdAt time.Time,
+	updatedAt time.Time,
+	finishedAt *time.Time,
+) *Recategorization {
+	return &Recategorization{
+		id:            id,
+		fromCategory:  fromCategory,
+		toCategory:    toCategory,
+		status:        status,
+		batches:       batches,
+		movedProducts: movedProducts,
+		failure:       failure,
+		createdAt:     createdAt,
+		updatedAt:     updatedAt,
+		finishedAt:    finishedAt,
+		changes:       NewChangeTracker(),
+	}
+}
+
+func (r *Recategorization) ID() string                     { return r.id }
+func (r *Recategorization) FromCategory() string           { return r.fromCategory }
+func (r *Recategorization) ToCategory() string             { return r.toCategory }
+func (r *Recategorization) Status() RecategorizationStatus { return r.status }
+func (r *Recategorization) MovedProducts() int64           { return r.movedProducts }
+func (r *Recategorization) CreatedAt() time.Time           { return r.createdAt }
+func (r *Recategorization) UpdatedAt() time.Time           { return r.updatedAt }
+func (r *Recategorization) FinishedAt() *time.Time         { return r.finishedAt }
+
+// Failure describes why a failed job stopped; it is empty otherwise.
+func (r *Recategorization) Failure() string { return r.failure }
+
+// Batches returns the number of committed batches.
+func (r *Recategorization) Batches() int64 { return r.batches }
+
+func (r *Recategorization) Changes() *ChangeTracker { return r.changes }
+
+// RecordBatch counts a batch of moved products and returns its number,
+// starting at 1. Batch numbers identify the batch checkpoint, so a batch
+// committed concurrently by another runner conflicts instead of being
+// counted twice.
+func (r *Recategorization) RecordBatch(movedProducts int, now time.Time) (int64, error) {
+	if r.status != RecategorizationRunning {
+		return 0, ErrRecategorizationFinished
+	}
+	r.batches++
+	r.movedProducts += int64(movedProducts)
+	r.updatedAt = now
+	r.changes.MarkDirty(FieldProgress)
+	return r.batches, nil
+}
+
+// Complete marks the job as done once no product is left in the source
+// category.
+func (r *Recategorization) Complete(now time.Time) {
+	if r.status != RecategorizationRunning {
+		return
+	}
+	r.status = RecategorizationSucceeded
+	r.finishedAt = &now
+	r.updatedAt = now
+	r.changes.MarkDirty(FieldStatus)
+	r.events = append(r.events, RecategorizationCompletedEvent{
+		baseEvent:     baseEvent{occurredAt: now},
+		JobID:         r.id,
+		FromCategory:  r.fromCategory,
+		ToCategory:    r.toCategory,
+		MovedProducts: r.movedProducts,
+	})
+}
+
+// Fail stops the job without moving the remaining products, e.g. when one
+// does not match the target category's attribute schema.
+func (r *Recategorization) Fail(failure string, now time.Time) {
+	if r.status != RecategorizationRunning {
+		return
+	}
+	r.status = RecategorizationFailed
+	r.failure = failure
+	r.finishedAt = &now
+	r.updatedAt = now
+	r.changes.MarkDirty(FieldStatus)
+	r.events = append(r.events, RecategorizationFailedEvent{
+		baseEvent:     baseEvent{occurredAt: now},
+		JobID:         r.id,
+		FromCategory:  r.fromCategory,
+		ToCategory:    r.toCategory,
+		MovedProducts: r.movedProducts,
+		Failure:       r.failure,
+	})
+}
+
+// DomainEvents returns a copy of pending events.
+func (r *Recategorization) DomainEvents() []DomainEvent {
+	out := make([]DomainEvent, len(r.events))
+	copy(out, r.events)
+	return out
+}
+
+// ClearDomainEvents removes all pending events. Usually called after persistence.
+func (r *Recategorization) ClearDomainEvents() {
+	r.events = nil
+}
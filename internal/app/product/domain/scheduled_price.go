@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// ScheduledPrice is a value object for a future base price: from
+// effectiveFrom on, price replaces the product base price.
+type ScheduledPrice struct {
+	id            string
+	price         *Money
+	effectiveFrom time.Time
+}
+
+// NewScheduledPrice creates a scheduled base price.
+// Price must not be negative.
+func NewScheduledPrice(id string, price *Money, effectiveFrom time.Time) (*ScheduledPrice, error) {
+	if price == nil {
+		return nil, fmt.Errorf("scheduled price is required")
+	}
+	if price.Rat().Sign() < 0 {
+		return nil, fmt.Errorf("scheduled price must be >= 0")
+	}
+	if effectiveFrom.IsZero() {
+		return nil, fmt.Errorf("%w: effective_from is required", ErrInvalidPriceSchedule)
+	}
+
+	return &ScheduledPrice{
+		id:            id,
+		price:         price.WithCurrency(price.Currency()),
+		effectiveFrom: effectiveFrom,
+	}, nil
+}
+
+// ID returns the scheduled price identifier within its product.
+func (s *ScheduledPrice) ID() string {
+	if s == nil {
+		return ""
+	}
+	return s.id
+}
+
+// Price returns the scheduled base price.
+func (s *ScheduledPrice) Price() *Money {
+	if s == nil || s.price == nil {
+		return nil
+	}
+	return s.price.WithCurrency(s.price.Currency())
+}
+
+// EffectiveFrom returns the time the price takes effect.
+func (s *ScheduledPrice) EffectiveFrom() time.Time {
+	if s == nil {
+		return time.Time{}
+	}
+	return s.effectiveFrom
+}
+
+// IsEffectiveAt returns true if the price is in force at the given time.
+func (s *ScheduledPrice) IsEffectiveAt(t time.Time) bool {
+	if s == nil {
+		return false
+	}
+	return !t.Before(s.effectiveFrom)
+}
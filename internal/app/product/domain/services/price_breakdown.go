@@ -0,0 +1,39 @@
+package services
+
+import (
+	"time"
+
+	"product-catalog-service/internal/app/product/domain"
+)
+
+// PriceBreakdown explains how the effective price of a product at a point in
+// time is obtained from its base price.
+type PriceBreakdown struct {
+	At time.Time
+	// BasePrice is the base price in force at At.
+	BasePrice *domain.Money
+	// Discounts are the discounts applied at At, in the order they are
+	// combined; empty when the base price applies.
+	Discounts []*domain.Discount
+	// DiscountAmount is BasePrice minus EffectivePrice.
+	DiscountAmount *domain.Money
+	EffectivePrice *domain.Money
+}
+
+// Breakdown returns the price breakdown of a product at the given time, or
+// nil if the product has no price.
+func (c PricingCalculator) Breakdown(p *domain.Product, at time.Time) *PriceBreakdown {
+	effective := c.EffectivePrice(p, at)
+	if effective == nil {
+		return nil
+	}
+
+	base := p.BasePriceAt(at)
+	return &PriceBreakdown{
+		At:             at,
+		BasePrice:      base,
+		Discounts:      c.ApplicableDiscounts(p, at),
+		DiscountAmount: base.Subtract(effective),
+		EffectivePrice: effective,
+	}
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"sort"
+	"time"
+
+	"product-catalog-service/internal/app/product/domain"
+)
+
+// PriceSnapshot is the pricing state of a product as recorded at a point in
+// time. It stays in force until the next snapshot.
+type PriceSnapshot struct {
+	RecordedAt time.Time
+	Product    *domain.Product
+}
+
+// PricePeriod is a time range [From, To) with a constant effective price.
+type PricePeriod struct {
+	From           time.Time
+	To             time.Time
+	BasePrice      *domain.Money
+	EffectivePrice *domain.Money
+}
+
+// Timeline returns the effective prices over [from, to) given the recorded
+// pricing snapshots of a product, merging adjacent periods with the same
+// price. Time before the first snapshot has no price.
+//
+// Within a snapshot the price only changes when a discount window starts or
+// ends or a scheduled price takes effect, so it is evaluated at those
+// instants only.
+func (c PricingCalculator) Timeline(snapshots []PriceSnapshot, from, to time.Time) []PricePeriod {
+	if !from.Before(to) || len(snapshots) == 0 {
+		return nil
+	}
+
+	ordered := make([]PriceSnapshot, len(snapshots))
+	copy(ordered, snapshots)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].RecordedAt.Before(ordered[j].RecordedAt)
+	})
+
+	var periods []PricePeriod
+	for i, s := range ordered {
+		start, end := s.RecordedAt, to
+		if i+1 < len(ordered) {
+			end = ordered[i+1].RecordedAt
+		}
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+		if !start.Before(end) {
+			continue
+		}
+
+		instants := changeInstants(s.Product, start, end)
+		for j, at := range instants {
+			until := end
+			if j+1 < len(instants) {
+				until = instants[j+1]
+			}
+			periods = appendPeriod(periods, PricePeriod{
+				From:           at,
+				To:             until,
+				BasePrice:      s.Product.BasePriceAt(at),
+				EffectivePrice: c.EffectivePrice(s.Product, at),
+			})
+		}
+	}
+
+	return periods
+}
+
+// LowestPrice returns the lowest effective price over [from, to), or nil if
+// the product had no price in that range.
+func (c PricingCalculator) LowestPrice(snapshots []PriceSnapshot, from, to time.Time) *domain.Money {
+	var lowest *domain.Money
+	for _, p := range c.Timeline(snapshots, from, to) {
+		if lowest == nil || p.EffectivePrice.Compare(lowest) < 0 {
+			lowest = p.EffectivePrice
+		}
+	}
+	return lowest
+}
+
+// changeInstants returns start and every instant in (start, end) at which
+// the effective price of p may change, in ascending order.
+func changeInstants(p *domain.Product, start, end time.Time) []time.Time {
+	candidates := []time.Time{start}
+	for _, d := range p.Discounts() {
+		// a discount is valid on [StartAt, EndAt]
+		candidates = append(candidates, d.StartAt(), d.EndAt().Add(time.Nanosecond))
+	}
+	for _, s := range p.ScheduledPrices() {
+		candidates = append(candidates, s.EffectiveFrom())
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].Before(candidates[j])
+	})
+
+	out := make([]time.Time, 0, len(candidates))
+	for _, t := range candidates {
+		if t.Before(start) || !t.Before(end) {
+			continue
+		}
+		if len(out) > 0 && out[len(out)-1].Equal(t) {
+			continue
+		}
+		out = append(out, t)
+	}
+	return out
+}
+
+// appendPeriod appends p, extending the last period instead when the prices
+// are the same.
+func appendPeriod(periods []PricePeriod, p PricePeriod) []PricePeriod {
+	if n := len(periods); n > 0 {
+		last := &periods[n-1]
+		if last.To.Equal(p.From) &&
+			last.EffectivePrice.Compare(p.EffectivePrice) == 0 &&
+			last.BasePrice.Compare(p.BasePrice) == 0 {
+			last.To = p.To
+			return periods
+		}
+	}
+	return append(periods, p)
+}
@@ -2,36 +2,238 @@ package services
 
 import (
 	"math/big"
+	"sort"
 	"time"
 
 	"product-catalog-service/internal/app/product/domain"
 )
 
+// CombinationPolicy defines how several stackable discounts valid at the
+// same time are combined into a single price.
+type CombinationPolicy string
+
+const (
+	// PolicyBestOf applies only the stackable discount giving the lowest price.
+	PolicyBestOf CombinationPolicy = "best_of"
+	// PolicySequential applies discounts one after another in priority order:
+	// percentages multiply, fixed amounts subtract, overrides set the price.
+	PolicySequential CombinationPolicy = "sequential"
+	// PolicyAdditiveCapped sums the percentages and caps the total, then
+	// subtracts the sum of fixed amounts:
+	// base * (1 - min(p1 + p2 + ..., cap)) - (a1 + a2 + ...)
+	// The lowest price override, if any, replaces base.
+	PolicyAdditiveCapped CombinationPolicy = "additive_capped"
+)
+
 // PricingCalculator encapsulates rules for computing effective price.
-type PricingCalculator struct{}
+//
+// The zero value combines discounts with PolicyBestOf and rounds
+// presented prices half-even.
+// Effective prices never go below zero.
+type PricingCalculator struct {
+	Policy CombinationPolicy
+	// AdditiveCap bounds the total percentage under PolicyAdditiveCapped.
+	// nil means 100%.
+	AdditiveCap *big.Rat
+	// Rounding is used when presenting prices in currency minor units.
+	Rounding domain.RoundingMode
+}
 
 // EffectivePrice returns the effective price for a product at the given time,
-// taking into account its discount (if valid at that time).
+// taking into account the base price in force and the discounts valid at
+// that time.
+//
+// Valid discounts are considered by descending priority. If the top one is
+// exclusive it is applied alone; otherwise all stackable discounts are
+// combined according to the calculator policy.
 //
-// If no valid discount exists at the given time, base price is returned.
+// If no valid discount exists at the given time, the base price in force
+// (see Product.BasePriceAt) is returned.
 // Uses precise decimal arithmetic via big.Rat.
-func (PricingCalculator) EffectivePrice(p *domain.Product, at time.Time) *domain.Money {
+func (c PricingCalculator) EffectivePrice(p *domain.Product, at time.Time) *domain.Money {
+	if p == nil || p.BasePrice() == nil {
+		return nil
+	}
+
+	return c.discounted(p.BasePriceAt(at), p, at)
+}
+
+// VariantPrice returns the effective price of a product variant at the
+// given time. The variant's price override, if any, replaces the base price
+// in force; the product discounts apply the same way as in EffectivePrice.
+//
+// Returns nil if the product has no such variant.
+func (c PricingCalculator) VariantPrice(p *domain.Product, variantID string, at time.Time) *domain.Money {
 	if p == nil || p.BasePrice() == nil {
 		return nil
 	}
+	v, ok := p.Variant(variantID)
+	if !ok {
+		return nil
+	}
+
+	base := v.PriceOverride()
+	if base == nil {
+		base = p.BasePriceAt(at)
+	}
+	return c.discounted(base, p, at)
+}
 
-	base := p.BasePrice()
-	d := p.Discount()
-	
-	// Only apply discount if it exists and is valid at the given time
-	if d == nil || !d.IsValidAt(at) {
+// discounted applies the product discounts valid at the given time to base.
+func (c PricingCalculator) discounted(base *domain.Money, p *domain.Product, at time.Time) *domain.Money {
+	applicable := c.applicable(p, base, at)
+	if len(applicable) == 0 {
 		return base
 	}
 
-	// finalPrice = base * (1 - percentage)
-	// Uses big.Rat for precise decimal arithmetic
-	one := big.NewRat(1, 1)
-	discountPart := new(big.Rat).Sub(one, d.Percentage())
-	return base.MultiplyBy(discountPart)
+	if c.policy() == PolicyAdditiveCapped {
+		return domain.NewMoneyFromRat(c.additive(base.Rat(), applicable)).WithCurrency(base.Currency())
+	}
+
+	// best-of yields a single discount, so both policies fold in order
+	price := base.Rat()
+	for _, d := range applicable {
+		price = applyDiscount(price, d)
+	}
+	return domain.NewMoneyFromRat(price).WithCurrency(base.Currency())
+}
+
+// RoundedPrice presents an exact price in its currency: a decimal string
+// (e.g. "15.99") and the amount in minor units (e.g. 1599), both rounded
+// with the calculator rounding mode.
+func (c PricingCalculator) RoundedPrice(price *domain.Money) (decimal string, minorUnits *big.Int) {
+	if price == nil {
+		return "", nil
+	}
+	return price.Decimal(c.rounding()), price.MinorUnits(c.rounding())
+}
+
+// ApplicableDiscounts returns the discounts that take part in the price at
+// the given time, ordered as they are combined.
+//
+// Under PolicyBestOf only the single discount giving the lowest price is
+// returned.
+func (c PricingCalculator) ApplicableDiscounts(p *domain.Product, at time.Time) []*domain.Discount {
+	if p == nil {
+		return nil
+	}
+	var base *domain.Money
+	if p.BasePrice() != nil {
+		base = p.BasePriceAt(at)
+	}
+	return c.applicable(p, base, at)
 }
 
+// applicable selects the discounts of p valid at the given time; best-of
+// picks the one giving the lowest price from base.
+func (c PricingCalculator) applicable(p *domain.Product, base *domain.Money, at time.Time) []*domain.Discount {
+
+	var valid []*domain.Discount
+	for _, d := range p.Discounts() {
+		if d.IsValidAt(at) {
+			valid = append(valid, d)
+		}
+	}
+	if len(valid) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(valid, func(i, j int) bool {
+		return valid[i].Priority() > valid[j].Priority()
+	})
+
+	// An exclusive discount on top is never combined with others.
+	if valid[0].Exclusive() {
+		return valid[:1]
+	}
+
+	stackable := make([]*domain.Discount, 0, len(valid))
+	for _, d := range valid {
+		if !d.Exclusive() {
+			stackable = append(stackable, d)
+		}
+	}
+
+	if c.policy() == PolicyBestOf && base != nil {
+		best := stackable[0]
+		bestPrice := applyDiscount(base.Rat(), best)
+		for _, d := range stackable[1:] {
+			if price := applyDiscount(base.Rat(), d); price.Cmp(bestPrice) < 0 {
+				best, bestPrice = d, price
+			}
+		}
+		return []*domain.Discount{best}
+	}
+
+	return stackable
+}
+
+// applyDiscount returns price after a single discount, clamped at zero.
+func applyDiscount(price *big.Rat, d *domain.Discount) *big.Rat {
+	out := new(big.Rat)
+	switch d.Kind() {
+	case domain.DiscountKindFixedAmount:
+		out.Sub(price, d.Amount().Rat())
+	case domain.DiscountKindPriceOverride:
+		out.Set(d.Amount().Rat())
+	default:
+		// price * (1 - percentage)
+		out.Mul(price, new(big.Rat).Sub(big.NewRat(1, 1), d.Percentage()))
+	}
+	return clampAtZero(out)
+}
+
+// additive combines discounts under PolicyAdditiveCapped.
+func (c PricingCalculator) additive(base *big.Rat, discounts []*domain.Discount) *big.Rat {
+	price := new(big.Rat).Set(base)
+	percent := new(big.Rat)
+	amount := new(big.Rat)
+	overridden := false
+
+	for _, d := range discounts {
+		switch d.Kind() {
+		case domain.DiscountKindFixedAmount:
+			amount.Add(amount, d.Amount().Rat())
+		case domain.DiscountKindPriceOverride:
+			if target := d.Amount().Rat(); !overridden || target.Cmp(price) < 0 {
+				price.Set(target)
+				overridden = true
+			}
+		default:
+			percent.Add(percent, d.Percentage())
+		}
+	}
+
+	limit := big.NewRat(1, 1)
+	if c.AdditiveCap != nil {
+		limit = c.AdditiveCap
+	}
+	if percent.Cmp(limit) > 0 {
+		percent.Set(limit)
+	}
+
+	price.Mul(price, new(big.Rat).Sub(big.NewRat(1, 1), percent))
+	price.Sub(price, amount)
+	return clampAtZero(price)
+}
+
+func clampAtZero(r *big.Rat) *big.Rat {
+	if r.Sign() < 0 {
+		return new(big.Rat)
+	}
+	return r
+}
+
+func (c PricingCalculator) policy() CombinationPolicy {
+	if c.Policy == "" {
+		return PolicyBestOf
+	}
+	return c.Policy
+}
+
+func (c PricingCalculator) rounding() domain.RoundingMode {
+	if c.Rounding == "" {
+		return domain.RoundHalfEven
+	}
+	return c.Rounding
+}
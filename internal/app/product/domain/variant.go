@@ -0,0 +1,168 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// VariantStatus tells whether a variant can be sold.
+type VariantStatus string
+
+const (
+	VariantStatusActive   VariantStatus = "active"
+	VariantStatusInactive VariantStatus = "inactive"
+)
+
+// Limits on product variants, matching the column sizes.
+const (
+	MaxVariants          = 100
+	MaxSKULength         = 64
+	MaxVariantOptions    = 10
+	MaxOptionValueLength = 100
+)
+
+// FieldVariants is marked dirty whenever a variant is added, changed or
+// removed; each changed variant is also marked as VariantField(id).
+const FieldVariants = "variants"
+
+var skuPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// VariantField returns the change tracking field of a variant.
+func VariantField(id string) string {
+	return FieldVariants + "." + id
+}
+
+// Variant is a sellable version of a product, e.g. a T-shirt in size M and
+// color red. It is identified within its product by ID, carries its own
+// SKU and may override the product's base price. Variants live inside the
+// Product aggregate and are changed through it.
+type Variant struct {
+	id      string
+	sku     string
+	options map[string]string
+	// priceOverride replaces the product base price; nil means none.
+	priceOverride *Money
+	status        VariantStatus
+}
+
+// NewVariant creates a variant. options maps option names such as "size"
+// to values such as "M"; there must be at least one. priceOverride is nil
+// when the variant sells at the product price. An empty status means
+// active.
+func NewVariant(id, sku string, options map[string]string, priceOverride *Money, status VariantStatus) (*Variant, error) {
+	if id == "" {
+		return nil, fmt.Errorf("%w: id is required", ErrInvalidVariant)
+	}
+	sku = strings.TrimSpace(sku)
+	if len(sku) > MaxSKULength || !skuPattern.MatchString(sku) {
+		return nil, fmt.Errorf("%w: sku must be 1 to %d letters, digits, dots, dashes and underscores starting with a letter or digit",
+			ErrInvalidVariant, MaxSKULength)
+	}
+	if len(options) == 0 {
+		return nil, fmt.Errorf("%w: at least one option is required", ErrInvalidVariant)
+	}
+	if len(options) > MaxVariantOptions {
+		return nil, fmt.Errorf("%w: at most %d options", ErrInvalidVariant, MaxVariantOptions)
+	}
+	opts := make(map[string]string, len(options))
+	for name, value := range options {
+		if !ValidAttributeKey(name) {
+			return nil, fmt.Errorf("%w: option name %q must be lowercase letters, digits and underscores starting with a letter", ErrInvalidVariant, name)
+		}
+		if strings.TrimSpace(value) == "" || len([]rune(value)) > MaxOptionValueLength {
+			return nil, fmt.Errorf("%w: option %q must have a value of 1 to %d characters", ErrInvalidVariant, name, MaxOptionValueLength)
+		}
+		opts[name] = value
+	}
+	if priceOverride != nil {
+		if priceOverride.Rat().Sign() < 0 {
+			return nil, fmt.Errorf("%w: price override must be >= 0", ErrInvalidVariant)
+		}
+		if err := priceOverride.CheckStorable(); err != nil {
+			return nil, fmt.Errorf("%w: price override: %v", ErrInvalidVariant, err)
+		}
+		priceOverride = priceOverride.WithCurrency(priceOverride.Currency())
+	}
+	switch status {
+	case "":
+		status = VariantStatusActive
+	case VariantStatusActive, VariantStatusInactive:
+	default:
+		return nil, fmt.Errorf("%w: unknown status %q", ErrInvalidVariant, status)
+	}
+
+	return &Variant{
+		id:            id,
+		sku:           sku,
+		options:       opts,
+		priceOverride: priceOverride,
+		status:        status,
+	}, nil
+}
+
+// ID returns the variant identifier within its product.
+func (v *Variant) ID() string { return v.id }
+
+// SKU returns the stock keeping unit, unique across all products.
+func (v *Variant) SKU() string { return v.sku }
+
+// Options returns a copy of the option values by option name.
+func (v *Variant) Options() map[string]string {
+	out := make(map[string]string, len(v.options))
+	for k, o := range v.options {
+		out[k] = o
+	}
+	return out
+}
+
+// PriceOverride returns the price replacing the product base price, or nil.
+func (v *Variant) PriceOverride() *Money {
+	if v.priceOverride == nil {
+		return nil
+	}
+	return v.priceOverride.WithCurrency(v.priceOverride.Currency())
+}
+
+func (v *Variant) Status() VariantStatus { return v.status }
+
+// IsActive reports whether the variant can be sold.
+func (v *Variant) IsActive() bool { return v.status == VariantStatusActive }
+
+// OptionKey returns the options in a canonical form, e.g.
+// "color=red;size=M". No two variants of a product share it.
+func (v *Variant) OptionKey() string {
+	names := make([]string, 0, len(v.options))
+	for name := range v.options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = name + "=" + v.options[name]
+	}
+	return strings.Join(parts, ";")
+}
+
+// Equal reports whether both variants have the same state.
+func (v *Variant) Equal(other *Variant) bool {
+	if v.id != other.id || v.sku != other.sku || v.status != other.status || v.OptionKey() != other.OptionKey() {
+		return false
+	}
+	if v.priceOverride == nil || other.priceOverride == nil {
+		return v.priceOverride == nil && other.priceOverride == nil
+	}
+	return v.priceOverride.Compare(other.priceOverride) == 0 &&
+		v.priceOverride.Currency() == other.priceOverride.Currency()
+}
+
+// withCurrency returns a copy of the variant whose price override is in
+// the given currency.
+func (v *Variant) withCurrency(c Currency) *Variant {
+	out := *v
+	if v.priceOverride != nil {
+		out.priceOverride = v.priceOverride.WithCurrency(c)
+	}
+	return &out
+}
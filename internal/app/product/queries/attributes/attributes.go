@@ -0,0 +1,28 @@
+// Package attributes converts product attributes for the product queries.
+package attributes
+
+import (
+	"product-catalog-service/internal/app/product/contracts"
+	"product-catalog-service/internal/app/product/domain"
+)
+
+// ToDTOs converts the attributes of a read-model record; nil stays nil.
+func ToDTOs(records map[string]contracts.AttributeRecord) map[string]AttributeDTO {
+	if records == nil {
+		return nil
+	}
+	out := make(map[string]AttributeDTO, len(records))
+	for key, r := range records {
+		dto := AttributeDTO{
+			Type: r.Type,
+			Text: r.Text,
+			Bool: r.Bool,
+			Unit: r.Unit,
+		}
+		if r.Number != nil {
+			dto.Number = domain.ExactString(r.Number)
+		}
+		out[key] = dto
+	}
+	return out
+}
@@ -0,0 +1,14 @@
+package attributes
+
+// AttributeDTO is a typed product attribute value. Text is set for string
+// and enum attributes, Number for number and measurement attributes, Bool
+// for bool attributes; Unit is only set for measurements.
+type AttributeDTO struct {
+	// Type is one of "string", "number", "bool", "enum" or "measurement".
+	Type string
+	Text string
+	// Number is the exact value as a decimal string, e.g. "42.5".
+	Number string
+	Bool   bool
+	Unit   string
+}
@@ -0,0 +1,47 @@
+package batchgetproducts
+
+import (
+	"time"
+
+	"product-catalog-service/internal/app/product/queries/attributes"
+	"product-catalog-service/internal/app/product/queries/productview"
+	"product-catalog-service/internal/app/product/queries/variants"
+)
+
+// ResultDTO is the result of the BatchGetProducts query.
+type ResultDTO struct {
+	// AsOf is the instant all prices were calculated for.
+	AsOf time.Time
+	// Items are in request order, one per requested ID.
+	Items []ItemDTO
+}
+
+// ItemDTO is the lookup result for one requested ID.
+type ItemDTO struct {
+	ProductID string
+	// Found is false when the product does not exist; Product is nil then.
+	Found   bool
+	Product *ProductDTO
+}
+
+// ProductDTO is a product with its effective price at the batch as-of time.
+type ProductDTO struct {
+	ID          string
+	Name        string
+	Description string
+	Category    string
+	Status      string
+	// Attributes are the typed product attributes by key.
+	Attributes map[string]attributes.AttributeDTO
+	// Variants are the product variants ordered by ID, priced like the
+	// product.
+	Variants []variants.VariantDTO
+
+	// PriceDTO is the price at the batch as-of time.
+	productview.PriceDTO
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// ArchivedAt is nil unless the product is archived.
+	ArchivedAt *time.Time
+}
@@ -0,0 +1,125 @@
+package batchgetproducts
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"product-catalog-service/internal/app/product/contracts"
+	"product-catalog-service/internal/app/product/domain/services"
+	"product-catalog-service/internal/app/product/queries/attributes"
+	"product-catalog-service/internal/app/product/queries/productview"
+	"product-catalog-service/internal/app/product/queries/rehydrate"
+	"product-catalog-service/internal/app/product/queries/variants"
+)
+
+// MaxProducts bounds the number of IDs in one batch.
+const MaxProducts = 200
+
+// Request represents input parameters for the BatchGetProducts query.
+type Request struct {
+	// ProductIDs are looked up in order; duplicates are allowed.
+	ProductIDs []string
+	// As-of time for price calculation; if zero, current time is used.
+	// All products are priced at the same instant.
+	Now time.Time
+	// Fields limits the products to the given fields, named as in the API
+	// (e.g. "name", "effective_price"); empty means all fields. Prices are
+	// only calculated when a price field is requested.
+	Fields []string
+}
+
+// fields are the API fields of a product in a batch.
+var fields = productview.NewFields(
+	"product_id", "name", "description", "category", "status",
+	"effective_price", "price_breakdown", "base_price",
+	"created_at", "updated_at", "archived_at", "attributes", "variants",
+)
+
+// Query implements "Get many products by ID with their effective prices".
+type Query struct {
+	readModel contracts.ReadModel
+	pricing   services.PricingCalculator
+}
+
+func New(readModel contracts.ReadModel, pricing services.PricingCalculator) *Query {
+	return &Query{
+		readModel: readModel,
+		pricing:   pricing,
+	}
+}
+
+// Execute runs the query. Products are read from one snapshot and priced at
+// one as-of time; missing products are reported as not found.
+func (q *Query) Execute(ctx context.Context, req Request) (*ResultDTO, error) {
+	if len(req.ProductIDs) > MaxProducts {
+		return nil, fmt.Errorf("too many product ids: %d > %d", len(req.ProductIDs), MaxProducts)
+	}
+	selected, err := fields.Select(req.Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	now := req.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	records, err := q.readModel.GetProductsByIDs(ctx, req.ProductIDs, selected)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ResultDTO{
+		AsOf:  now,
+		Items: make([]ItemDTO, 0, len(req.ProductIDs)),
+	}
+	for i, id := range req.ProductIDs {
+		item := ItemDTO{ProductID: id}
+		if records[i] != nil {
+			product, err := q.toProductDTO(records[i], selected, now)
+			if err != nil {
+				return nil, err
+			}
+			item.Found = true
+			item.Product = product
+		}
+		result.Items = append(result.Items, item)
+	}
+
+	return result, nil
+}
+
+// toProductDTO converts a record, calculating its prices when selected.
+func (q *Query) toProductDTO(record *contracts.ProductRecord, selected contracts.ProductFields, now time.Time) (*ProductDTO, error) {
+	dto := &ProductDTO{
+		ID:          record.ProductID,
+		Name:        record.Name,
+		Description: record.Description,
+		Category:    record.Category,
+		Status:      record.Status,
+		CreatedAt:   record.CreatedAt,
+		UpdatedAt:   record.UpdatedAt,
+		ArchivedAt:  record.ArchivedAt,
+		Attributes:  attributes.ToDTOs(record.Attributes),
+	}
+	if !selected.Has(contracts.ProductFieldPricing) {
+		return dto, nil
+	}
+
+	product, err := rehydrate.Product(record)
+	if err != nil {
+		return nil, err
+	}
+
+	// Calculate effective price at the as-of time (only applies valid discounts)
+	dto.PriceDTO, err = productview.Price(q.pricing, product, now)
+	if err != nil {
+		return nil, err
+	}
+	if selected.Has(contracts.ProductFieldVariants) {
+		dto.Variants = variants.ToDTOs(product, q.pricing, now)
+	}
+
+	return dto, nil
+}
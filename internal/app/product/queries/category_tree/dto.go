@@ -0,0 +1,54 @@
+package categorytree
+
+import "time"
+
+// CategoryDTO is a category as returned by the category queries.
+type CategoryDTO struct {
+	ID       string
+	Name     string
+	Slug     string
+	ParentID string
+	// SortOrder orders the category among its siblings, lowest first.
+	SortOrder int64
+	// Depth is the number of ancestors; 0 for root categories.
+	Depth int
+	// AttributeSchema declares the attributes of the category's products,
+	// sorted by key.
+	AttributeSchema []AttributeDefinitionDTO
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// AttributeDefinitionDTO is an attribute a category declares. Type is one
+// of string, number, bool, enum and measurement; AllowedValues is only set
+// for enums.
+type AttributeDefinitionDTO struct {
+	Key           string
+	Type          string
+	Required      bool
+	AllowedValues []string
+}
+
+// ToDTO converts a node for presentation.
+func ToDTO(n Node) CategoryDTO {
+	var schema []AttributeDefinitionDTO
+	for _, d := range n.Record.AttributeSchema {
+		schema = append(schema, AttributeDefinitionDTO{
+			Key:           d.Key,
+			Type:          d.Type,
+			Required:      d.Required,
+			AllowedValues: d.AllowedValues,
+		})
+	}
+	return CategoryDTO{
+		ID:              n.Record.CategoryID,
+		Name:            n.Record.Name,
+		Slug:            n.Record.Slug,
+		ParentID:        n.Record.ParentID,
+		SortOrder:       n.Record.SortOrder,
+		Depth:           n.Depth,
+		AttributeSchema: schema,
+		CreatedAt:       n.Record.CreatedAt,
+		UpdatedAt:       n.Record.UpdatedAt,
+	}
+}
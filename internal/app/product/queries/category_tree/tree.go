@@ -0,0 +1,118 @@
+// Package categorytree arranges category records into the tree shared by
+// the category queries and the listing's descendant filter.
+package categorytree
+
+import (
+	"sort"
+
+	"product-catalog-service/internal/app/product/contracts"
+)
+
+// Node is a category in walk order with its distance from the root.
+type Node struct {
+	Record *contracts.CategoryRecord
+	// Depth is 0 for root categories.
+	Depth int
+}
+
+// Tree is an immutable index of categories by ID, slug and parent.
+// Categories whose parent is missing are treated as roots.
+type Tree struct {
+	byID     map[string]*contracts.CategoryRecord
+	bySlug   map[string]*contracts.CategoryRecord
+	children map[string][]*contracts.CategoryRecord
+}
+
+// Build indexes the given categories. Siblings are ordered by sort order,
+// then name, then ID.
+func Build(records []*contracts.CategoryRecord) *Tree {
+	t := &Tree{
+		byID:     make(map[string]*contracts.CategoryRecord, len(records)),
+		bySlug:   make(map[string]*contracts.CategoryRecord, len(records)),
+		children: make(map[string][]*contracts.CategoryRecord),
+	}
+	for _, r := range records {
+		t.byID[r.CategoryID] = r
+		t.bySlug[r.Slug] = r
+	}
+	for _, r := range records {
+		parent := r.ParentID
+		if _, ok := t.byID[parent]; !ok {
+			parent = ""
+		}
+		t.children[parent] = append(t.children[parent], r)
+	}
+	for _, siblings := range t.children {
+		sort.Slice(siblings, func(i, j int) bool {
+			a, b := siblings[i], siblings[j]
+			if a.SortOrder != b.SortOrder {
+				return a.SortOrder < b.SortOrder
+			}
+			if a.Name != b.Name {
+				return a.Name < b.Name
+			}
+			return a.CategoryID < b.CategoryID
+		})
+	}
+	return t
+}
+
+// ByID returns the category with the given ID, or nil.
+func (t *Tree) ByID(id string) *contracts.CategoryRecord { return t.byID[id] }
+
+// BySlug returns the category with the given slug, or nil.
+func (t *Tree) BySlug(slug string) *contracts.CategoryRecord { return t.bySlug[slug] }
+
+// Depth returns the number of ancestors of the category.
+func (t *Tree) Depth(id string) int {
+	depth := 0
+	seen := map[string]bool{id: true}
+	for r := t.byID[id]; r != nil; r = t.byID[r.ParentID] {
+		if r.ParentID == "" || seen[r.ParentID] || t.byID[r.ParentID] == nil {
+			break
+		}
+		seen[r.ParentID] = true
+		depth++
+	}
+	return depth
+}
+
+// Walk returns the descendants of the category with the given ID,
+// depth-first, or the whole tree when id is empty. The category itself is
+// not included.
+func (t *Tree) Walk(id string) []Node {
+	var out []Node
+	depth := 0
+	if id != "" {
+		depth = t.Depth(id) + 1
+	}
+	seen := map[string]bool{id: true}
+	t.walk(id, depth, seen, &out)
+	return out
+}
+
+func (t *Tree) walk(id string, depth int, seen map[string]bool, out *[]Node) {
+	for _, child := range t.children[id] {
+		if seen[child.CategoryID] {
+			continue
+		}
+		seen[child.CategoryID] = true
+		*out = append(*out, Node{Record: child, Depth: depth})
+		t.walk(child.CategoryID, depth+1, seen, out)
+	}
+}
+
+// SubtreeSlugs returns the slug of the category with the given slug
+// followed by the slugs of its descendants. A slug without a category is
+// returned alone, so legacy product categories still match themselves.
+func (t *Tree) SubtreeSlugs(slug string) []string {
+	out := []string{slug}
+	r := t.bySlug[slug]
+	if r == nil {
+		return out
+	}
+	for _, n := range t.Walk(r.CategoryID) {
+		out = append(out, n.Record.Slug)
+	}
+	return out
+}
@@ -0,0 +1,24 @@
+package facets
+
+// CountDTO is the number of products with a facet value.
+type CountDTO struct {
+	Value string
+	Count int64
+}
+
+// PriceBucketDTO is the number of products with an effective price from
+// From (inclusive) to To (exclusive); To is empty for the last bucket.
+type PriceBucketDTO struct {
+	From  string
+	To    string
+	Count int64
+}
+
+// ResultDTO holds the counts of the selected facets; the others are empty.
+type ResultDTO struct {
+	Categories    []CountDTO
+	Statuses      []CountDTO
+	Discounted    int64
+	NotDiscounted int64
+	PriceBuckets  []PriceBucketDTO
+}
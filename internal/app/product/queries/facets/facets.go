@@ -0,0 +1,89 @@
+// Package facets parses the facet options shared by the listing and search
+// queries and converts the counts for presentation.
+package facets
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"product-catalog-service/internal/app/product/contracts"
+	"product-catalog-service/internal/app/product/domain"
+)
+
+// MaxPriceBuckets bounds the number of price buckets of a request.
+const MaxPriceBuckets = 20
+
+// ErrInvalidPriceBuckets is returned when Request.PriceBuckets are not
+// ascending non-negative decimals or are too many.
+var ErrInvalidPriceBuckets = errors.New("invalid price buckets")
+
+// Request selects the facets counted alongside a listing or search.
+type Request struct {
+	Category bool
+	Status   bool
+	// Discount counts the products on sale at the as-of time and the others.
+	Discount bool
+	// PriceBuckets are the ascending lower bounds of effective price
+	// buckets as decimal strings, e.g. ["0", "25", "50", "100"] for
+	// 0-25, 25-50, 50-100 and 100 or more; empty skips the price facet.
+	PriceBuckets []string
+}
+
+// Empty reports whether no facet is selected.
+func (r Request) Empty() bool {
+	return !r.Category && !r.Status && !r.Discount && len(r.PriceBuckets) == 0
+}
+
+// Parse validates a request and converts it for the read model.
+func Parse(req Request) (contracts.ProductFacets, error) {
+	out := contracts.ProductFacets{
+		Category: req.Category,
+		Status:   req.Status,
+		Discount: req.Discount,
+	}
+	if len(req.PriceBuckets) > MaxPriceBuckets {
+		return out, fmt.Errorf("%w: at most %d are allowed", ErrInvalidPriceBuckets, MaxPriceBuckets)
+	}
+	for i, s := range req.PriceBuckets {
+		bound, ok := new(big.Rat).SetString(s)
+		if !ok || bound.Sign() < 0 || strings.ContainsAny(s, "/eE") {
+			return out, fmt.Errorf("%w: %q is not a non-negative decimal", ErrInvalidPriceBuckets, s)
+		}
+		if i > 0 && bound.Cmp(out.PriceBuckets[i-1]) <= 0 {
+			return out, fmt.Errorf("%w: %q does not exceed %q", ErrInvalidPriceBuckets, s, req.PriceBuckets[i-1])
+		}
+		out.PriceBuckets = append(out.PriceBuckets, bound)
+	}
+	return out, nil
+}
+
+// ToDTO converts the counts of the read model.
+func ToDTO(counts *contracts.FacetCounts) *ResultDTO {
+	out := &ResultDTO{
+		Categories:    toCountDTOs(counts.Categories),
+		Statuses:      toCountDTOs(counts.Statuses),
+		Discounted:    counts.Discounted,
+		NotDiscounted: counts.NotDiscounted,
+	}
+	for _, b := range counts.PriceBuckets {
+		bucket := PriceBucketDTO{
+			From:  domain.ExactString(b.From),
+			Count: b.Count,
+		}
+		if b.To != nil {
+			bucket.To = domain.ExactString(b.To)
+		}
+		out.PriceBuckets = append(out.PriceBuckets, bucket)
+	}
+	return out
+}
+
+func toCountDTOs(counts []contracts.FacetCount) []CountDTO {
+	out := make([]CountDTO, 0, len(counts))
+	for _, c := range counts {
+		out = append(out, CountDTO{Value: c.Value, Count: c.Count})
+	}
+	return out
+}
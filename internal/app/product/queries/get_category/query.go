@@ -0,0 +1,45 @@
+package getcategory
+
+import (
+	"context"
+
+	"product-catalog-service/internal/app/product/contracts"
+	"product-catalog-service/internal/app/product/domain"
+	categorytree "product-catalog-service/internal/app/product/queries/category_tree"
+)
+
+// Request represents input parameters for the GetCategory query.
+// Exactly one of CategoryID and Slug is set.
+type Request struct {
+	CategoryID string
+	Slug       string
+}
+
+// Query implements "Show one category".
+type Query struct {
+	readModel contracts.CategoryReadModel
+}
+
+func New(readModel contracts.CategoryReadModel) *Query {
+	return &Query{readModel: readModel}
+}
+
+// Execute returns the category or domain.ErrCategoryNotFound.
+func (q *Query) Execute(ctx context.Context, req Request) (*categorytree.CategoryDTO, error) {
+	records, err := q.readModel.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tree := categorytree.Build(records)
+
+	record := tree.ByID(req.CategoryID)
+	if req.CategoryID == "" {
+		record = tree.BySlug(req.Slug)
+	}
+	if record == nil {
+		return nil, domain.ErrCategoryNotFound
+	}
+
+	dto := categorytree.ToDTO(categorytree.Node{Record: record, Depth: tree.Depth(record.CategoryID)})
+	return &dto, nil
+}
@@ -0,0 +1,36 @@
+package getpricehistory
+
+import "time"
+
+// PriceHistoryDTO is the response model for the GetPriceHistory query.
+type PriceHistoryDTO struct {
+	ProductID string
+	From      time.Time
+	To        time.Time
+
+	// Periods are the effective prices over [From, To) in time order.
+	Periods []PricePeriodDTO
+
+	// LowestPrice is the lowest effective price over [From, To);
+	// nil if the product had no price in that range.
+	LowestPrice *PriceDTO
+}
+
+// PricePeriodDTO is a time range [From, To) with a constant effective price.
+type PricePeriodDTO struct {
+	From           time.Time
+	To             time.Time
+	BasePrice      PriceDTO
+	EffectivePrice PriceDTO
+}
+
+// PriceDTO is an exact price together with its rounded presentation.
+type PriceDTO struct {
+	Currency string
+	// Exact is the exact price, e.g. "15.992" or "1/3".
+	Exact string
+	// Decimal is the rounded price, e.g. "15.99".
+	Decimal string
+	// MinorUnits is the rounded price in minor units, e.g. 1599.
+	MinorUnits int64
+}
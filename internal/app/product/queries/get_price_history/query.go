@@ -0,0 +1,99 @@
+package getpricehistory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"product-catalog-service/internal/app/product/contracts"
+	"product-catalog-service/internal/app/product/domain"
+	"product-catalog-service/internal/app/product/domain/services"
+	"product-catalog-service/internal/app/product/queries/rehydrate"
+)
+
+// DefaultWindow is the history range returned when none is requested.
+const DefaultWindow = 30 * 24 * time.Hour
+
+// Request represents input parameters for the GetPriceHistory query.
+type Request struct {
+	ProductID string
+	// From and To bound the history range [From, To). A zero To means now;
+	// a zero From means DefaultWindow before To.
+	From time.Time
+	To   time.Time
+}
+
+// Query implements "What did this product cost over time".
+type Query struct {
+	readModel contracts.ReadModel
+	pricing   services.PricingCalculator
+}
+
+func New(readModel contracts.ReadModel, pricing services.PricingCalculator) *Query {
+	return &Query{
+		readModel: readModel,
+		pricing:   pricing,
+	}
+}
+
+// Execute runs the query and returns the effective price periods and the
+// lowest effective price in the range.
+func (q *Query) Execute(ctx context.Context, req Request) (*PriceHistoryDTO, error) {
+	to := req.To
+	if to.IsZero() {
+		to = time.Now()
+	}
+	from := req.From
+	if from.IsZero() {
+		from = to.Add(-DefaultWindow)
+	}
+	if !from.Before(to) {
+		return nil, fmt.Errorf("history range is empty: from must be before to")
+	}
+
+	current, err := q.readModel.GetProductByID(ctx, req.ProductID, contracts.ProductFieldPricing)
+	if err != nil {
+		return nil, err
+	}
+	history, err := q.readModel.GetPriceHistory(ctx, req.ProductID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	snapshots, err := rehydrate.Snapshots(history, current)
+	if err != nil {
+		return nil, err
+	}
+
+	dto := &PriceHistoryDTO{
+		ProductID: req.ProductID,
+		From:      from,
+		To:        to,
+	}
+	for _, p := range q.pricing.Timeline(snapshots, from, to) {
+		dto.Periods = append(dto.Periods, PricePeriodDTO{
+			From:           p.From,
+			To:             p.To,
+			BasePrice:      q.toPriceDTO(p.BasePrice),
+			EffectivePrice: q.toPriceDTO(p.EffectivePrice),
+		})
+	}
+	if lowest := q.pricing.LowestPrice(snapshots, from, to); lowest != nil {
+		price := q.toPriceDTO(lowest)
+		dto.LowestPrice = &price
+	}
+
+	return dto, nil
+}
+
+func (q *Query) toPriceDTO(price *domain.Money) PriceDTO {
+	decimal, minor := q.pricing.RoundedPrice(price)
+	dto := PriceDTO{
+		Currency: string(price.Currency()),
+		Exact:    price.String(),
+		Decimal:  decimal,
+	}
+	if minor.IsInt64() {
+		dto.MinorUnits = minor.Int64()
+	}
+	return dto
+}
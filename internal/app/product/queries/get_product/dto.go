@@ -1,16 +1,78 @@
 package getproduct
 
+import (
+	"time"
+
+	"product-catalog-service/internal/app/product/queries/attributes"
+	"product-catalog-service/internal/app/product/queries/productview"
+	"product-catalog-service/internal/app/product/queries/variants"
+)
+
 // ProductDTO is the response model for the GetProduct query.
 // Prices are exposed as rational numerator/denominator pair to
-// preserve full precision for callers.
+// preserve full precision for callers, together with the price
+// rounded to the currency minor unit.
 type ProductDTO struct {
 	ID          string
 	Name        string
 	Description string
 	Category    string
 	Status      string
+	// Attributes are the typed product attributes by key.
+	Attributes map[string]attributes.AttributeDTO
+	// Variants are the product variants ordered by ID, priced like the
+	// product.
+	Variants []variants.VariantDTO
+
+	// AsOf is the instant the prices were calculated for.
+	AsOf time.Time
+	// PriceDTO is the price at AsOf.
+	productview.PriceDTO
+
+	// LowestPrice* is the lowest effective price over the previous
+	// LowestPriceDays days, including the current price.
+	LowestPriceDays       int
+	LowestPriceExact      string
+	LowestPriceDecimal    string
+	LowestPriceMinorUnits int64
 
-	EffectivePriceNumerator   int64
-	EffectivePriceDenominator int64
+	// Discounts are all discounts of the product, including those not
+	// valid at AsOf.
+	Discounts []DiscountDTO
+	// ScheduledPrices are the future base prices not yet applied.
+	ScheduledPrices []ScheduledPriceDTO
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// ArchivedAt is nil unless the product is archived.
+	ArchivedAt *time.Time
+}
+
+// DiscountDTO is a discount of the product with its validity window.
+type DiscountDTO struct {
+	ID   string
+	Kind string
+	// Percentage is the exact fraction taken off, e.g. "0.2"; empty
+	// unless Kind is "percentage".
+	Percentage string
+	// Amount is the exact amount off or the override price; empty for
+	// percentage discounts.
+	Amount    string
+	Start     time.Time
+	End       time.Time
+	Priority  int
+	Exclusive bool
 }
 
+// ScheduledPriceDTO is a base price taking effect at EffectiveFrom.
+type ScheduledPriceDTO struct {
+	ID            string
+	EffectiveFrom time.Time
+	// PriceExact is the exact price, e.g. "15.992".
+	PriceExact string
+	// PriceDecimal is the rounded price, e.g. "15.99".
+	PriceDecimal string
+	// PriceMinorUnits is the rounded price in minor units, e.g. 1599;
+	// zero when it does not fit in int64.
+	PriceMinorUnits int64
+}
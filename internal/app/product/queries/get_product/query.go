@@ -2,21 +2,43 @@ package getproduct
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"product-catalog-service/internal/app/product/contracts"
 	"product-catalog-service/internal/app/product/domain"
 	"product-catalog-service/internal/app/product/domain/services"
+	"product-catalog-service/internal/app/product/queries/attributes"
+	"product-catalog-service/internal/app/product/queries/productview"
+	"product-catalog-service/internal/app/product/queries/rehydrate"
+	"product-catalog-service/internal/app/product/queries/variants"
 )
 
+// DefaultLowestPriceDays is the lookback used for the lowest price when
+// none is requested (30 days, as required for EU Omnibus disclosure).
+const DefaultLowestPriceDays = 30
+
 // Request represents input parameters for GetProduct query.
 type Request struct {
 	ProductID string
 	// As-of time for price calculation; if zero, current time is used.
 	Now time.Time
+	// LowestPriceDays is the lookback for the lowest effective price;
+	// if zero, DefaultLowestPriceDays is used.
+	LowestPriceDays int
+	// Fields limits the result to the given fields, named as in the API
+	// (e.g. "name", "effective_price"); empty means all fields. Prices are
+	// only calculated when a price field is requested.
+	Fields []string
 }
 
+// fields are the API fields of a product.
+var fields = productview.NewFields(
+	"product_id", "name", "description", "category", "status",
+	"effective_price", "lowest_price", "lowest_price_days", "price_breakdown",
+	"base_price", "discounts", "scheduled_prices",
+	"created_at", "updated_at", "archived_at", "attributes", "variants",
+)
+
 // Query implements "Get product by ID with current effective price".
 type Query struct {
 	readModel contracts.ReadModel
@@ -31,66 +53,127 @@ func New(readModel contracts.ReadModel, pricing services.PricingCalculator) *Que
 }
 
 // Execute runs the query and returns a DTO with current effective price.
+// Fields not selected by req.Fields are left empty.
 func (q *Query) Execute(ctx context.Context, req Request) (*ProductDTO, error) {
-	record, err := q.readModel.GetProductByID(ctx, req.ProductID)
+	selected, err := fields.Select(req.Fields)
 	if err != nil {
 		return nil, err
 	}
 
+	record, err := q.readModel.GetProductByID(ctx, req.ProductID, selected)
+	if err != nil {
+		return nil, err
+	}
+
+	dto := &ProductDTO{
+		ID:          record.ProductID,
+		Name:        record.Name,
+		Description: record.Description,
+		Category:    record.Category,
+		Status:      record.Status,
+		CreatedAt:   record.CreatedAt,
+		UpdatedAt:   record.UpdatedAt,
+		ArchivedAt:  record.ArchivedAt,
+		Attributes:  attributes.ToDTOs(record.Attributes),
+	}
+	if !selected.Has(contracts.ProductFieldPricing) {
+		return dto, nil
+	}
+
 	now := req.Now
 	if now.IsZero() {
 		now = time.Now()
 	}
 
-	basePrice, err := domain.NewMoneyFromFraction(
-		record.BasePriceNumerator,
-		record.BasePriceDenominator,
-	)
+	product, err := rehydrate.Product(record)
 	if err != nil {
 		return nil, err
 	}
 
-	var discount *domain.Discount
-	if record.DiscountPercent != nil && record.DiscountStart != nil && record.DiscountEnd != nil {
-		discount, err = domain.NewDiscount(
-			record.DiscountPercent,
-			*record.DiscountStart,
-			*record.DiscountEnd,
-		)
-		if err != nil {
-			// if stored discount is invalid, treat as no discount
-			discount = nil
-		}
+	// Calculate effective price at the as-of time (only applies valid discounts)
+	dto.PriceDTO, err = productview.Price(q.pricing, product, now)
+	if err != nil {
+		return nil, err
+	}
+	dto.AsOf = now
+	if selected.Has(contracts.ProductFieldVariants) {
+		dto.Variants = variants.ToDTOs(product, q.pricing, now)
 	}
+	dto.Discounts = toDiscountDTOs(product.Discounts())
+	dto.ScheduledPrices = q.toScheduledPriceDTOs(product.ScheduledPrices())
 
-	product := domain.RehydrateProduct(
-		record.ProductID,
-		record.Name,
-		record.Description,
-		record.Category,
-		basePrice,
-		discount,
-		domain.ProductStatus(record.Status),
-		nil, // archivedAt not required for this query
-		time.Time{}, // createdAt not required
-		time.Time{}, // updatedAt not required
-	)
-
-	// Calculate effective price at current time (only applies valid discounts)
-	effective := q.pricing.EffectivePrice(product, now)
-	if effective == nil {
-		return nil, fmt.Errorf("failed to calculate effective price")
-	}
-	num, den := effective.Fraction()
-
-	return &ProductDTO{
-		ID:                       record.ProductID,
-		Name:                     record.Name,
-		Description:              record.Description,
-		Category:                 record.Category,
-		Status:                   record.Status,
-		EffectivePriceNumerator:   num,
-		EffectivePriceDenominator: den,
-	}, nil
+	if !productview.Selected(req.Fields, "lowest_price", "lowest_price_days") {
+		return dto, nil
+	}
+
+	// Lowest effective price over the previous days, including now
+	days := req.LowestPriceDays
+	if days <= 0 {
+		days = DefaultLowestPriceDays
+	}
+	from := now.AddDate(0, 0, -days)
+	history, err := q.readModel.GetPriceHistory(ctx, req.ProductID, from, now)
+	if err != nil {
+		return nil, err
+	}
+	snapshots, err := rehydrate.Snapshots(history, record)
+	if err != nil {
+		return nil, err
+	}
+	dto.LowestPriceDays = days
+	lowest := q.pricing.LowestPrice(snapshots, from, now.Add(time.Nanosecond))
+	if lowest == nil {
+		dto.LowestPriceExact = dto.EffectivePriceExact
+		dto.LowestPriceDecimal = dto.EffectivePriceDecimal
+		dto.LowestPriceMinorUnits = dto.EffectivePriceMinorUnits
+		return dto, nil
+	}
+	lowestDecimal, lowestMinor := q.pricing.RoundedPrice(lowest)
+
+	dto.LowestPriceExact = lowest.String()
+	dto.LowestPriceDecimal = lowestDecimal
+	dto.LowestPriceMinorUnits = lowestMinor.Int64()
+
+	return dto, nil
 }
 
+// toDiscountDTOs converts all discounts of a product.
+func toDiscountDTOs(discounts []*domain.Discount) []DiscountDTO {
+	out := make([]DiscountDTO, 0, len(discounts))
+	for _, d := range discounts {
+		dto := DiscountDTO{
+			ID:        d.ID(),
+			Kind:      string(d.Kind()),
+			Start:     d.StartAt(),
+			End:       d.EndAt(),
+			Priority:  d.Priority(),
+			Exclusive: d.Exclusive(),
+		}
+		if d.Kind() == domain.DiscountKindFixedAmount || d.Kind() == domain.DiscountKindPriceOverride {
+			dto.Amount = d.Amount().String()
+		} else {
+			dto.Percentage = domain.ExactString(d.Percentage())
+		}
+		out = append(out, dto)
+	}
+	return out
+}
+
+// toScheduledPriceDTOs converts the scheduled prices of a product.
+func (q *Query) toScheduledPriceDTOs(scheduled []*domain.ScheduledPrice) []ScheduledPriceDTO {
+	out := make([]ScheduledPriceDTO, 0, len(scheduled))
+	for _, s := range scheduled {
+		decimal, minor := q.pricing.RoundedPrice(s.Price())
+		dto := ScheduledPriceDTO{
+			ID:            s.ID(),
+			EffectiveFrom: s.EffectiveFrom(),
+			PriceExact:    s.Price().String(),
+			PriceDecimal:  decimal,
+		}
+		if minor.IsInt64() {
+			dto.PriceMinorUnits = minor.Int64()
+		}
+		out = append(out, dto)
+	}
+	return out
+}
@@ -0,0 +1,23 @@
+package getrecategorization
+
+import "time"
+
+// RecategorizationDTO is the response model for the GetRecategorization query.
+type RecategorizationDTO struct {
+	ID           string
+	FromCategory string
+	ToCategory   string
+	// Status is "running", "succeeded" or "failed".
+	Status string
+	// Failure describes why a failed job stopped.
+	Failure string
+	// MovedProducts is the number of products moved so far.
+	MovedProducts int64
+	// RemainingProducts is the number of products still in FromCategory,
+	// archived ones included.
+	RemainingProducts int64
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	// FinishedAt is nil while the job is running.
+	FinishedAt *time.Time
+}
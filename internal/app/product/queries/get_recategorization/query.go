@@ -0,0 +1,59 @@
+package getrecategorization
+
+import (
+	"context"
+	"time"
+
+	"product-catalog-service/internal/app/product/contracts"
+)
+
+// Request represents input parameters for the GetRecategorization query.
+type Request struct {
+	JobID string
+}
+
+// Query implements "How far along is this recategorization".
+type Query struct {
+	readModel contracts.ReadModel
+}
+
+func New(readModel contracts.ReadModel) *Query {
+	return &Query{readModel: readModel}
+}
+
+// Execute returns the job's progress or domain.ErrRecategorizationNotFound.
+func (q *Query) Execute(ctx context.Context, req Request) (*RecategorizationDTO, error) {
+	record, err := q.readModel.GetRecategorization(ctx, req.JobID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Remaining products are counted like a category facet
+	from := record.FromCategory
+	counts, err := q.readModel.CountProductFacets(
+		ctx,
+		contracts.ProductFilter{Category: &from, IncludeArchived: true},
+		contracts.ProductFacets{Category: true},
+		time.Time{},
+	)
+	if err != nil {
+		return nil, err
+	}
+	var remaining int64
+	for _, c := range counts.Categories {
+		remaining += c.Count
+	}
+
+	return &RecategorizationDTO{
+		ID:                record.JobID,
+		FromCategory:      record.FromCategory,
+		ToCategory:        record.ToCategory,
+		Status:            record.Status,
+		Failure:           record.Failure,
+		MovedProducts:     record.MovedProducts,
+		RemainingProducts: remaining,
+		CreatedAt:         record.CreatedAt,
+		UpdatedAt:         record.UpdatedAt,
+		FinishedAt:        record.FinishedAt,
+	}, nil
+}
@@ -0,0 +1,54 @@
+package listcategories
+
+import (
+	"context"
+
+	"product-catalog-service/internal/app/product/contracts"
+	"product-catalog-service/internal/app/product/domain"
+	categorytree "product-catalog-service/internal/app/product/queries/category_tree"
+)
+
+// Request represents input parameters for the ListCategories query.
+type Request struct {
+	// ParentID limits the result to the descendants of a category; empty
+	// returns the whole tree.
+	ParentID string
+}
+
+// ListResultDTO is the result of the ListCategories query.
+type ListResultDTO struct {
+	// Categories are in depth-first order, siblings by sort order then
+	// name, so each category follows its parent.
+	Categories []categorytree.CategoryDTO
+}
+
+// Query implements "Show the category tree". Category trees are small, so
+// the result is not paginated.
+type Query struct {
+	readModel contracts.CategoryReadModel
+}
+
+func New(readModel contracts.CategoryReadModel) *Query {
+	return &Query{readModel: readModel}
+}
+
+// Execute returns the categories under req.ParentID or
+// domain.ErrCategoryNotFound if it does not exist.
+func (q *Query) Execute(ctx context.Context, req Request) (*ListResultDTO, error) {
+	records, err := q.readModel.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tree := categorytree.Build(records)
+
+	if req.ParentID != "" && tree.ByID(req.ParentID) == nil {
+		return nil, domain.ErrCategoryNotFound
+	}
+
+	nodes := tree.Walk(req.ParentID)
+	result := &ListResultDTO{Categories: make([]categorytree.CategoryDTO, 0, len(nodes))}
+	for _, n := range nodes {
+		result.Categories = append(result.Categories, categorytree.ToDTO(n))
+	}
+	return result, nil
+}
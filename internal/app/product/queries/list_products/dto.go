@@ -1,19 +1,33 @@
 package listproducts
 
+import (
+	"time"
+
+	"product-catalog-service/internal/app/product/queries/attributes"
+	"product-catalog-service/internal/app/product/queries/facets"
+	"product-catalog-service/internal/app/product/queries/productview"
+)
+
 // ProductListItemDTO represents a single item in the products list.
 type ProductListItemDTO struct {
 	ID       string
 	Name     string
 	Category string
 	Status   string
+	// Attributes are the typed product attributes by key.
+	Attributes map[string]attributes.AttributeDTO
 
-	EffectivePriceNumerator   int64
-	EffectivePriceDenominator int64
+	// AsOf is the instant the prices were calculated for.
+	AsOf time.Time
+	// PriceDTO is the price at AsOf.
+	productview.PriceDTO
 }
 
 // ListResultDTO is the result of the ListProducts query.
 type ListResultDTO struct {
 	Items         []ProductListItemDTO
 	NextPageToken string
+	// Facets is nil unless facets were requested.
+	Facets *facets.ResultDTO
 }
 
@@ -2,11 +2,26 @@ package listproducts
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"product-catalog-service/internal/app/product/contracts"
 	"product-catalog-service/internal/app/product/domain"
 	"product-catalog-service/internal/app/product/domain/services"
+	"product-catalog-service/internal/app/product/queries/attributes"
+	categorytree "product-catalog-service/internal/app/product/queries/category_tree"
+	"product-catalog-service/internal/app/product/queries/facets"
+	"product-catalog-service/internal/app/product/queries/productview"
+	"product-catalog-service/internal/app/product/queries/rehydrate"
+	"product-catalog-service/internal/pkg/filter"
+	"product-catalog-service/internal/pkg/pagetoken"
 )
 
 // Request represents input parameters for the ListProducts query.
@@ -16,96 +31,356 @@ type Request struct {
 	PageToken  string
 	// As-of time for price calculation; if zero, current time is used.
 	Now time.Time
+	// Admin lists products in any status for back-office users. Otherwise
+	// only active products are listed and Statuses and IncludeArchived are
+	// ignored.
+	Admin bool
+	// Statuses limits an admin listing to the given statuses; empty means
+	// any status.
+	Statuses []domain.ProductStatus
+	// IncludeArchived adds archived products to an admin listing. Listing
+	// the archived status implies it.
+	IncludeArchived bool
+	// Filter is an AIP-160 style filter expression over FilterSchema, e.g.
+	// `category = "shoes" AND price < 50`; empty matches all.
+	Filter string
+	// MinPrice and MaxPrice bound the effective price at the as-of time,
+	// inclusive, as decimal strings (e.g. "19.99"); empty means unbounded.
+	MinPrice string
+	MaxPrice string
+	// OrderBy is an AIP-132 order such as "name" or "price desc" over
+	// SortFields; empty orders by product_id.
+	OrderBy string
+	// Fields limits the items to the given fields, named as in the API
+	// (e.g. "name", "effective_price"); empty means all fields. Prices are
+	// only calculated when a price field is requested.
+	Fields []string
+	// Facets selects the facet counts returned over all matching products,
+	// not just the page.
+	Facets facets.Request
+	// IncludeDescendants widens Category to the products of its
+	// descendant categories too.
+	IncludeDescendants bool
 }
 
+// FilterSchema lists the fields usable in Request.Filter.
+// price compares the effective price at the as-of time, like MinPrice,
+// MaxPrice and ordering by price; has_discount is true when a discount is
+// valid at the as-of time. attributes.<key> compares a product attribute
+// with a string, number or bool, e.g. attributes.size >= 42; measurements
+// compare in their stored unit.
+var FilterSchema = filter.Schema{
+	"product_id":   filter.TypeString,
+	"name":         filter.TypeString,
+	"category":     filter.TypeString,
+	"status":       filter.TypeString,
+	"currency":     filter.TypeString,
+	"price":        filter.TypeNumber,
+	"has_discount": filter.TypeBool,
+	"created_at":   filter.TypeTimestamp,
+	"updated_at":   filter.TypeTimestamp,
+	"attributes.*": filter.TypeAny,
+}
+
+// SortFields lists the fields usable in Request.OrderBy. price orders by
+// the effective price at the as-of time. Ties are broken by product_id.
+var SortFields = map[string]contracts.ProductSortField{
+	"product_id": contracts.ProductSortID,
+	"name":       contracts.ProductSortName,
+	"created_at": contracts.ProductSortCreatedAt,
+	"updated_at": contracts.ProductSortUpdatedAt,
+	"price":      contracts.ProductSortPrice,
+}
+
+// ErrInvalidOrderBy is returned when Request.OrderBy cannot be parsed.
+var ErrInvalidOrderBy = errors.New("invalid order_by")
+
+// ErrInvalidPriceRange is returned when Request.MinPrice or MaxPrice is not
+// a non-negative decimal or MinPrice exceeds MaxPrice.
+var ErrInvalidPriceRange = errors.New("invalid price range")
+
+// fields are the API fields of a list item.
+var fields = productview.NewFields(
+	"product_id", "name", "category", "status",
+	"effective_price", "price_breakdown", "attributes",
+)
+
 // Query implements "List active products with pagination" and
-// optional filtering by category.
+// optional filtering by category, and the back-office listing of products
+// in any status.
 type Query struct {
 	readModel contracts.ReadModel
 	pricing   services.PricingCalculator
+	tokens    *pagetoken.Codec
 }
 
-func New(readModel contracts.ReadModel, pricing services.PricingCalculator) *Query {
+func New(readModel contracts.ReadModel, pricing services.PricingCalculator, tokens *pagetoken.Codec) *Query {
 	return &Query{
 		readModel: readModel,
 		pricing:   pricing,
+		tokens:    tokens,
 	}
 }
 
-// Execute runs the list query.
+// Execute runs the list query. Fields not selected by req.Fields are left
+// empty.
 func (q *Query) Execute(ctx context.Context, req Request) (*ListResultDTO, error) {
+	selected, err := fields.Select(req.Fields)
+	if err != nil {
+		return nil, err
+	}
+
 	now := req.Now
 	if now.IsZero() {
 		now = time.Now()
 	}
 
-	records, nextToken, err := q.readModel.ListActiveProducts(
+	productFilter, err := listFilter(req, now)
+	if err != nil {
+		return nil, err
+	}
+	if req.IncludeDescendants && req.Category != nil && *req.Category != "" {
+		records, err := q.readModel.ListCategories(ctx)
+		if err != nil {
+			return nil, err
+		}
+		productFilter.Categories = categorytree.Build(records).SubtreeSlugs(*req.Category)
+		productFilter.Category = nil
+	}
+
+	order, err := parseOrderBy(req.OrderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	productFacets, err := facets.Parse(req.Facets)
+	if err != nil {
+		return nil, err
+	}
+
+	// Page tokens are only valid for the filter and order they were issued
+	// for; later pages are read at the first page's snapshot and, unless
+	// requested otherwise, priced at its as-of time
+	hash := filterHash(req, productFilter)
+	var cursor string
+	var readTime time.Time
+	if req.PageToken != "" {
+		token, err := q.tokens.Check(req.PageToken, time.Now(), hash, order.String())
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", contracts.ErrInvalidPageToken, err)
+		}
+		cursor = token.Cursor
+		readTime = token.ReadTime
+		if req.Now.IsZero() && !token.AsOf.IsZero() {
+			now = token.AsOf
+			productFilter.AsOf = now
+		}
+	}
+
+	page, err := q.readModel.ListProducts(
 		ctx,
-		req.Category,
+		productFilter,
+		order,
 		req.PageSize,
-		req.PageToken,
+		cursor,
+		readTime,
+		selected,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	items := make([]ProductListItemDTO, 0, len(records))
+	if readTime.IsZero() {
+		readTime = page.ReadTime
+	}
 
-	for _, r := range records {
-		basePrice, err := domain.NewMoneyFromFraction(
-			r.BasePriceNumerator,
-			r.BasePriceDenominator,
-		)
+	// Facets are counted at the snapshot of the page
+	var facetCounts *facets.ResultDTO
+	if !req.Facets.Empty() {
+		counts, err := q.readModel.CountProductFacets(ctx, productFilter, productFacets, readTime)
 		if err != nil {
 			return nil, err
 		}
+		facetCounts = facets.ToDTO(counts)
+	}
 
-		var discount *domain.Discount
-		if r.DiscountPercent != nil && r.DiscountStart != nil && r.DiscountEnd != nil {
-			discount, err = domain.NewDiscount(
-				r.DiscountPercent,
-				*r.DiscountStart,
-				*r.DiscountEnd,
-			)
-			if err != nil {
-				discount = nil
-			}
+	nextToken := ""
+	if page.NextCursor != "" {
+		nextToken, err = q.tokens.Encode(pagetoken.Token{
+			Cursor:     page.NextCursor,
+			FilterHash: hash,
+			Sort:       order.String(),
+			ReadTime:   readTime,
+			AsOf:       now,
+			IssuedAt:   time.Now(),
+		})
+		if err != nil {
+			return nil, err
 		}
+	}
 
-		product := domain.RehydrateProduct(
-			r.ProductID,
-			r.Name,
-			r.Description,
-			r.Category,
-			basePrice,
-			discount,
-			domain.ProductStatus(r.Status),
-			nil,
-			time.Time{},
-			time.Time{},
-		)
-
-		// Calculate effective price at current time (only applies valid discounts)
-		effective := q.pricing.EffectivePrice(product, now)
-		if effective == nil {
-			// Skip this item if price calculation fails
+	items := make([]ProductListItemDTO, 0, len(page.Records))
+
+	for _, r := range page.Records {
+		item := ProductListItemDTO{
+			ID:         r.ProductID,
+			Name:       r.Name,
+			Category:   r.Category,
+			Status:     r.Status,
+			Attributes: attributes.ToDTOs(r.Attributes),
+		}
+		if !selected.Has(contracts.ProductFieldPricing) {
+			items = append(items, item)
 			continue
 		}
-		num, den := effective.Fraction()
-
-		items = append(items, ProductListItemDTO{
-			ID:                        r.ProductID,
-			Name:                      r.Name,
-			Category:                  r.Category,
-			Status:                    r.Status,
-			EffectivePriceNumerator:   num,
-			EffectivePriceDenominator: den,
-		})
+
+		product, err := rehydrate.Product(r)
+		if err != nil {
+			return nil, err
+		}
+
+		// Calculate effective price at the as-of time (only applies valid discounts)
+		item.PriceDTO, err = productview.Price(q.pricing, product, now)
+		if err != nil {
+			return nil, err
+		}
+		item.AsOf = now
+		items = append(items, item)
 	}
 
 	return &ListResultDTO{
 		Items:         items,
 		NextPageToken: nextToken,
+		Facets:        facetCounts,
 	}, nil
 }
 
+// listFilter builds the read-model filter of a listing. Storefront
+// listings are restricted to active products.
+func listFilter(req Request, now time.Time) (contracts.ProductFilter, error) {
+	expr, err := filter.Parse(req.Filter, FilterSchema)
+	if err != nil {
+		return contracts.ProductFilter{}, err
+	}
+
+	minPrice, err := parsePrice(req.MinPrice)
+	if err != nil {
+		return contracts.ProductFilter{}, err
+	}
+	maxPrice, err := parsePrice(req.MaxPrice)
+	if err != nil {
+		return contracts.ProductFilter{}, err
+	}
+	if minPrice != nil && maxPrice != nil && minPrice.Cmp(maxPrice) > 0 {
+		return contracts.ProductFilter{}, fmt.Errorf("%w: min_price %s exceeds max_price %s", ErrInvalidPriceRange, req.MinPrice, req.MaxPrice)
+	}
+
+	out := contracts.ProductFilter{
+		Category: req.Category,
+		Expr:     expr,
+		MinPrice: minPrice,
+		MaxPrice: maxPrice,
+		AsOf:     now,
+	}
+	if !req.Admin {
+		out.Statuses = []string{string(domain.ProductStatusActive)}
+		return out, nil
+	}
+
+	out.IncludeArchived = req.IncludeArchived
+	for _, s := range req.Statuses {
+		switch s {
+		case domain.ProductStatusDraft, domain.ProductStatusActive, domain.ProductStatusInactive:
+		case domain.ProductStatusArchived:
+			out.IncludeArchived = true
+		default:
+			return contracts.ProductFilter{}, fmt.Errorf("unknown product status %q", s)
+		}
+		out.Statuses = append(out.Statuses, string(s))
+	}
+	return out, nil
+}
+
+// filterHash identifies the parameters selecting the listed products. An
+// explicit as-of time is included as it changes discount-dependent filters.
+func filterHash(req Request, productFilter contracts.ProductFilter) string {
+	statuses := append([]string(nil), productFilter.Statuses...)
+	sort.Strings(statuses)
+
+	category := ""
+	if req.Category != nil {
+		category = *req.Category
+	}
+	asOf := ""
+	if !req.Now.IsZero() {
+		asOf = req.Now.UTC().Format(time.RFC3339Nano)
+	}
+
+	h := sha256.New()
+	for _, part := range []string{
+		category,
+		strconv.FormatBool(req.IncludeDescendants),
+		strings.Join(statuses, ","),
+		strconv.FormatBool(productFilter.IncludeArchived),
+		req.Filter,
+		req.MinPrice,
+		req.MaxPrice,
+		asOf,
+	} {
+		// Length-prefixed so parts cannot run into each other
+		fmt.Fprintf(h, "%d:%s;", len(part), part)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// parsePrice parses a price bound; empty means unbounded.
+func parsePrice(s string) (*big.Rat, error) {
+	if s == "" {
+		return nil, nil
+	}
+	price, ok := new(big.Rat).SetString(s)
+	if !ok || price.Sign() < 0 || strings.ContainsAny(s, "/eE") {
+		return nil, fmt.Errorf("%w: %q is not a non-negative decimal", ErrInvalidPriceRange, s)
+	}
+	return price, nil
+}
+
+// parseOrderBy parses an AIP-132 order of a single field with an optional
+// "asc" or "desc"; a trailing product_id tie-break is accepted as it is
+// always applied.
+func parseOrderBy(orderBy string) (contracts.ProductOrder, error) {
+	var order contracts.ProductOrder
+	if strings.TrimSpace(orderBy) == "" {
+		return order, nil
+	}
+	terms := strings.Split(orderBy, ",")
+	for i, term := range terms {
+		parts := strings.Fields(term)
+		if len(parts) == 0 || len(parts) > 2 {
+			return order, fmt.Errorf("%w: %q", ErrInvalidOrderBy, orderBy)
+		}
+		field, ok := SortFields[parts[0]]
+		if !ok {
+			return order, fmt.Errorf("%w: cannot order by %q", ErrInvalidOrderBy, parts[0])
+		}
+		descending := false
+		if len(parts) == 2 {
+			switch parts[1] {
+			case "asc":
+			case "desc":
+				descending = true
+			default:
+				return order, fmt.Errorf("%w: unknown direction %q", ErrInvalidOrderBy, parts[1])
+			}
+		}
+
+		if i == 0 {
+			order = contracts.ProductOrder{Field: field, Descending: descending}
+			continue
+		}
+		if i > 1 || field != contracts.ProductSortID || descending != order.Descending {
+			return order, fmt.Errorf("%w: only a product_id tie-break in the same direction may follow %q", ErrInvalidOrderBy, strings.TrimSpace(terms[0]))
+		}
+	}
+	return order, nil
+}
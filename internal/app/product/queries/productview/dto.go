@@ -0,0 +1,42 @@
+package productview
+
+// PriceDTO is the price of a product at an as-of time, exact and rounded
+// to the currency minor unit.
+type PriceDTO struct {
+	Currency string
+
+	// EffectivePriceNumerator and EffectivePriceDenominator are the exact
+	// price as a fraction; both are zero when it does not fit in int64.
+	EffectivePriceNumerator   int64
+	EffectivePriceDenominator int64
+	// EffectivePriceExact is the exact price, e.g. "15.992" or "1/3".
+	EffectivePriceExact string
+	// EffectivePriceDecimal is the rounded price, e.g. "15.99".
+	EffectivePriceDecimal string
+	// EffectivePriceMinorUnits is the rounded price in minor units, e.g. 1599.
+	EffectivePriceMinorUnits int64
+
+	// BasePrice* is the base price in force at the as-of time.
+	BasePriceExact      string
+	BasePriceDecimal    string
+	BasePriceMinorUnits int64
+	// AppliedDiscounts are the discounts applied at the as-of time, in the
+	// order they are combined.
+	AppliedDiscounts []AppliedDiscountDTO
+	// DiscountAmount* is the base price minus the effective price.
+	DiscountAmountExact      string
+	DiscountAmountDecimal    string
+	DiscountAmountMinorUnits int64
+}
+
+// AppliedDiscountDTO is a discount taking part in the effective price.
+type AppliedDiscountDTO struct {
+	ID   string
+	Kind string
+	// Percentage is the exact fraction taken off, e.g. "0.2"; empty
+	// unless Kind is "percentage".
+	Percentage string
+	// Amount is the exact amount off or the override price; empty for
+	// percentage discounts.
+	Amount string
+}
@@ -0,0 +1,86 @@
+// Package productview holds the field selection and price calculation
+// shared by the product queries.
+package productview
+
+import (
+	"fmt"
+
+	"product-catalog-service/internal/app/product/contracts"
+)
+
+// fieldSources maps the API field names to the record fields they are
+// built from.
+var fieldSources = map[string]contracts.ProductFields{
+	"product_id":        0,
+	"name":              contracts.ProductFieldName,
+	"description":       contracts.ProductFieldDescription,
+	"category":          contracts.ProductFieldCategory,
+	"status":            contracts.ProductFieldStatus,
+	"effective_price":   contracts.ProductFieldPricing,
+	"lowest_price":      contracts.ProductFieldPricing,
+	"lowest_price_days": contracts.ProductFieldPricing,
+	"price_breakdown":   contracts.ProductFieldPricing,
+	"base_price":        contracts.ProductFieldPricing,
+	"discounts":         contracts.ProductFieldPricing,
+	"scheduled_prices":  contracts.ProductFieldPricing,
+	"created_at":        contracts.ProductFieldTimestamps,
+	"updated_at":        contracts.ProductFieldTimestamps,
+	"archived_at":       contracts.ProductFieldTimestamps,
+	"attributes":        contracts.ProductFieldAttributes,
+	"variants":          contracts.ProductFieldVariants | contracts.ProductFieldPricing,
+}
+
+// Fields are the API fields a query supports, mapped to the record fields
+// they are built from.
+type Fields map[string]contracts.ProductFields
+
+// NewFields returns the fields with the given API names. It panics on an
+// unknown name.
+func NewFields(names ...string) Fields {
+	out := make(Fields, len(names))
+	for _, name := range names {
+		source, ok := fieldSources[name]
+		if !ok {
+			panic(fmt.Sprintf("productview: unknown product field %q", name))
+		}
+		out[name] = source
+	}
+	return out
+}
+
+// Select returns the record fields needed for the requested API fields.
+// Requesting none selects all record fields, variants only when the query
+// supports them.
+func (f Fields) Select(names []string) (contracts.ProductFields, error) {
+	if len(names) == 0 {
+		if _, ok := f["variants"]; !ok {
+			return contracts.AllProductFields &^ contracts.ProductFieldVariants, nil
+		}
+		return contracts.AllProductFields, nil
+	}
+	var fields contracts.ProductFields
+	for _, name := range names {
+		source, ok := f[name]
+		if !ok {
+			return 0, fmt.Errorf("unknown product field %q", name)
+		}
+		fields |= source
+	}
+	return fields, nil
+}
+
+// Selected reports whether any of the given API fields is requested;
+// requesting none selects all.
+func Selected(requested []string, names ...string) bool {
+	if len(requested) == 0 {
+		return true
+	}
+	for _, r := range requested {
+		for _, n := range names {
+			if r == n {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package productview
+
+import (
+	"fmt"
+	"time"
+
+	"product-catalog-service/internal/app/product/domain"
+	"product-catalog-service/internal/app/product/domain/services"
+)
+
+// Price calculates the price of a product at the given time. Only the
+// discounts valid then are applied. It fails when a rounded price does not
+// fit in int64 minor units.
+func Price(pricing services.PricingCalculator, p *domain.Product, at time.Time) (PriceDTO, error) {
+	breakdown := pricing.Breakdown(p, at)
+	if breakdown == nil {
+		return PriceDTO{}, fmt.Errorf("failed to calculate effective price of product %s", p.ID())
+	}
+	effective := breakdown.EffectivePrice
+	// num/den stay zero when the exact price does not fit in int64
+	num, den, _ := effective.Fraction()
+	decimal, minor := pricing.RoundedPrice(effective)
+	baseDecimal, baseMinor := pricing.RoundedPrice(breakdown.BasePrice)
+	discountDecimal, discountMinor := pricing.RoundedPrice(breakdown.DiscountAmount)
+	if !minor.IsInt64() || !baseMinor.IsInt64() || !discountMinor.IsInt64() {
+		return PriceDTO{}, fmt.Errorf("effective price of product %s out of range", p.ID())
+	}
+
+	return PriceDTO{
+		Currency:                  string(effective.Currency()),
+		EffectivePriceNumerator:   num,
+		EffectivePriceDenominator: den,
+		EffectivePriceExact:       effective.String(),
+		EffectivePriceDecimal:     decimal,
+		EffectivePriceMinorUnits:  minor.Int64(),
+		BasePriceExact:            breakdown.BasePrice.String(),
+		BasePriceDecimal:          baseDecimal,
+		BasePriceMinorUnits:       baseMinor.Int64(),
+		AppliedDiscounts:          toAppliedDiscountDTOs(breakdown.Discounts),
+		DiscountAmountExact:       breakdown.DiscountAmount.String(),
+		DiscountAmountDecimal:     discountDecimal,
+		DiscountAmountMinorUnits:  discountMinor.Int64(),
+	}, nil
+}
+
+// toAppliedDiscountDTOs converts the discounts of a price breakdown.
+func toAppliedDiscountDTOs(discounts []*domain.Discount) []AppliedDiscountDTO {
+	out := make([]AppliedDiscountDTO, 0, len(discounts))
+	for _, d := range discounts {
+		dto := AppliedDiscountDTO{
+			ID:   d.ID(),
+			Kind: string(d.Kind()),
+		}
+		if d.Kind() == domain.DiscountKindFixedAmount || d.Kind() == domain.DiscountKindPriceOverride {
+			dto.Amount = d.Amount().String()
+		} else {
+			dto.Percentage = domain.ExactString(d.Percentage())
+		}
+		out = append(out, dto)
+	}
+	return out
+}
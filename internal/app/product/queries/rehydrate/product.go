@@ -0,0 +1,104 @@
+// Package rehydrate rebuilds domain aggregates from read-model records, so
+// that queries can reuse domain services such as the pricing calculator.
+package rehydrate
+
+import (
+	"fmt"
+
+	"product-catalog-service/internal/app/product/contracts"
+	"product-catalog-service/internal/app/product/domain"
+)
+
+// Product rebuilds a product from a read-model record.
+// Stored discounts, scheduled prices, attributes and variants that are
+// invalid are skipped.
+func Product(record *contracts.ProductRecord) (*domain.Product, error) {
+	basePrice := domain.NewMoneyFromRat(record.BasePrice)
+	if basePrice == nil {
+		return nil, fmt.Errorf("product %s has no base price", record.ProductID)
+	}
+	basePrice = basePrice.WithCurrency(domain.Currency(record.Currency))
+
+	discounts := make([]*domain.Discount, 0, len(record.Discounts))
+	for _, d := range record.Discounts {
+		discount, err := domain.RehydrateDiscount(
+			d.DiscountID,
+			domain.DiscountKind(d.Kind),
+			d.Percent,
+			domain.NewMoneyFromRat(d.Amount),
+			d.Start,
+			d.End,
+			d.Priority,
+			d.Exclusive,
+		)
+		if err != nil {
+			// if stored discount is invalid, treat as no discount
+			continue
+		}
+		discounts = append(discounts, discount)
+	}
+
+	scheduledPrices := make([]*domain.ScheduledPrice, 0, len(record.ScheduledPrices))
+	for _, s := range record.ScheduledPrices {
+		scheduled, err := domain.NewScheduledPrice(s.ScheduledPriceID, domain.NewMoneyFromRat(s.Price), s.EffectiveFrom)
+		if err != nil {
+			// if stored scheduled price is invalid, ignore it
+			continue
+		}
+		scheduledPrices = append(scheduledPrices, scheduled)
+	}
+
+	attributes := make(map[string]domain.AttributeValue, len(record.Attributes))
+	for key, a := range record.Attributes {
+		attribute, err := Attribute(a)
+		if err != nil {
+			// if stored attribute is invalid, ignore it
+			continue
+		}
+		attributes[key] = attribute
+	}
+
+	variants := make([]*domain.Variant, 0, len(record.Variants))
+	for _, v := range record.Variants {
+		var priceOverride *domain.Money
+		if v.PriceOverride != nil {
+			priceOverride = domain.NewMoneyFromRat(v.PriceOverride).WithCurrency(basePrice.Currency())
+		}
+		variant, err := domain.NewVariant(v.VariantID, v.SKU, v.Options, priceOverride, domain.VariantStatus(v.Status))
+		if err != nil {
+			// if stored variant is invalid, ignore it
+			continue
+		}
+		variants = append(variants, variant)
+	}
+
+	return domain.RehydrateProduct(
+		record.ProductID,
+		record.Name,
+		record.Description,
+		record.Category,
+		basePrice,
+		discounts,
+		scheduledPrices,
+		attributes,
+		variants,
+		domain.ProductStatus(record.Status),
+		record.ArchivedAt,
+		record.CreatedAt,
+		record.UpdatedAt,
+	), nil
+}
+
+// Attribute rebuilds an attribute value from a read-model record.
+func Attribute(record contracts.AttributeRecord) (domain.AttributeValue, error) {
+	switch domain.AttributeType(record.Type) {
+	case domain.AttributeNumber:
+		return domain.NewNumberAttribute(record.Number)
+	case domain.AttributeMeasurement:
+		return domain.NewMeasurementAttribute(record.Number, record.Unit)
+	case domain.AttributeBool:
+		return domain.NewBoolAttribute(record.Bool), nil
+	default:
+		return domain.ParseAttribute(domain.AttributeType(record.Type), record.Text, "")
+	}
+}
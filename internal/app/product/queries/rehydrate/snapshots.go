@@ -0,0 +1,34 @@
+package rehydrate
+
+import (
+	"time"
+
+	"product-catalog-service/internal/app/product/contracts"
+	"product-catalog-service/internal/app/product/domain/services"
+)
+
+// Snapshots converts price history records to pricing snapshots.
+// Products priced before the history existed have no records; for them the
+// current record is used as the pricing state since the beginning of time.
+func Snapshots(history []*contracts.PriceHistoryRecord, current *contracts.ProductRecord) ([]services.PriceSnapshot, error) {
+	if len(history) == 0 {
+		if current == nil {
+			return nil, nil
+		}
+		product, err := Product(current)
+		if err != nil {
+			return nil, err
+		}
+		return []services.PriceSnapshot{{RecordedAt: time.Time{}, Product: product}}, nil
+	}
+
+	snapshots := make([]services.PriceSnapshot, 0, len(history))
+	for _, h := range history {
+		product, err := Product(&h.Pricing)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, services.PriceSnapshot{RecordedAt: h.RecordedAt, Product: product})
+	}
+	return snapshots, nil
+}
@@ -0,0 +1,38 @@
+package searchproducts
+
+import (
+	"time"
+
+	"product-catalog-service/internal/app/product/queries/facets"
+	"product-catalog-service/internal/app/product/queries/productview"
+)
+
+// ProductItemDTO is a product found by a search.
+type ProductItemDTO struct {
+	ID          string
+	Name        string
+	Description string
+	Category    string
+
+	// AsOf is the instant the prices were calculated for.
+	AsOf time.Time
+	// PriceDTO is the price at AsOf.
+	productview.PriceDTO
+}
+
+// SearchResultItemDTO is a search hit with its relevance and its name and
+// description as escaped HTML with the matched words wrapped in <em> tags.
+type SearchResultItemDTO struct {
+	Product              ProductItemDTO
+	Score                float64
+	NameHighlight        string
+	DescriptionHighlight string
+}
+
+// SearchResultDTO is the result of the SearchProducts query.
+type SearchResultDTO struct {
+	Results       []SearchResultItemDTO
+	NextPageToken string
+	// Facets is nil unless facets were requested.
+	Facets *facets.ResultDTO
+}
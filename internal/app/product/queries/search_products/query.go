@@ -0,0 +1,243 @@
+package searchproducts
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"product-catalog-service/internal/app/product/contracts"
+	"product-catalog-service/internal/app/product/domain"
+	"product-catalog-service/internal/app/product/domain/services"
+	"product-catalog-service/internal/app/product/queries/facets"
+	"product-catalog-service/internal/app/product/queries/productview"
+	"product-catalog-service/internal/app/product/queries/rehydrate"
+	"product-catalog-service/internal/pkg/pagetoken"
+	"product-catalog-service/internal/pkg/search"
+)
+
+// Page sizes of a search.
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// MaxQueryLength bounds the length of a search query in bytes.
+const MaxQueryLength = 256
+
+// MaxFacetHits bounds the hits facets are counted over; facets of broader
+// searches only cover the most relevant hits.
+const MaxFacetHits = 10000
+
+// relevanceSort is the sort page tokens of a search are bound to.
+const relevanceSort = "relevance"
+
+// Highlight markers wrapped around matched words.
+const (
+	highlightPre  = "<em>"
+	highlightPost = "</em>"
+)
+
+// ErrInvalidQuery is returned when Request.Query has no searchable words or
+// is too long.
+var ErrInvalidQuery = errors.New("invalid search query")
+
+// Request represents input parameters for the SearchProducts query.
+type Request struct {
+	// Query is free text; products matching any of its words are returned,
+	// most relevant first.
+	Query     string
+	PageSize  int
+	PageToken string
+	// As-of time for price calculation; if zero, current time is used.
+	Now time.Time
+	// Facets selects the facet counts returned over all hits, not just the
+	// page.
+	Facets facets.Request
+}
+
+// Query implements "Search active products by keyword".
+type Query struct {
+	readModel contracts.ReadModel
+	searcher  contracts.ProductSearcher
+	pricing   services.PricingCalculator
+	tokens    *pagetoken.Codec
+}
+
+func New(readModel contracts.ReadModel, searcher contracts.ProductSearcher, pricing services.PricingCalculator, tokens *pagetoken.Codec) *Query {
+	return &Query{
+		readModel: readModel,
+		searcher:  searcher,
+		pricing:   pricing,
+		tokens:    tokens,
+	}
+}
+
+// Execute runs the search. Hits are read back from the read model, so
+// products changed since they were indexed are shown as they are now and
+// products no longer active are skipped.
+func (q *Query) Execute(ctx context.Context, req Request) (*SearchResultDTO, error) {
+	if len(req.Query) > MaxQueryLength {
+		return nil, fmt.Errorf("%w: longer than %d bytes", ErrInvalidQuery, MaxQueryLength)
+	}
+	terms := search.Terms(req.Query)
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("%w: %q has no searchable words", ErrInvalidQuery, req.Query)
+	}
+
+	pageSize := req.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+
+	now := req.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	productFacets, err := facets.Parse(req.Facets)
+	if err != nil {
+		return nil, err
+	}
+
+	// Page tokens carry the offset of the next page and are only valid for
+	// the query they were issued for
+	hash := queryHash(terms)
+	offset := 0
+	if req.PageToken != "" {
+		token, err := q.tokens.Check(req.PageToken, time.Now(), hash, relevanceSort)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", contracts.ErrInvalidPageToken, err)
+		}
+		offset, err = strconv.Atoi(token.Cursor)
+		if err != nil || offset < 0 {
+			return nil, fmt.Errorf("%w: bad offset %q", contracts.ErrInvalidPageToken, token.Cursor)
+		}
+		if req.Now.IsZero() && !token.AsOf.IsZero() {
+			now = token.AsOf
+		}
+	}
+
+	hits, more, err := q.searcher.SearchProducts(ctx, req.Query, offset, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	nextToken := ""
+	if more {
+		nextToken, err = q.tokens.Encode(pagetoken.Token{
+			Cursor:     strconv.Itoa(offset + len(hits)),
+			FilterHash: hash,
+			Sort:       relevanceSort,
+			AsOf:       now,
+			IssuedAt:   time.Now(),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ids := make([]string, 0, len(hits))
+	for _, h := range hits {
+		ids = append(ids, h.ProductID)
+	}
+	// search results carry no variants
+	records, err := q.readModel.GetProductsByIDs(ctx, ids, contracts.AllProductFields&^contracts.ProductFieldVariants)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]SearchResultItemDTO, 0, len(hits))
+	for i, h := range hits {
+		r := records[i]
+		if r == nil || r.Status != string(domain.ProductStatusActive) || r.ArchivedAt != nil {
+			// Skip hits the index has not caught up with yet
+			continue
+		}
+
+		item, err := q.toItemDTO(r, now)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, SearchResultItemDTO{
+			Product:              item,
+			Score:                h.Score,
+			NameHighlight:        search.Highlight(r.Name, terms, highlightPre, highlightPost),
+			DescriptionHighlight: search.Highlight(r.Description, terms, highlightPre, highlightPost),
+		})
+	}
+
+	result := &SearchResultDTO{
+		Results:       results,
+		NextPageToken: nextToken,
+	}
+	if !req.Facets.Empty() {
+		if result.Facets, err = q.countFacets(ctx, req.Query, productFacets, now); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// countFacets counts the facets of the products matching query.
+func (q *Query) countFacets(ctx context.Context, query string, productFacets contracts.ProductFacets, now time.Time) (*facets.ResultDTO, error) {
+	hits, _, err := q.searcher.SearchProducts(ctx, query, 0, MaxFacetHits)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(hits))
+	for _, h := range hits {
+		ids = append(ids, h.ProductID)
+	}
+
+	// Hits the index has not caught up with are not counted
+	productFilter := contracts.ProductFilter{
+		Statuses:   []string{string(domain.ProductStatusActive)},
+		ProductIDs: ids,
+		AsOf:       now,
+	}
+	counts, err := q.readModel.CountProductFacets(ctx, productFilter, productFacets, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	return facets.ToDTO(counts), nil
+}
+
+// toItemDTO converts a record with its prices at now.
+func (q *Query) toItemDTO(r *contracts.ProductRecord, now time.Time) (ProductItemDTO, error) {
+	product, err := rehydrate.Product(r)
+	if err != nil {
+		return ProductItemDTO{}, err
+	}
+
+	price, err := productview.Price(q.pricing, product, now)
+	if err != nil {
+		return ProductItemDTO{}, err
+	}
+
+	return ProductItemDTO{
+		ID:          r.ProductID,
+		Name:        r.Name,
+		Description: r.Description,
+		Category:    r.Category,
+		AsOf:        now,
+		PriceDTO:    price,
+	}, nil
+}
+
+// queryHash identifies a search by its distinct terms in any order, so
+// that pages can be fetched with any spelling of the same query.
+func queryHash(terms []string) string {
+	sorted := append([]string(nil), terms...)
+	sort.Strings(sorted)
+	sum := sha256.Sum256([]byte(strings.Join(sorted, " ")))
+	return hex.EncodeToString(sum[:])
+}
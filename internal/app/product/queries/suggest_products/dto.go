@@ -0,0 +1,16 @@
+package suggestproducts
+
+// SuggestionDTO is a completion of the typed prefix.
+type SuggestionDTO struct {
+	Text string
+	// Kind is "name" or "category".
+	Kind         string
+	ProductCount int
+	// Corrected is true when the prefix only matched after correcting typos.
+	Corrected bool
+}
+
+// ResultDTO is the result of the SuggestProducts query, best first.
+type ResultDTO struct {
+	Suggestions []SuggestionDTO
+}
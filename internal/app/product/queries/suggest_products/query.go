@@ -0,0 +1,76 @@
+package suggestproducts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"product-catalog-service/internal/app/product/contracts"
+)
+
+// Limits of a suggestion request.
+const (
+	DefaultLimit    = 10
+	MaxLimit        = 20
+	MaxPrefixLength = 100
+)
+
+// ErrInvalidPrefix is returned when Request.Prefix is blank or too long.
+var ErrInvalidPrefix = errors.New("invalid prefix")
+
+// Request represents input parameters for the SuggestProducts query.
+type Request struct {
+	// Prefix is what was typed so far, e.g. "runn"; a trailing space asks
+	// for completions of the next word.
+	Prefix string
+	Limit  int
+}
+
+// Query implements "Suggest product names and categories for a prefix".
+type Query struct {
+	suggester contracts.ProductSuggester
+}
+
+func New(suggester contracts.ProductSuggester) *Query {
+	return &Query{
+		suggester: suggester,
+	}
+}
+
+// Execute runs the query. Suggestions come from an in-memory index and do
+// not read the database.
+func (q *Query) Execute(ctx context.Context, req Request) (*ResultDTO, error) {
+	if strings.TrimSpace(req.Prefix) == "" {
+		return nil, fmt.Errorf("%w: prefix is blank", ErrInvalidPrefix)
+	}
+	if len(req.Prefix) > MaxPrefixLength {
+		return nil, fmt.Errorf("%w: longer than %d bytes", ErrInvalidPrefix, MaxPrefixLength)
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	suggestions, err := q.suggester.SuggestProducts(ctx, req.Prefix, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ResultDTO{
+		Suggestions: make([]SuggestionDTO, 0, len(suggestions)),
+	}
+	for _, s := range suggestions {
+		result.Suggestions = append(result.Suggestions, SuggestionDTO{
+			Text:         s.Text,
+			Kind:         string(s.Kind),
+			ProductCount: s.ProductCount,
+			Corrected:    s.Typos > 0,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,20 @@
+package variants
+
+// VariantDTO is a product variant priced at the query's as-of time.
+type VariantDTO struct {
+	ID      string
+	SKU     string
+	Options map[string]string
+	// Status is "active" or "inactive".
+	Status string
+	// PriceOverride is the exact override price, e.g. "24.99"; empty when
+	// the variant sells at the product price.
+	PriceOverride string
+	// EffectivePriceExact is the exact variant price after discounts.
+	EffectivePriceExact string
+	// EffectivePriceDecimal is the rounded price, e.g. "19.99".
+	EffectivePriceDecimal string
+	// EffectivePriceMinorUnits is the rounded price in minor units, e.g.
+	// 1999; zero when it does not fit in int64.
+	EffectivePriceMinorUnits int64
+}
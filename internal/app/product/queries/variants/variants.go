@@ -0,0 +1,38 @@
+// Package variants converts product variants for the product queries.
+package variants
+
+import (
+	"time"
+
+	"product-catalog-service/internal/app/product/domain"
+	"product-catalog-service/internal/app/product/domain/services"
+)
+
+// ToDTOs converts the variants of a product, pricing each at the given
+// time; a product without variants yields nil.
+func ToDTOs(p *domain.Product, pricing services.PricingCalculator, at time.Time) []VariantDTO {
+	variants := p.Variants()
+	if len(variants) == 0 {
+		return nil
+	}
+	out := make([]VariantDTO, 0, len(variants))
+	for _, v := range variants {
+		dto := VariantDTO{
+			ID:            v.ID(),
+			SKU:           v.SKU(),
+			Options:       v.Options(),
+			Status:        string(v.Status()),
+			PriceOverride: v.PriceOverride().String(),
+		}
+		if price := pricing.VariantPrice(p, v.ID(), at); price != nil {
+			decimal, minor := pricing.RoundedPrice(price)
+			dto.EffectivePriceExact = price.String()
+			dto.EffectivePriceDecimal = decimal
+			if minor.IsInt64() {
+				dto.EffectivePriceMinorUnits = minor.Int64()
+			}
+		}
+		out = append(out, dto)
+	}
+	return out
+}
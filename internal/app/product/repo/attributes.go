@@ -0,0 +1,111 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
+	"product-catalog-service/internal/app/product/contracts"
+	"product-catalog-service/internal/app/product/domain"
+	mproductattribute "product-catalog-service/internal/models/m_product_attribute"
+)
+
+// readAttributes loads the attributes of the given products grouped by
+// product ID.
+func readAttributes(
+	ctx context.Context,
+	reader rowReader,
+	productIDs []string,
+) (map[string][]*mproductattribute.ProductAttribute, error) {
+	out := make(map[string][]*mproductattribute.ProductAttribute, len(productIDs))
+	if len(productIDs) == 0 {
+		return out, nil
+	}
+
+	keys := make([]spanner.KeySet, 0, len(productIDs))
+	for _, id := range productIDs {
+		keys = append(keys, spanner.Key{id}.AsPrefix())
+	}
+
+	iter := reader.Read(ctx, mproductattribute.TableName, spanner.KeySets(keys...), mproductattribute.Columns())
+	defer iter.Stop()
+
+	for {
+		row, err := iter.Next()
+		if err != nil {
+			if err == iterator.Done {
+				break
+			}
+			return nil, err
+		}
+
+		var model mproductattribute.ProductAttribute
+		if err := row.ToStruct(&model); err != nil {
+			return nil, fmt.Errorf("failed to parse attribute row: %w", err)
+		}
+		out[model.ProductID] = append(out[model.ProductID], &model)
+	}
+
+	return out, nil
+}
+
+// attributeToModel converts a domain attribute value to its storage row.
+func attributeToModel(productID, key string, v domain.AttributeValue) *mproductattribute.ProductAttribute {
+	model := &mproductattribute.ProductAttribute{
+		ProductID:    productID,
+		AttributeKey: key,
+		Type:         string(v.Type()),
+	}
+	switch v.Type() {
+	case domain.AttributeString, domain.AttributeEnum:
+		model.StringValue = spanner.NullString{StringVal: v.Text(), Valid: true}
+	case domain.AttributeNumber:
+		model.NumberValue = spanner.NullNumeric{Numeric: *v.Number(), Valid: true}
+	case domain.AttributeMeasurement:
+		model.NumberValue = spanner.NullNumeric{Numeric: *v.Number(), Valid: true}
+		model.Unit = spanner.NullString{StringVal: v.Unit(), Valid: true}
+	case domain.AttributeBool:
+		model.BoolValue = spanner.NullBool{Bool: v.Bool(), Valid: true}
+	}
+	return model
+}
+
+// attributeFromModel converts a storage row to a domain attribute value.
+func attributeFromModel(model *mproductattribute.ProductAttribute) (domain.AttributeValue, error) {
+	switch domain.AttributeType(model.Type) {
+	case domain.AttributeString:
+		return domain.NewStringAttribute(model.StringValue.StringVal)
+	case domain.AttributeEnum:
+		return domain.NewEnumAttribute(model.StringValue.StringVal)
+	case domain.AttributeNumber:
+		if !model.NumberValue.Valid {
+			return domain.AttributeValue{}, fmt.Errorf("number attribute has no value")
+		}
+		return domain.NewNumberAttribute(&model.NumberValue.Numeric)
+	case domain.AttributeMeasurement:
+		if !model.NumberValue.Valid {
+			return domain.AttributeValue{}, fmt.Errorf("measurement attribute has no value")
+		}
+		return domain.NewMeasurementAttribute(&model.NumberValue.Numeric, model.Unit.StringVal)
+	case domain.AttributeBool:
+		return domain.NewBoolAttribute(model.BoolValue.Bool), nil
+	default:
+		return domain.AttributeValue{}, fmt.Errorf("unknown attribute type %q", model.Type)
+	}
+}
+
+// attributeToRecord converts a storage row to its read-model form.
+func attributeToRecord(model *mproductattribute.ProductAttribute) contracts.AttributeRecord {
+	record := contracts.AttributeRecord{
+		Type: model.Type,
+		Text: model.StringValue.StringVal,
+		Bool: model.BoolValue.Bool,
+		Unit: model.Unit.StringVal,
+	}
+	if model.NumberValue.Valid {
+		record.Number = new(big.Rat).Set(&model.NumberValue.Numeric)
+	}
+	return record
+}
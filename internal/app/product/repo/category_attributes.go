@@ -0,0 +1,71 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
+	"product-catalog-service/internal/app/product/contracts"
+	"product-catalog-service/internal/app/product/domain"
+	mcategoryattribute "product-catalog-service/internal/models/m_category_attribute"
+)
+
+// readCategoryAttributes loads the attribute definitions in keys grouped by
+// category ID, in key order.
+func readCategoryAttributes(
+	ctx context.Context,
+	reader rowReader,
+	keys spanner.KeySet,
+) (map[string][]*mcategoryattribute.CategoryAttribute, error) {
+	out := make(map[string][]*mcategoryattribute.CategoryAttribute)
+
+	iter := reader.Read(ctx, mcategoryattribute.TableName, keys, mcategoryattribute.Columns())
+	defer iter.Stop()
+
+	for {
+		row, err := iter.Next()
+		if err != nil {
+			if err == iterator.Done {
+				break
+			}
+			return nil, err
+		}
+
+		var model mcategoryattribute.CategoryAttribute
+		if err := row.ToStruct(&model); err != nil {
+			return nil, fmt.Errorf("failed to parse category attribute row: %w", err)
+		}
+		out[model.CategoryID] = append(out[model.CategoryID], &model)
+	}
+
+	return out, nil
+}
+
+// definitionToModel converts a domain attribute definition to its storage
+// row.
+func definitionToModel(categoryID string, d domain.AttributeDefinition) *mcategoryattribute.CategoryAttribute {
+	return &mcategoryattribute.CategoryAttribute{
+		CategoryID:    categoryID,
+		AttributeKey:  d.Key(),
+		Type:          string(d.Type()),
+		Required:      d.Required(),
+		AllowedValues: d.AllowedValues(),
+	}
+}
+
+// definitionFromModel converts a storage row to a domain attribute
+// definition.
+func definitionFromModel(model *mcategoryattribute.CategoryAttribute) (domain.AttributeDefinition, error) {
+	return domain.NewAttributeDefinition(model.AttributeKey, domain.AttributeType(model.Type), model.Required, model.AllowedValues)
+}
+
+// definitionToRecord converts a storage row to its read-model form.
+func definitionToRecord(model *mcategoryattribute.CategoryAttribute) contracts.AttributeDefinitionRecord {
+	return contracts.AttributeDefinitionRecord{
+		Key:           model.AttributeKey,
+		Type:          model.Type,
+		Required:      model.Required,
+		AllowedValues: model.AllowedValues,
+	}
+}
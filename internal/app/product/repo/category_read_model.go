@@ -0,0 +1,57 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
+	"product-catalog-service/internal/app/product/contracts"
+	mcategory "product-catalog-service/internal/models/m_category"
+)
+
+// ListCategories returns every category with its attribute schema, reading
+// both tables whole from one snapshot.
+func (r *ReadModel) ListCategories(ctx context.Context) ([]*contracts.CategoryRecord, error) {
+	txn := r.client.ReadOnlyTransaction()
+	defer txn.Close()
+
+	attrs, err := readCategoryAttributes(ctx, txn, spanner.AllKeys())
+	if err != nil {
+		return nil, err
+	}
+
+	iter := txn.Read(ctx, mcategory.TableName, spanner.AllKeys(), mcategory.Columns)
+	defer iter.Stop()
+
+	var records []*contracts.CategoryRecord
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var model mcategory.Category
+		if err := row.ToStruct(&model); err != nil {
+			return nil, fmt.Errorf("failed to parse category row: %w", err)
+		}
+		record := &contracts.CategoryRecord{
+			CategoryID: model.CategoryID,
+			Name:       model.Name,
+			Slug:       model.Slug,
+			ParentID:   model.ParentID.StringVal,
+			SortOrder:  model.SortOrder,
+			CreatedAt:  model.CreatedAt,
+			UpdatedAt:  model.UpdatedAt,
+		}
+		for _, m := range attrs[model.CategoryID] {
+			record.AttributeSchema = append(record.AttributeSchema, definitionToRecord(m))
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
@@ -0,0 +1,280 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
+	"product-catalog-service/internal/app/product/contracts"
+	"product-catalog-service/internal/app/product/domain"
+	mcategory "product-catalog-service/internal/models/m_category"
+	mcategoryattribute "product-catalog-service/internal/models/m_category_attribute"
+	"product-catalog-service/internal/models/mproduct"
+	"product-catalog-service/internal/pkg/committer"
+)
+
+// CategoryRepo implements contracts.CategoryRepo using Spanner.
+type CategoryRepo struct {
+	client *spanner.Client
+}
+
+var _ contracts.CategoryRepo = (*CategoryRepo)(nil)
+
+// NewCategoryRepo creates a new CategoryRepo with the given Spanner client.
+func NewCategoryRepo(client *spanner.Client) *CategoryRepo {
+	return &CategoryRepo{client: client}
+}
+
+// InsertMut returns a mutation to insert a new category.
+// Returns nil if category is nil.
+func (r *CategoryRepo) InsertMut(c *domain.Category) *spanner.Mutation {
+	if c == nil {
+		return nil
+	}
+
+	return mcategory.InsertMut(&mcategory.Category{
+		CategoryID: c.ID(),
+		Name:       c.Name(),
+		Slug:       c.Slug(),
+		ParentID:   nullString(c.ParentID()),
+		SortOrder:  c.SortOrder(),
+		CreatedAt:  c.CreatedAt(),
+		UpdatedAt:  c.UpdatedAt(),
+	})
+}
+
+// UpdateMut returns a mutation to update changed fields of a category.
+// Returns nil if no changes are dirty.
+func (r *CategoryRepo) UpdateMut(c *domain.Category) *spanner.Mutation {
+	if c == nil {
+		return nil
+	}
+
+	updates := make(map[string]interface{})
+
+	if c.Changes().Dirty(domain.FieldName) {
+		updates[mcategory.Name] = c.Name()
+	}
+	if c.Changes().Dirty(domain.FieldSortOrder) {
+		updates[mcategory.SortOrder] = c.SortOrder()
+	}
+	if c.Changes().Dirty(domain.FieldParentID) {
+		updates[mcategory.ParentID] = nullString(c.ParentID())
+	}
+
+	if c.Changes().Dirty(domain.FieldAttributeSchema) {
+		// Definitions are written by AttributeSchemaMuts; only the row
+		// timestamp changes here.
+		updates[mcategory.UpdatedAt] = c.UpdatedAt()
+	}
+
+	if len(updates) == 0 {
+		return nil
+	}
+
+	updates[mcategory.UpdatedAt] = c.UpdatedAt()
+	return mcategory.UpdateMut(c.ID(), updates)
+}
+
+// AttributeSchemaMuts returns mutations that write the changed attribute
+// definitions of a category and delete the removed ones. Definitions live
+// in the interleaved category_attributes table and must follow the
+// category insert in the same plan.
+// Returns nil if no definition is dirty.
+func (r *CategoryRepo) AttributeSchemaMuts(c *domain.Category) []*spanner.Mutation {
+	if c == nil {
+		return nil
+	}
+
+	var muts []*spanner.Mutation
+	prefix := domain.AttributeSchemaField("")
+	for _, field := range c.Changes().DirtyWithPrefix(prefix) {
+		key := field[len(prefix):]
+		if d, ok := c.AttributeDefinition(key); ok {
+			muts = append(muts, mcategoryattribute.InsertOrUpdateMut(definitionToModel(c.ID(), d)))
+		} else {
+			muts = append(muts, mcategoryattribute.DeleteMut(c.ID(), key))
+		}
+	}
+	return muts
+}
+
+// DeleteMut returns a mutation to delete a category.
+// Returns nil if category is nil.
+func (r *CategoryRepo) DeleteMut(c *domain.Category) *spanner.Mutation {
+	if c == nil {
+		return nil
+	}
+	return mcategory.DeleteMut(c.ID())
+}
+
+// FindByID loads a category aggregate by ID with its attribute schema.
+func (r *CategoryRepo) FindByID(ctx context.Context, id string) (*domain.Category, error) {
+	txn := r.client.ReadOnlyTransaction()
+	defer txn.Close()
+
+	return findCategoryByID(ctx, txn, id)
+}
+
+// FindBySlug loads a category aggregate by slug using the unique slug index.
+func (r *CategoryRepo) FindBySlug(ctx context.Context, slug string) (*domain.Category, error) {
+	txn := r.client.ReadOnlyTransaction()
+	defer txn.Close()
+
+	return findCategoryBySlug(ctx, txn, slug)
+}
+
+// SlugCheck returns a check that loads the category with the given slug
+// in the committing transaction and passes it to check.
+func (r *CategoryRepo) SlugCheck(slug string, check func(c *domain.Category) error) committer.Check {
+	return func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		category, err := findCategoryBySlug(ctx, txn, slug)
+		if err != nil {
+			return err
+		}
+		return check(category)
+	}
+}
+
+// AncestorsCheck returns a check that walks up from parentID in the
+// committing transaction and passes the IDs above it to check.
+func (r *CategoryRepo) AncestorsCheck(parentID string, check func(ancestors []string) error) committer.Check {
+	return func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		var ancestors []string
+		seen := make(map[string]bool)
+		for id := parentID; id != "" && !seen[id]; {
+			seen[id] = true
+			parent, err := findCategoryByID(ctx, txn, id)
+			if err != nil {
+				return err
+			}
+			if id != parentID {
+				ancestors = append(ancestors, id)
+			}
+			id = parent.ParentID()
+		}
+		return check(ancestors)
+	}
+}
+
+// UnusedCheck returns a check that fails with domain.ErrCategoryInUse when
+// a category has c as parent or a product references its slug.
+func (r *CategoryRepo) UnusedCheck(c *domain.Category) committer.Check {
+	return func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		hasChildren, err := exists(ctx, txn, spanner.Statement{
+			SQL:    `SELECT 1 FROM categories WHERE parent_id = @parent_id LIMIT 1`,
+			Params: map[string]interface{}{"parent_id": c.ID()},
+		})
+		if err != nil {
+			return err
+		}
+		if hasChildren {
+			return fmt.Errorf("%w: %q has subcategories", domain.ErrCategoryInUse, c.Slug())
+		}
+
+		hasProducts, err := exists(ctx, txn, spanner.Statement{
+			SQL:    `SELECT 1 FROM ` + mproduct.TableName + ` WHERE category = @category LIMIT 1`,
+			Params: map[string]interface{}{"category": c.Slug()},
+		})
+		if err != nil {
+			return err
+		}
+		if hasProducts {
+			return fmt.Errorf("%w: %q has products", domain.ErrCategoryInUse, c.Slug())
+		}
+		return nil
+	}
+}
+
+// categoryReader is implemented by read-only and read-write transactions.
+type categoryReader interface {
+	rowReader
+	ReadRow(ctx context.Context, table string, key spanner.Key, columns []string) (*spanner.Row, error)
+	ReadRowUsingIndex(ctx context.Context, table, index string, key spanner.Key, columns []string) (*spanner.Row, error)
+	Query(ctx context.Context, statement spanner.Statement) *spanner.RowIterator
+}
+
+// findCategoryByID reads a category row and its attribute definitions.
+func findCategoryByID(ctx context.Context, reader categoryReader, id string) (*domain.Category, error) {
+	row, err := reader.ReadRow(ctx, mcategory.TableName, spanner.Key{id}, mcategory.Columns)
+	if err != nil {
+		if spanner.ErrCode(err) == spanner.ErrCode(spanner.ErrNotFound) {
+			return nil, domain.ErrCategoryNotFound
+		}
+		return nil, err
+	}
+
+	attrs, err := readCategoryAttributes(ctx, reader, spanner.Key{id}.AsPrefix())
+	if err != nil {
+		return nil, err
+	}
+	return categoryToDomain(row, attrs[id])
+}
+
+// findCategoryBySlug looks a category up in the unique slug index.
+func findCategoryBySlug(ctx context.Context, reader categoryReader, slug string) (*domain.Category, error) {
+	row, err := reader.ReadRowUsingIndex(ctx, mcategory.TableName, mcategory.SlugIndex,
+		spanner.Key{slug}, []string{mcategory.CategoryID})
+	if err != nil {
+		if spanner.ErrCode(err) == spanner.ErrCode(spanner.ErrNotFound) {
+			return nil, domain.ErrCategoryNotFound
+		}
+		return nil, err
+	}
+
+	var id string
+	if err := row.Column(0, &id); err != nil {
+		return nil, fmt.Errorf("failed to parse category id: %w", err)
+	}
+	return findCategoryByID(ctx, reader, id)
+}
+
+// exists reports whether stmt returns any row.
+func exists(ctx context.Context, reader categoryReader, stmt spanner.Statement) (bool, error) {
+	iter := reader.Query(ctx, stmt)
+	defer iter.Stop()
+
+	_, err := iter.Next()
+	if err == iterator.Done {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// categoryToDomain converts a categories row and its attribute definition
+// rows to a domain aggregate.
+func categoryToDomain(row *spanner.Row, attrModels []*mcategoryattribute.CategoryAttribute) (*domain.Category, error) {
+	var model mcategory.Category
+	if err := row.ToStruct(&model); err != nil {
+		return nil, fmt.Errorf("failed to parse category row: %w", err)
+	}
+
+	schema := make([]domain.AttributeDefinition, 0, len(attrModels))
+	for _, m := range attrModels {
+		d, err := definitionFromModel(m)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse category attribute %q: %w", m.AttributeKey, err)
+		}
+		schema = append(schema, d)
+	}
+
+	return domain.RehydrateCategory(
+		model.CategoryID,
+		model.Name,
+		model.Slug,
+		model.ParentID.StringVal,
+		model.SortOrder,
+		schema,
+		model.CreatedAt,
+		model.UpdatedAt,
+	), nil
+}
+
+// nullString maps an empty string to NULL.
+func nullString(s string) spanner.NullString {
+	return spanner.NullString{StringVal: s, Valid: s != ""}
+}
@@ -0,0 +1,127 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
+	"product-catalog-service/internal/app/product/domain"
+	mproductdiscount "product-catalog-service/internal/models/m_product_discount"
+	"product-catalog-service/internal/models/mproduct"
+)
+
+// rowReader is implemented by both single-use and read-only transactions.
+type rowReader interface {
+	Read(ctx context.Context, table string, keys spanner.KeySet, columns []string) *spanner.RowIterator
+}
+
+// readDiscounts loads the discounts of the given products grouped by product ID.
+// The reader lets callers share a transaction with the parent row reads.
+func readDiscounts(
+	ctx context.Context,
+	reader rowReader,
+	productIDs []string,
+) (map[string][]*mproductdiscount.ProductDiscount, error) {
+	out := make(map[string][]*mproductdiscount.ProductDiscount, len(productIDs))
+	if len(productIDs) == 0 {
+		return out, nil
+	}
+
+	keys := make([]spanner.KeySet, 0, len(productIDs))
+	for _, id := range productIDs {
+		keys = append(keys, spanner.Key{id}.AsPrefix())
+	}
+
+	iter := reader.Read(ctx, mproductdiscount.TableName, spanner.KeySets(keys...), mproductdiscount.Columns())
+	defer iter.Stop()
+
+	for {
+		row, err := iter.Next()
+		if err != nil {
+			if err == iterator.Done {
+				break
+			}
+			return nil, err
+		}
+
+		var model mproductdiscount.ProductDiscount
+		if err := row.ToStruct(&model); err != nil {
+			return nil, fmt.Errorf("failed to parse product discount row: %w", err)
+		}
+		out[model.ProductID] = append(out[model.ProductID], &model)
+	}
+
+	return out, nil
+}
+
+// discountToModel converts a domain discount to its storage row.
+func discountToModel(productID string, d *domain.Discount) *mproductdiscount.ProductDiscount {
+	model := &mproductdiscount.ProductDiscount{
+		ProductID:  productID,
+		DiscountID: d.ID(),
+		Kind:       string(d.Kind()),
+		StartDate:  d.StartAt(),
+		EndDate:    d.EndAt(),
+		Priority:   int64(d.Priority()),
+		Exclusive:  d.Exclusive(),
+	}
+
+	if percent := d.Percentage(); percent != nil {
+		model.Percentage = spanner.NullNumeric{Numeric: *percent, Valid: true}
+	}
+	if amount := d.Amount(); amount != nil {
+		model.Amount = spanner.NullNumeric{Numeric: *amount.Rat(), Valid: true}
+	}
+
+	return model
+}
+
+// discountFromModel converts a storage row to a domain discount.
+func discountFromModel(model *mproductdiscount.ProductDiscount) (*domain.Discount, error) {
+	var percent *big.Rat
+	if model.Percentage.Valid {
+		percent = new(big.Rat).Set(&model.Percentage.Numeric)
+	}
+
+	amount, err := amountFromModel(model)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.RehydrateDiscount(
+		model.DiscountID,
+		domain.DiscountKind(model.Kind),
+		percent,
+		amount,
+		model.StartDate,
+		model.EndDate,
+		int(model.Priority),
+		model.Exclusive,
+	)
+}
+
+// amountFromModel reads the discount amount, falling back to the legacy
+// rational columns for rows written before the NUMERIC column existed.
+func amountFromModel(model *mproductdiscount.ProductDiscount) (*domain.Money, error) {
+	if model.Amount.Valid {
+		return domain.NewMoneyFromRat(&model.Amount.Numeric), nil
+	}
+	if model.AmountNumerator.Valid && model.AmountDenominator.Valid {
+		return domain.NewMoneyFromFraction(model.AmountNumerator.Int64, model.AmountDenominator.Int64)
+	}
+	return nil, nil
+}
+
+// basePriceFromModel reads the product base price, falling back to the
+// legacy rational columns for rows written before the NUMERIC column existed.
+func basePriceFromModel(model *mproduct.Product) (*domain.Money, error) {
+	if model.BasePrice.Valid {
+		return domain.NewMoneyFromRat(&model.BasePrice.Numeric), nil
+	}
+	if model.BasePriceNumerator.Valid && model.BasePriceDenominator.Valid {
+		return domain.NewMoneyFromFraction(model.BasePriceNumerator.Int64, model.BasePriceDenominator.Int64)
+	}
+	return nil, fmt.Errorf("base price is missing")
+}
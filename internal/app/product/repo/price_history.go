@@ -0,0 +1,203 @@
+package repo
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
+	"product-catalog-service/internal/app/product/contracts"
+	"product-catalog-service/internal/app/product/domain"
+	mpricehistory "product-catalog-service/internal/models/m_price_history"
+)
+
+// pricingFields are the product fields whose changes are recorded in the
+// price history.
+var pricingFields = []string{
+	domain.FieldBasePrice,
+	domain.FieldDiscount,
+	domain.FieldScheduledPrices,
+}
+
+// PriceHistoryMut returns a mutation appending the pricing state of a
+// product to the price history, recorded at its last update.
+// Returns nil if no pricing field is dirty.
+func (r *ProductRepo) PriceHistoryMut(p *domain.Product) *spanner.Mutation {
+	if p == nil {
+		return nil
+	}
+
+	var reasons []string
+	for _, f := range pricingFields {
+		if p.Changes().Dirty(f) {
+			reasons = append(reasons, f)
+		}
+	}
+	if len(reasons) == 0 {
+		return nil
+	}
+
+	discounts := make([]mpricehistory.Discount, 0, len(p.Discounts()))
+	for _, d := range p.Discounts() {
+		entry := mpricehistory.Discount{
+			ID:        d.ID(),
+			Kind:      string(d.Kind()),
+			StartDate: d.StartAt(),
+			EndDate:   d.EndAt(),
+			Priority:  d.Priority(),
+			Exclusive: d.Exclusive(),
+		}
+		if percent := d.Percentage(); percent != nil {
+			entry.Percentage = domain.ExactString(percent)
+		}
+		if amount := d.Amount(); amount != nil {
+			entry.Amount = amount.String()
+		}
+		discounts = append(discounts, entry)
+	}
+
+	scheduledPrices := make([]mpricehistory.ScheduledPrice, 0, len(p.ScheduledPrices()))
+	for _, s := range p.ScheduledPrices() {
+		scheduledPrices = append(scheduledPrices, mpricehistory.ScheduledPrice{
+			ID:            s.ID(),
+			Price:         s.Price().String(),
+			EffectiveFrom: s.EffectiveFrom(),
+		})
+	}
+
+	// marshalling plain structs of strings and times cannot fail
+	discountsJSON, _ := json.Marshal(discounts)
+	scheduledJSON, _ := json.Marshal(scheduledPrices)
+
+	return mpricehistory.InsertMut(&mpricehistory.PriceHistoryEntry{
+		ProductID:       p.ID(),
+		RecordedAt:      p.UpdatedAt(),
+		EntryID:         newEntryID(),
+		Reason:          strings.Join(reasons, ","),
+		BasePrice:       *p.BasePrice().Rat(),
+		Currency:        string(p.Currency()),
+		Discounts:       string(discountsJSON),
+		ScheduledPrices: string(scheduledJSON),
+	})
+}
+
+// GetPriceHistory returns the price history entries of a product in force
+// during [from, to]: the last entry recorded at or before from, followed by
+// the entries recorded up to to, in recorded order.
+func (r *ReadModel) GetPriceHistory(
+	ctx context.Context,
+	productID string,
+	from, to time.Time,
+) ([]*contracts.PriceHistoryRecord, error) {
+	stmt := spanner.Statement{
+		SQL: `SELECT product_id, recorded_at, entry_id, reason, base_price,
+		             currency, discounts, scheduled_prices
+		      FROM price_history
+		      WHERE product_id = @product_id
+		        AND recorded_at <= @to
+		        AND recorded_at >= COALESCE(
+		            (SELECT MAX(recorded_at) FROM price_history
+		             WHERE product_id = @product_id AND recorded_at <= @from),
+		            @from)
+		      ORDER BY recorded_at, entry_id`,
+		Params: map[string]interface{}{
+			"product_id": productID,
+			"from":       from,
+			"to":         to,
+		},
+	}
+
+	iter := r.client.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	var records []*contracts.PriceHistoryRecord
+	for {
+		row, err := iter.Next()
+		if err != nil {
+			if err == iterator.Done {
+				break
+			}
+			return nil, err
+		}
+
+		var model mpricehistory.PriceHistoryEntry
+		if err := row.ToStruct(&model); err != nil {
+			return nil, fmt.Errorf("failed to parse price history row: %w", err)
+		}
+		record, err := priceHistoryToRecord(&model)
+		if err != nil {
+			return nil, fmt.Errorf("price history entry %s: %w", model.EntryID, err)
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// priceHistoryToRecord converts a history row to a read-model record.
+func priceHistoryToRecord(model *mpricehistory.PriceHistoryEntry) (*contracts.PriceHistoryRecord, error) {
+	var discounts []mpricehistory.Discount
+	if err := json.Unmarshal([]byte(model.Discounts), &discounts); err != nil {
+		return nil, fmt.Errorf("invalid discounts: %w", err)
+	}
+	var scheduledPrices []mpricehistory.ScheduledPrice
+	if err := json.Unmarshal([]byte(model.ScheduledPrices), &scheduledPrices); err != nil {
+		return nil, fmt.Errorf("invalid scheduled prices: %w", err)
+	}
+
+	record := &contracts.PriceHistoryRecord{
+		EntryID:    model.EntryID,
+		RecordedAt: model.RecordedAt,
+		Reason:     model.Reason,
+		Pricing: contracts.ProductRecord{
+			ProductID: model.ProductID,
+			BasePrice: new(big.Rat).Set(&model.BasePrice),
+			Currency:  model.Currency,
+		},
+	}
+
+	for _, d := range discounts {
+		discount := contracts.DiscountRecord{
+			DiscountID: d.ID,
+			Kind:       d.Kind,
+			Start:      d.StartDate,
+			End:        d.EndDate,
+			Priority:   d.Priority,
+			Exclusive:  d.Exclusive,
+		}
+		if d.Percentage != "" {
+			discount.Percent, _ = new(big.Rat).SetString(d.Percentage)
+		}
+		if d.Amount != "" {
+			discount.Amount, _ = new(big.Rat).SetString(d.Amount)
+		}
+		record.Pricing.Discounts = append(record.Pricing.Discounts, discount)
+	}
+
+	for _, s := range scheduledPrices {
+		price, ok := new(big.Rat).SetString(s.Price)
+		if !ok {
+			return nil, fmt.Errorf("invalid scheduled price %s", s.ID)
+		}
+		record.Pricing.ScheduledPrices = append(record.Pricing.ScheduledPrices, contracts.ScheduledPriceRecord{
+			ScheduledPriceID: s.ID,
+			Price:            price,
+			EffectiveFrom:    s.EffectiveFrom,
+		})
+	}
+
+	return record, nil
+}
+
+// newEntryID returns a random identifier for a history entry.
+func newEntryID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
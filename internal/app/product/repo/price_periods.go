@@ -0,0 +1,66 @@
+package repo
+
+import (
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"product-catalog-service/internal/app/product/domain"
+	"product-catalog-service/internal/app/product/domain/services"
+	mproductpriceperiod "product-catalog-service/internal/models/m_product_price_period"
+)
+
+// The price timeline is materialized over the whole Spanner timestamp range
+// so any as_of finds its period.
+var (
+	pricePeriodsFrom  = time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC)
+	pricePeriodsUntil = time.Date(9999, 12, 31, 23, 59, 59, 999999000, time.UTC)
+)
+
+// effectivePriceSQL is the effective price of a product row at @as_of: the
+// price of the materialized period in force. Every product has periods
+// once the BackfillPricePeriods job has run.
+const effectivePriceSQL = "(SELECT pp.effective_price FROM product_price_periods pp" +
+	" WHERE pp.product_id = products.product_id AND pp.valid_from <= @as_of AND pp.valid_until > @as_of)"
+
+// PricePeriodMuts returns mutations that replace the materialized effective
+// price timeline of a product. Like discounts, the periods live in an
+// interleaved table and must follow the product insert in the same plan.
+// Returns nil if no pricing field is dirty.
+func (r *ProductRepo) PricePeriodMuts(p *domain.Product) []*spanner.Mutation {
+	if p == nil {
+		return nil
+	}
+	dirty := false
+	for _, f := range pricingFields {
+		dirty = dirty || p.Changes().Dirty(f)
+	}
+	if !dirty {
+		return nil
+	}
+
+	snapshots := []services.PriceSnapshot{{RecordedAt: pricePeriodsFrom, Product: p}}
+	muts := []*spanner.Mutation{mproductpriceperiod.DeleteAllMut(p.ID())}
+	for _, period := range r.pricing.Timeline(snapshots, pricePeriodsFrom, pricePeriodsUntil) {
+		// Spanner keeps microseconds; a discount ending at t is valid at t,
+		// so boundaries round up.
+		from, until := ceilMicro(period.From), ceilMicro(period.To)
+		if !from.Before(until) {
+			continue
+		}
+		muts = append(muts, mproductpriceperiod.InsertMut(&mproductpriceperiod.ProductPricePeriod{
+			ProductID:      p.ID(),
+			ValidFrom:      from,
+			ValidUntil:     until,
+			EffectivePrice: *period.EffectivePrice.Rat(),
+		}))
+	}
+	return muts
+}
+
+// ceilMicro rounds t up to the next microsecond.
+func ceilMicro(t time.Time) time.Time {
+	if truncated := t.Truncate(time.Microsecond); truncated.Before(t) {
+		return truncated.Add(time.Microsecond)
+	}
+	return t
+}
@@ -0,0 +1,191 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"product-catalog-service/internal/app/product/contracts"
+)
+
+// hasDiscountSQL is true for a product row with a discount valid at @as_of.
+const hasDiscountSQL = "EXISTS (SELECT 1 FROM product_discounts d" +
+	" WHERE d.product_id = products.product_id AND d.start_date <= @as_of AND d.end_date >= @as_of)"
+
+// CountProductFacets counts the products matching the filter by the
+// selected facets, with one aggregate query per facet in a single snapshot.
+func (r *ReadModel) CountProductFacets(
+	ctx context.Context,
+	filter contracts.ProductFilter,
+	facets contracts.ProductFacets,
+	readTime time.Time,
+) (*contracts.FacetCounts, error) {
+	txn := r.client.ReadOnlyTransaction()
+	if !readTime.IsZero() {
+		if time.Since(readTime) >= snapshotRetention {
+			return nil, fmt.Errorf("%w: it was taken at %s", contracts.ErrSnapshotExpired, readTime.Format(time.RFC3339))
+		}
+		txn = txn.WithTimestampBound(spanner.ReadTimestamp(readTime))
+	}
+	defer txn.Close()
+
+	counts := &contracts.FacetCounts{}
+	var err error
+	if facets.Category {
+		if counts.Categories, err = countByColumn(ctx, txn, filter, "category", readTime); err != nil {
+			return nil, err
+		}
+	}
+	if facets.Status {
+		if counts.Statuses, err = countByColumn(ctx, txn, filter, "status", readTime); err != nil {
+			return nil, err
+		}
+	}
+	if facets.Discount {
+		if err := countDiscounted(ctx, txn, filter, counts, readTime); err != nil {
+			return nil, err
+		}
+	}
+	if len(facets.PriceBuckets) > 0 {
+		if counts.PriceBuckets, err = countPriceBuckets(ctx, txn, filter, facets.PriceBuckets, readTime); err != nil {
+			return nil, err
+		}
+	}
+	return counts, nil
+}
+
+// countByColumn counts the matching products per value of a column, most
+// first.
+func countByColumn(ctx context.Context, txn *spanner.ReadOnlyTransaction, filter contracts.ProductFilter, column string, readTime time.Time) ([]contracts.FacetCount, error) {
+	params := map[string]interface{}{}
+	where, err := whereSQL(filter, params)
+	if err != nil {
+		return nil, err
+	}
+	stmt := spanner.Statement{
+		SQL: `SELECT ` + column + `, COUNT(*) AS n
+		      FROM products
+		      ` + where + `
+		      GROUP BY ` + column + `
+		      ORDER BY n DESC, ` + column,
+		Params: params,
+	}
+
+	var out []contracts.FacetCount
+	err = queryFacetRows(ctx, txn, stmt, readTime, func(row *spanner.Row) error {
+		var c contracts.FacetCount
+		if err := row.Columns(&c.Value, &c.Count); err != nil {
+			return fmt.Errorf("failed to parse %s facet row: %w", column, err)
+		}
+		out = append(out, c)
+		return nil
+	})
+	return out, err
+}
+
+// countDiscounted counts the matching products with and without a
+// discount valid at the filter's AsOf.
+func countDiscounted(ctx context.Context, txn *spanner.ReadOnlyTransaction, filter contracts.ProductFilter, counts *contracts.FacetCounts, readTime time.Time) error {
+	params := map[string]interface{}{}
+	where, err := whereSQL(filter, params)
+	if err != nil {
+		return err
+	}
+	setAsOf(filter, params)
+	stmt := spanner.Statement{
+		SQL: `SELECT discounted, COUNT(*)
+		      FROM (SELECT ` + hasDiscountSQL + ` AS discounted
+		            FROM products
+		            ` + where + `)
+		      GROUP BY discounted`,
+		Params: params,
+	}
+
+	return queryFacetRows(ctx, txn, stmt, readTime, func(row *spanner.Row) error {
+		var discounted bool
+		var n int64
+		if err := row.Columns(&discounted, &n); err != nil {
+			return fmt.Errorf("failed to parse discount facet row: %w", err)
+		}
+		if discounted {
+			counts.Discounted = n
+		} else {
+			counts.NotDiscounted = n
+		}
+		return nil
+	})
+}
+
+// countPriceBuckets counts the matching products per effective price
+// bucket at the filter's AsOf. bounds must be ascending.
+func countPriceBuckets(ctx context.Context, txn *spanner.ReadOnlyTransaction, filter contracts.ProductFilter, bounds []*big.Rat, readTime time.Time) ([]contracts.PriceBucketCount, error) {
+	params := map[string]interface{}{}
+	where, err := whereSQL(filter, params)
+	if err != nil {
+		return nil, err
+	}
+	setAsOf(filter, params)
+
+	// Products cheaper than the first bound fall in bucket -1
+	var cases strings.Builder
+	for i, bound := range bounds {
+		name := fmt.Sprintf("bucket_%d", i)
+		params[name] = spanner.NullNumeric{Numeric: *bound, Valid: true}
+		fmt.Fprintf(&cases, " WHEN price < @%s THEN %d", name, i-1)
+	}
+	stmt := spanner.Statement{
+		SQL: `SELECT bucket, COUNT(*)
+		      FROM (SELECT CASE` + cases.String() + fmt.Sprintf(" ELSE %d END", len(bounds)-1) + ` AS bucket
+		            FROM (SELECT ` + effectivePriceSQL + ` AS price
+		                  FROM products
+		                  ` + where + `))
+		      WHERE bucket >= 0
+		      GROUP BY bucket`,
+		Params: params,
+	}
+
+	out := make([]contracts.PriceBucketCount, len(bounds))
+	for i, bound := range bounds {
+		out[i].From = bound
+		if i+1 < len(bounds) {
+			out[i].To = bounds[i+1]
+		}
+	}
+	err = queryFacetRows(ctx, txn, stmt, readTime, func(row *spanner.Row) error {
+		var bucket, n int64
+		if err := row.Columns(&bucket, &n); err != nil {
+			return fmt.Errorf("failed to parse price facet row: %w", err)
+		}
+		out[bucket].Count = n
+		return nil
+	})
+	return out, err
+}
+
+// queryFacetRows runs a facet query, calling fn for every row.
+func queryFacetRows(ctx context.Context, txn *spanner.ReadOnlyTransaction, stmt spanner.Statement, readTime time.Time, fn func(*spanner.Row) error) error {
+	iter := txn.Query(ctx, stmt)
+	defer iter.Stop()
+
+	for {
+		row, err := iter.Next()
+		if err != nil {
+			if err == iterator.Done {
+				return nil
+			}
+			// Spanner rejects reads older than its version GC window
+			if !readTime.IsZero() && spanner.ErrCode(err) == codes.FailedPrecondition {
+				return fmt.Errorf("%w: %v", contracts.ErrSnapshotExpired, err)
+			}
+			return err
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+}
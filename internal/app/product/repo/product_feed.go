@@ -0,0 +1,166 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
+	"product-catalog-service/internal/app/product/contracts"
+)
+
+// feedSyncOverlap re-reads recent outbox events on every sync. Outbox
+// rows carry the time they were built, not their commit time, so a slow
+// transaction can make an event visible after later ones were read.
+const feedSyncOverlap = 30 * time.Second
+
+// feedSyncBatch bounds the outbox events read per page.
+const feedSyncBatch = 1000
+
+// feedFields are the record fields a productFeed reads.
+const feedFields = contracts.ProductFieldName | contracts.ProductFieldDescription |
+	contracts.ProductFieldCategory | contracts.ProductFieldStatus | contracts.ProductFieldTimestamps
+
+// productFeed follows the active, unarchived products for an in-memory
+// index: load reads them all, then sync reports the products named by
+// outbox events since. apply receives a nil record for products to drop.
+// It is not safe for concurrent use.
+type productFeed struct {
+	client    *spanner.Client
+	readModel *ReadModel
+	// since is the creation time of the last outbox event applied.
+	since time.Time
+}
+
+func newProductFeed(client *spanner.Client) *productFeed {
+	return &productFeed{
+		client:    client,
+		readModel: NewReadModel(client),
+	}
+}
+
+// load calls apply with every active, unarchived product. Changes committed
+// while loading are picked up by the next sync.
+func (f *productFeed) load(ctx context.Context, apply func(id string, record *contracts.ProductRecord)) error {
+	start := time.Now()
+	stmt := spanner.Statement{
+		SQL: `SELECT product_id, name, description, category
+		      FROM products
+		      WHERE status = 'active' AND archived_at IS NULL`,
+	}
+	iter := f.client.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	for {
+		row, err := iter.Next()
+		if err != nil {
+			if err == iterator.Done {
+				break
+			}
+			return err
+		}
+
+		var record contracts.ProductRecord
+		var description spanner.NullString
+		if err := row.Columns(&record.ProductID, &record.Name, &description, &record.Category); err != nil {
+			return fmt.Errorf("failed to parse product row: %w", err)
+		}
+		record.Description = description.StringVal
+		record.Status = "active"
+		apply(record.ProductID, &record)
+	}
+
+	f.since = start
+	return nil
+}
+
+// sync calls apply with the products named by outbox events created since
+// the last sync; the record is nil when the product is no longer active or
+// was archived. Events are read in pages by (created_at, event_id); only
+// the first page reaches back by feedSyncOverlap.
+func (f *productFeed) sync(ctx context.Context, apply func(id string, record *contracts.ProductRecord)) error {
+	after := feedPosition{createdAt: f.since.Add(-feedSyncOverlap)}
+	for {
+		ids, last, n, err := f.readEvents(ctx, after)
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return nil
+		}
+
+		records, err := f.readModel.GetProductsByIDs(ctx, ids, feedFields)
+		if err != nil {
+			return err
+		}
+		for i, record := range records {
+			if record == nil || record.Status != "active" || record.ArchivedAt != nil {
+				apply(ids[i], nil)
+				continue
+			}
+			apply(ids[i], record)
+		}
+
+		if last.createdAt.After(f.since) {
+			f.since = last.createdAt
+		}
+		if n < feedSyncBatch {
+			return nil
+		}
+		after = last
+	}
+}
+
+// feedPosition is the position of an outbox event in sync order.
+type feedPosition struct {
+	createdAt time.Time
+	eventID   string
+}
+
+// readEvents reads up to feedSyncBatch outbox events after the given
+// position, served by idx_outbox_created. It returns the distinct aggregate
+// IDs they name, the position of the last event and the number of events
+// read.
+func (f *productFeed) readEvents(ctx context.Context, after feedPosition) ([]string, feedPosition, int, error) {
+	stmt := spanner.Statement{
+		SQL: `SELECT aggregate_id, created_at, event_id
+		      FROM outbox_events
+		      WHERE created_at >= @after_at
+		        AND (created_at > @after_at OR event_id > @after_id)
+		      ORDER BY created_at, event_id
+		      LIMIT @limit`,
+		Params: map[string]interface{}{
+			"after_at": after.createdAt,
+			"after_id": after.eventID,
+			"limit":    feedSyncBatch,
+		},
+	}
+	iter := f.client.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	var ids []string
+	seen := map[string]bool{}
+	last := after
+	n := 0
+	for {
+		row, err := iter.Next()
+		if err != nil {
+			if err == iterator.Done {
+				break
+			}
+			return nil, feedPosition{}, 0, err
+		}
+
+		var id string
+		if err := row.Columns(&id, &last.createdAt, &last.eventID); err != nil {
+			return nil, feedPosition{}, 0, fmt.Errorf("failed to parse outbox row: %w", err)
+		}
+		n++
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids, last, n, nil
+}
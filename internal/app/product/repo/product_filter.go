@@ -0,0 +1,227 @@
+package repo
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"product-catalog-service/internal/app/product/contracts"
+	"product-catalog-service/internal/models/mproduct"
+	"product-catalog-service/internal/pkg/filter"
+)
+
+// filterColumns maps filterable fields to SQL expressions over the
+// products table. price is the effective price at @as_of, like the price
+// range and order; has_discount is handled separately.
+var filterColumns = map[string]string{
+	"product_id": mproduct.ProductID,
+	"name":       mproduct.Name,
+	"category":   mproduct.Category,
+	"status":     mproduct.Status,
+	"currency":   mproduct.Currency,
+	"price":      effectivePriceSQL,
+	"created_at": mproduct.CreatedAt,
+	"updated_at": mproduct.UpdatedAt,
+}
+
+// attributeFieldPrefix starts the filterable fields naming a product
+// attribute, e.g. attributes.color.
+const attributeFieldPrefix = "attributes."
+
+// whereSQL builds the WHERE clause selecting the products matching f,
+// adding its parameters to params.
+func whereSQL(f contracts.ProductFilter, params map[string]interface{}) (string, error) {
+	sql := "WHERE TRUE"
+
+	// Add status filter if provided
+	if len(f.Statuses) > 0 {
+		sql += " AND status IN UNNEST(@statuses)"
+		params["statuses"] = f.Statuses
+	}
+
+	// Archived products are hidden unless requested
+	if !f.IncludeArchived {
+		sql += " AND archived_at IS NULL"
+	}
+
+	// Add category filter if provided
+	if f.Category != nil && *f.Category != "" {
+		sql += " AND category = @category"
+		params["category"] = *f.Category
+	}
+
+	// Add category set if provided
+	if f.Categories != nil {
+		sql += " AND category IN UNNEST(@categories)"
+		params["categories"] = f.Categories
+	}
+
+	// Add product IDs if provided
+	if f.ProductIDs != nil {
+		sql += " AND product_id IN UNNEST(@product_ids)"
+		params["product_ids"] = f.ProductIDs
+	}
+
+	// Add filter expression if provided
+	if f.Expr != nil {
+		cond, err := filterSQL(f.Expr, f.AsOf, params)
+		if err != nil {
+			return "", err
+		}
+		sql += " AND " + cond
+	}
+
+	// Add effective price range if provided
+	if f.MinPrice != nil {
+		setAsOf(f, params)
+		sql += " AND " + effectivePriceSQL + " >= @min_price"
+		params["min_price"] = spanner.NullNumeric{Numeric: *f.MinPrice, Valid: true}
+	}
+	if f.MaxPrice != nil {
+		setAsOf(f, params)
+		sql += " AND " + effectivePriceSQL + " <= @max_price"
+		params["max_price"] = spanner.NullNumeric{Numeric: *f.MaxPrice, Valid: true}
+	}
+
+	return sql, nil
+}
+
+// setAsOf sets the @as_of parameter effective prices are evaluated at.
+func setAsOf(f contracts.ProductFilter, params map[string]interface{}) {
+	asOf := f.AsOf
+	if asOf.IsZero() {
+		asOf = time.Now()
+	}
+	params["as_of"] = asOf
+}
+
+// filterTranslator turns a parsed filter into a parameterized SQL condition.
+type filterTranslator struct {
+	params map[string]interface{}
+	asOf   time.Time
+	next   int
+}
+
+// filterSQL translates expr into a SQL condition over the products table,
+// adding its parameters to params. Prices and discounts are checked as of
+// asOf, or now if it is zero.
+func filterSQL(expr filter.Expr, asOf time.Time, params map[string]interface{}) (string, error) {
+	if asOf.IsZero() {
+		asOf = time.Now()
+	}
+	t := &filterTranslator{params: params, asOf: asOf}
+	return t.translate(expr)
+}
+
+func (t *filterTranslator) translate(expr filter.Expr) (string, error) {
+	switch e := expr.(type) {
+	case filter.And:
+		return t.binary("AND", e.Left, e.Right)
+	case filter.Or:
+		return t.binary("OR", e.Left, e.Right)
+	case filter.Not:
+		inner, err := t.translate(e.Expr)
+		if err != nil {
+			return "", err
+		}
+		return "NOT (" + inner + ")", nil
+	case filter.Comparison:
+		return t.comparison(e)
+	default:
+		return "", fmt.Errorf("unsupported filter expression %T", expr)
+	}
+}
+
+func (t *filterTranslator) binary(op string, left, right filter.Expr) (string, error) {
+	l, err := t.translate(left)
+	if err != nil {
+		return "", err
+	}
+	r, err := t.translate(right)
+	if err != nil {
+		return "", err
+	}
+	return "(" + l + " " + op + " " + r + ")", nil
+}
+
+func (t *filterTranslator) comparison(c filter.Comparison) (string, error) {
+	if c.Field == "has_discount" {
+		return t.hasDiscount(c)
+	}
+	if strings.HasPrefix(c.Field, attributeFieldPrefix) {
+		return t.attribute(c)
+	}
+
+	column, ok := filterColumns[c.Field]
+	if !ok {
+		return "", fmt.Errorf("field %q cannot be filtered", c.Field)
+	}
+	if column == effectivePriceSQL {
+		t.params["as_of"] = t.asOf
+	}
+
+	var value interface{}
+	switch c.Value.Type {
+	case filter.TypeString:
+		value = c.Value.String
+	case filter.TypeNumber:
+		value = spanner.NullNumeric{Numeric: *c.Value.Number, Valid: true}
+	case filter.TypeBool:
+		value = c.Value.Bool
+	case filter.TypeTimestamp:
+		value = c.Value.Time
+	default:
+		return "", fmt.Errorf("field %q has an unsupported type", c.Field)
+	}
+
+	return fmt.Sprintf("%s %s @%s", column, c.Op, t.param(value)), nil
+}
+
+// hasDiscount checks for a discount valid at asOf.
+func (t *filterTranslator) hasDiscount(c filter.Comparison) (string, error) {
+	if c.Value.Type != filter.TypeBool {
+		return "", fmt.Errorf("field %q must be compared with a bool", c.Field)
+	}
+	asOf := t.param(t.asOf)
+	exists := fmt.Sprintf(
+		"EXISTS (SELECT 1 FROM product_discounts d WHERE d.product_id = products.product_id AND d.start_date <= @%s AND d.end_date >= @%s)",
+		asOf, asOf,
+	)
+	if c.Value.Bool == (c.Op == filter.OpEqual) {
+		return exists, nil
+	}
+	return "NOT " + exists, nil
+}
+
+// attribute checks for an attribute with a matching value: strings match
+// string and enum attributes, numbers number and measurement attributes
+// (in their stored unit) and bools bool attributes. Products without the
+// attribute match neither = nor !=.
+func (t *filterTranslator) attribute(c filter.Comparison) (string, error) {
+	var column string
+	var value interface{}
+	switch c.Value.Type {
+	case filter.TypeString:
+		column, value = "string_value", c.Value.String
+	case filter.TypeNumber:
+		column, value = "number_value", spanner.NullNumeric{Numeric: *c.Value.Number, Valid: true}
+	case filter.TypeBool:
+		column, value = "bool_value", c.Value.Bool
+	default:
+		return "", fmt.Errorf("field %q cannot be compared with a %s", c.Field, c.Value.Type)
+	}
+	key := t.param(strings.TrimPrefix(c.Field, attributeFieldPrefix))
+	return fmt.Sprintf(
+		"EXISTS (SELECT 1 FROM product_attributes a WHERE a.product_id = products.product_id AND a.attribute_key = @%s AND a.%s %s @%s)",
+		key, column, c.Op, t.param(value),
+	), nil
+}
+
+// param adds a parameter and returns its name.
+func (t *filterTranslator) param(value interface{}) string {
+	name := fmt.Sprintf("f%d", t.next)
+	t.next++
+	t.params[name] = value
+	return name
+}
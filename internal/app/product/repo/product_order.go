@@ -0,0 +1,165 @@
+package repo
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"product-catalog-service/internal/app/product/contracts"
+	"product-catalog-service/internal/models/mproduct"
+)
+
+// sortColumns maps the sortable fields to SQL expressions over the
+// products table. Each is backed by a (column, product_id) index except
+// price, the effective price at @as_of.
+var sortColumns = map[contracts.ProductSortField]string{
+	contracts.ProductSortID:        mproduct.ProductID,
+	contracts.ProductSortName:      mproduct.Name,
+	contracts.ProductSortCreatedAt: mproduct.CreatedAt,
+	contracts.ProductSortUpdatedAt: mproduct.UpdatedAt,
+	contracts.ProductSortPrice:     effectivePriceSQL,
+}
+
+// sortKeyColumn is the alias the sort key is selected as.
+const sortKeyColumn = "sort_key"
+
+// pageCursor is the position after the last product of a page. Cursors
+// are its base64-encoded JSON.
+type pageCursor struct {
+	// Order is the listing order the cursor belongs to, e.g. "name desc".
+	Order string `json:"o"`
+	// Key is the sort key of the last product; empty when ordering by
+	// product_id.
+	Key string `json:"k,omitempty"`
+	// ID is the product_id of the last product.
+	ID string `json:"id"`
+}
+
+// normalizeOrder fills in the default sort field and rejects unknown ones.
+func normalizeOrder(order contracts.ProductOrder) (contracts.ProductOrder, error) {
+	if order.Field == "" {
+		order.Field = contracts.ProductSortID
+	}
+	if _, ok := sortColumns[order.Field]; !ok {
+		return order, fmt.Errorf("cannot order products by %q", order.Field)
+	}
+	return order, nil
+}
+
+// orderSQL returns the ORDER BY clause of a listing, tie-breaking on
+// product_id in the same direction.
+func orderSQL(order contracts.ProductOrder) string {
+	dir := ""
+	if order.Descending {
+		dir = " DESC"
+	}
+	if order.Field == contracts.ProductSortID {
+		return " ORDER BY product_id" + dir
+	}
+	return " ORDER BY " + sortColumns[order.Field] + dir + ", product_id" + dir
+}
+
+// cursorSQL returns the condition selecting the products after the cursor
+// and adds its parameters to params.
+func cursorSQL(order contracts.ProductOrder, cursor pageCursor, params map[string]interface{}) (string, error) {
+	cmp := ">"
+	if order.Descending {
+		cmp = "<"
+	}
+	params["cursor"] = cursor.ID
+	if order.Field == contracts.ProductSortID {
+		return " AND product_id " + cmp + " @cursor", nil
+	}
+
+	key, err := decodeSortKey(order.Field, cursor.Key)
+	if err != nil {
+		return "", err
+	}
+	params["sort_key"] = key
+	column := sortColumns[order.Field]
+	return fmt.Sprintf(" AND (%s %s @sort_key OR (%s = @sort_key AND product_id %s @cursor))",
+		column, cmp, column, cmp), nil
+}
+
+// readSortKey reads the sort key selected as sortKeyColumn and encodes it
+// for a cursor.
+func readSortKey(order contracts.ProductOrder, row *spanner.Row) (string, error) {
+	switch order.Field {
+	case contracts.ProductSortName:
+		var key string
+		if err := row.ColumnByName(sortKeyColumn, &key); err != nil {
+			return "", err
+		}
+		return key, nil
+	case contracts.ProductSortCreatedAt, contracts.ProductSortUpdatedAt:
+		var key time.Time
+		if err := row.ColumnByName(sortKeyColumn, &key); err != nil {
+			return "", err
+		}
+		return key.UTC().Format(time.RFC3339Nano), nil
+	case contracts.ProductSortPrice:
+		var key spanner.NullNumeric
+		if err := row.ColumnByName(sortKeyColumn, &key); err != nil {
+			return "", err
+		}
+		if !key.Valid {
+			return "", fmt.Errorf("product price is missing")
+		}
+		return spanner.NumericString(&key.Numeric), nil
+	default:
+		return "", nil
+	}
+}
+
+// encodeCursor returns the cursor of the page after the product with the
+// given ID and encoded sort key.
+func encodeCursor(order contracts.ProductOrder, id, key string) (string, error) {
+	cursor := pageCursor{Order: order.String(), Key: key, ID: id}
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+// decodeCursor parses a cursor issued for the given order.
+func decodeCursor(order contracts.ProductOrder, s string) (pageCursor, error) {
+	data, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return pageCursor{}, contracts.ErrInvalidPageToken
+	}
+
+	var cursor pageCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return pageCursor{}, contracts.ErrInvalidPageToken
+	}
+	if cursor.Order != order.String() || cursor.ID == "" {
+		return pageCursor{}, fmt.Errorf("%w: it was issued for order_by %q", contracts.ErrInvalidPageToken, cursor.Order)
+	}
+	return cursor, nil
+}
+
+// decodeSortKey converts an encoded sort key to a query parameter.
+func decodeSortKey(field contracts.ProductSortField, key string) (interface{}, error) {
+	switch field {
+	case contracts.ProductSortName:
+		return key, nil
+	case contracts.ProductSortCreatedAt, contracts.ProductSortUpdatedAt:
+		t, err := time.Parse(time.RFC3339Nano, key)
+		if err != nil {
+			return nil, contracts.ErrInvalidPageToken
+		}
+		return t, nil
+	case contracts.ProductSortPrice:
+		r, ok := new(big.Rat).SetString(key)
+		if !ok {
+			return nil, contracts.ErrInvalidPageToken
+		}
+		return spanner.NullNumeric{Numeric: *r, Valid: true}, nil
+	default:
+		return nil, contracts.ErrInvalidPageToken
+	}
+}
@@ -2,24 +2,36 @@ package repo
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"math/big"
 	"time"
 
 	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
 	"product-catalog-service/internal/app/product/contracts"
 	"product-catalog-service/internal/app/product/domain"
+	"product-catalog-service/internal/app/product/domain/services"
+	mproductattribute "product-catalog-service/internal/models/m_product_attribute"
+	mproductdiscount "product-catalog-service/internal/models/m_product_discount"
+	mproductscheduledprice "product-catalog-service/internal/models/m_product_scheduled_price"
+	mproductvariant "product-catalog-service/internal/models/m_product_variant"
 	"product-catalog-service/internal/models/mproduct"
+	"product-catalog-service/internal/pkg/committer"
 )
 
 // ProductRepo implements contracts.ProductRepo using Spanner.
 type ProductRepo struct {
 	client *spanner.Client
+	// pricing computes the materialized effective price periods.
+	pricing services.PricingCalculator
 }
 
+var _ contracts.ProductRepo = (*ProductRepo)(nil)
+
 // NewProductRepo creates a new ProductRepo with the given Spanner client.
-func NewProductRepo(client *spanner.Client) *ProductRepo {
-	return &ProductRepo{client: client}
+// pricing must match the calculator used by the queries.
+func NewProductRepo(client *spanner.Client, pricing services.PricingCalculator) *ProductRepo {
+	return &ProductRepo{client: client, pricing: pricing}
 }
 
 // InsertMut returns a mutation to insert a new product.
@@ -29,36 +41,16 @@ func (r *ProductRepo) InsertMut(p *domain.Product) *spanner.Mutation {
 		return nil
 	}
 
-	baseNum, baseDen := p.BasePrice().Fraction()
-
 	model := &mproduct.Product{
-		ProductID:            p.ID(),
-		Name:                 p.Name(),
-		Description:          p.Description(),
-		Category:             p.Category(),
-		BasePriceNumerator:   baseNum,
-		BasePriceDenominator: baseDen,
-		Status:               string(p.Status()),
-		CreatedAt:            p.CreatedAt(),
-		UpdatedAt:            p.UpdatedAt(),
-	}
-
-	if discount := p.Discount(); discount != nil {
-		// Convert discount percentage to NUMERIC
-		percent := discount.Percentage()
-		// NUMERIC in Spanner is stored as string representation
-		model.DiscountPercent = &spanner.NullNumeric{
-			Numeric: spanner.Numeric(percent.String()),
-			Valid:   true,
-		}
-		model.DiscountStartDate = spanner.NullTime{
-			Time:  discount.StartAt(),
-			Valid: true,
-		}
-		model.DiscountEndDate = spanner.NullTime{
-			Time:  discount.EndAt(),
-			Valid: true,
-		}
+		ProductID:   p.ID(),
+		Name:        p.Name(),
+		Description: p.Description(),
+		Category:    p.Category(),
+		BasePrice:   spanner.NullNumeric{Numeric: *p.BasePrice().Rat(), Valid: true},
+		Currency:    string(p.Currency()),
+		Status:      string(p.Status()),
+		CreatedAt:   p.CreatedAt(),
+		UpdatedAt:   p.UpdatedAt(),
 	}
 
 	if archivedAt := p.ArchivedAt(); archivedAt != nil {
@@ -97,27 +89,16 @@ func (r *ProductRepo) UpdateMut(p *domain.Product) *spanner.Mutation {
 		updates[mproduct.Status] = string(p.Status())
 	}
 
-	if p.Changes().Dirty(domain.FieldDiscount) {
-		if discount := p.Discount(); discount != nil {
-			percent := discount.Percentage()
-			updates[mproduct.DiscountPercent] = spanner.NullNumeric{
-				Numeric: spanner.Numeric(percent.String()),
-				Valid:   true,
-			}
-			updates[mproduct.DiscountStartDate] = spanner.NullTime{
-				Time:  discount.StartAt(),
-				Valid: true,
-			}
-			updates[mproduct.DiscountEndDate] = spanner.NullTime{
-				Time:  discount.EndAt(),
-				Valid: true,
-			}
-		} else {
-			// Clear discount
-			updates[mproduct.DiscountPercent] = spanner.NullNumeric{Valid: false}
-			updates[mproduct.DiscountStartDate] = spanner.NullTime{Valid: false}
-			updates[mproduct.DiscountEndDate] = spanner.NullTime{Valid: false}
-		}
+	if p.Changes().Dirty(domain.FieldBasePrice) {
+		updates[mproduct.BasePrice] = spanner.NullNumeric{Numeric: *p.BasePrice().Rat(), Valid: true}
+	}
+
+	if p.Changes().Dirty(domain.FieldDiscount) || p.Changes().Dirty(domain.FieldScheduledPrices) ||
+		p.Changes().Dirty(domain.FieldAttributes) || p.Changes().Dirty(domain.FieldVariants) {
+		// Discounts, scheduled prices, attributes and variants are written
+		// by DiscountMuts, ScheduledPriceMuts, AttributeMuts and
+		// VariantMuts; only the row timestamp changes here.
+		updates[mproduct.UpdatedAt] = p.UpdatedAt()
 	}
 
 	if p.Changes().Dirty(domain.FieldArchivedAt) {
@@ -140,19 +121,153 @@ func (r *ProductRepo) UpdateMut(p *domain.Product) *spanner.Mutation {
 	return nil // No changes
 }
 
+// DiscountMuts returns mutations that write the applied and replaced
+// discounts of a product and delete the removed and expired ones, leaving
+// the discounts it did not change untouched. Discounts are stored in the
+// interleaved product_discounts table, so the mutations must follow the
+// product insert in the same plan.
+// Returns nil if no discount is dirty.
+func (r *ProductRepo) DiscountMuts(p *domain.Product) []*spanner.Mutation {
+	if p == nil {
+		return nil
+	}
+
+	var muts []*spanner.Mutation
+	prefix := domain.DiscountField("")
+	for _, field := range p.Changes().DirtyWithPrefix(prefix) {
+		id := field[len(prefix):]
+		if d, ok := p.Discount(id); ok {
+			muts = append(muts, mproductdiscount.InsertOrUpdateMut(discountToModel(p.ID(), d)))
+		} else {
+			muts = append(muts, mproductdiscount.DeleteMut(p.ID(), id))
+		}
+	}
+	return muts
+}
+
+// ScheduledPriceMuts returns mutations that replace the stored scheduled
+// prices of a product with its current set. Like discounts, they live in an
+// interleaved table and must follow the product insert in the same plan.
+// Returns nil if scheduled prices are not dirty.
+func (r *ProductRepo) ScheduledPriceMuts(p *domain.Product) []*spanner.Mutation {
+	if p == nil || !p.Changes().Dirty(domain.FieldScheduledPrices) {
+		return nil
+	}
+
+	muts := []*spanner.Mutation{mproductscheduledprice.DeleteAllMut(p.ID())}
+	for _, s := range p.ScheduledPrices() {
+		muts = append(muts, mproductscheduledprice.InsertMut(scheduledPriceToModel(p.ID(), s)))
+	}
+	return muts
+}
+
+// AttributeMuts returns mutations that write the changed attributes of a
+// product and delete the removed ones. Attributes live in the interleaved
+// product_attributes table and must follow the product insert in the same
+// plan.
+// Returns nil if no attribute is dirty.
+func (r *ProductRepo) AttributeMuts(p *domain.Product) []*spanner.Mutation {
+	if p == nil {
+		return nil
+	}
+
+	var muts []*spanner.Mutation
+	prefix := domain.AttributeField("")
+	for _, field := range p.Changes().DirtyWithPrefix(prefix) {
+		key := field[len(prefix):]
+		if v, ok := p.Attribute(key); ok {
+			muts = append(muts, mproductattribute.InsertOrUpdateMut(attributeToModel(p.ID(), key, v)))
+		} else {
+			muts = append(muts, mproductattribute.DeleteMut(p.ID(), key))
+		}
+	}
+	return muts
+}
+
+// VariantMuts returns mutations that write the added and changed variants
+// of a product and delete the removed ones. Variants live in the
+// interleaved product_variants table and must follow the product insert in
+// the same plan.
+// Returns nil if no variant is dirty.
+func (r *ProductRepo) VariantMuts(p *domain.Product) []*spanner.Mutation {
+	if p == nil {
+		return nil
+	}
+
+	var muts []*spanner.Mutation
+	prefix := domain.VariantField("")
+	for _, field := range p.Changes().DirtyWithPrefix(prefix) {
+		id := field[len(prefix):]
+		if v, ok := p.Variant(id); ok {
+			muts = append(muts, mproductvariant.InsertOrUpdateMut(variantToModel(p.ID(), v)))
+		} else {
+			muts = append(muts, mproductvariant.DeleteMut(p.ID(), id))
+		}
+	}
+	return muts
+}
+
+// FindVariantBySKU returns the IDs of the product and variant with the
+// given SKU using the unique SKU index, or domain.ErrVariantNotFound.
+func (r *ProductRepo) FindVariantBySKU(ctx context.Context, sku string) (productID, variantID string, err error) {
+	return findVariantBySKU(ctx, r.client.Single(), sku)
+}
+
+// SKUCheck returns a check that fails with domain.ErrSKUTaken when a
+// variant of another product has the SKU in the committing transaction.
+func (r *ProductRepo) SKUCheck(sku, productID string) committer.Check {
+	return func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		owner, _, err := findVariantBySKU(ctx, txn, sku)
+		if err != nil {
+			if errors.Is(err, domain.ErrVariantNotFound) {
+				return nil
+			}
+			return err
+		}
+		if owner != productID {
+			return fmt.Errorf("%w: %q", domain.ErrSKUTaken, sku)
+		}
+		return nil
+	}
+}
+
+// indexRowReader is implemented by single-use, read-only and read-write
+// transactions.
+type indexRowReader interface {
+	ReadRowUsingIndex(ctx context.Context, table, index string, key spanner.Key, columns []string) (*spanner.Row, error)
+}
+
+// findVariantBySKU looks a variant up in the unique SKU index.
+func findVariantBySKU(ctx context.Context, reader indexRowReader, sku string) (productID, variantID string, err error) {
+	row, err := reader.ReadRowUsingIndex(ctx, mproductvariant.TableName, mproductvariant.SKUIndex,
+		spanner.Key{sku}, []string{mproductvariant.ProductID, mproductvariant.VariantID})
+	if err != nil {
+		if spanner.ErrCode(err) == spanner.ErrCode(spanner.ErrNotFound) {
+			return "", "", domain.ErrVariantNotFound
+		}
+		return "", "", err
+	}
+	if err := row.Columns(&productID, &variantID); err != nil {
+		return "", "", fmt.Errorf("failed to parse variant key: %w", err)
+	}
+	return productID, variantID, nil
+}
+
 // FindByID loads a product aggregate by ID.
 // Returns domain error if not found.
 func (r *ProductRepo) FindByID(ctx context.Context, id string) (*domain.Product, error) {
-	row, err := r.client.Single().ReadRow(ctx, mproduct.TableName, spanner.Key{id}, []string{
+	txn := r.client.ReadOnlyTransaction()
+	defer txn.Close()
+
+	row, err := txn.ReadRow(ctx, mproduct.TableName, spanner.Key{id}, []string{
 		mproduct.ProductID,
 		mproduct.Name,
 		mproduct.Description,
 		mproduct.Category,
+		mproduct.BasePrice,
 		mproduct.BasePriceNumerator,
 		mproduct.BasePriceDenominator,
-		mproduct.DiscountPercent,
-		mproduct.DiscountStartDate,
-		mproduct.DiscountEndDate,
+		mproduct.Currency,
 		mproduct.Status,
 		mproduct.CreatedAt,
 		mproduct.UpdatedAt,
@@ -170,36 +285,196 @@ func (r *ProductRepo) FindByID(ctx context.Context, id string) (*domain.Product,
 		return nil, fmt.Errorf("failed to parse product row: %w", err)
 	}
 
-	return r.toDomain(&model)
+	discounts, err := readDiscounts(ctx, txn, []string{id})
+	if err != nil {
+		return nil, err
+	}
+	scheduledPrices, err := readScheduledPrices(ctx, txn, []string{id})
+	if err != nil {
+		return nil, err
+	}
+	attributes, err := readAttributes(ctx, txn, []string{id})
+	if err != nil {
+		return nil, err
+	}
+	variants, err := readVariants(ctx, txn, []string{id})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.toDomain(&model, discounts[id], scheduledPrices[id], attributes[id], variants[id])
+}
+
+// FindPriceTransitions returns up to limit price transitions after the
+// given one and at or before until, in (at, product_id) order. A discount
+// ends the microsecond after its end date, when it stops being active.
+func (r *ProductRepo) FindPriceTransitions(
+	ctx context.Context,
+	after contracts.PriceTransition,
+	until time.Time,
+	limit int,
+) ([]contracts.PriceTransition, error) {
+	stmt := spanner.Statement{
+		SQL: `SELECT product_id, at FROM (
+		        SELECT product_id, start_date AS at FROM product_discounts
+		        WHERE start_date >= @after_at AND start_date <= @until
+		        UNION DISTINCT
+		        SELECT product_id, TIMESTAMP_ADD(end_date, INTERVAL 1 MICROSECOND) AS at FROM product_discounts
+		        WHERE end_date >= TIMESTAMP_SUB(@after_at, INTERVAL 1 MICROSECOND) AND end_date < @until
+		        UNION DISTINCT
+		        SELECT product_id, effective_from AS at FROM product_scheduled_prices
+		        WHERE effective_from >= @after_at AND effective_from <= @until
+		      )
+		      WHERE at > @after_at OR (at = @after_at AND product_id > @after_product_id)
+		      ORDER BY at, product_id
+		      LIMIT @limit`,
+		Params: map[string]interface{}{
+			"after_at":         after.At,
+			"after_product_id": after.ProductID,
+			"until":            until,
+			"limit":            int64(limit),
+		},
+	}
+
+	iter := r.client.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	var transitions []contracts.PriceTransition
+	for {
+		row, err := iter.Next()
+		if err != nil {
+			if err == iterator.Done {
+				break
+			}
+			return nil, err
+		}
+
+		var t contracts.PriceTransition
+		if err := row.Columns(&t.ProductID, &t.At); err != nil {
+			return nil, fmt.Errorf("failed to parse price transition: %w", err)
+		}
+		transitions = append(transitions, t)
+	}
+
+	return transitions, nil
+}
+
+// FindIDsWithoutPricePeriods returns up to limit IDs of products after the
+// given ID that have no materialized price timeline.
+func (r *ProductRepo) FindIDsWithoutPricePeriods(
+	ctx context.Context,
+	after string,
+	limit int,
+) ([]string, error) {
+	stmt := spanner.Statement{
+		SQL: `SELECT product_id FROM products
+		      WHERE product_id > @after
+		        AND NOT EXISTS (SELECT 1 FROM product_price_periods pp WHERE pp.product_id = products.product_id)
+		      ORDER BY product_id
+		      LIMIT @limit`,
+		Params: map[string]interface{}{
+			"after": after,
+			"limit": int64(limit),
+		},
+	}
+
+	return r.queryIDs(ctx, stmt)
+}
+
+// FindIDsByCategory returns up to limit IDs of products in a category,
+// archived ones included.
+func (r *ProductRepo) FindIDsByCategory(
+	ctx context.Context,
+	category string,
+	limit int,
+) ([]string, error) {
+	stmt := spanner.Statement{
+		SQL: `SELECT product_id FROM products
+		      WHERE category = @category
+		      ORDER BY product_id
+		      LIMIT @limit`,
+		Params: map[string]interface{}{
+			"category": category,
+			"limit":    int64(limit),
+		},
+	}
+
+	return r.queryIDs(ctx, stmt)
+}
+
+// queryIDs runs a statement whose first column is a product ID.
+func (r *ProductRepo) queryIDs(ctx context.Context, stmt spanner.Statement) ([]string, error) {
+	iter := r.client.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	var ids []string
+	for {
+		row, err := iter.Next()
+		if err != nil {
+			if err == iterator.Done {
+				break
+			}
+			return nil, err
+		}
+
+		var id string
+		if err := row.Column(0, &id); err != nil {
+			return nil, fmt.Errorf("failed to parse product id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
 }
 
 // toDomain converts a database model to a domain aggregate.
-func (r *ProductRepo) toDomain(model *mproduct.Product) (*domain.Product, error) {
-	basePrice, err := domain.NewMoneyFromFraction(
-		model.BasePriceNumerator,
-		model.BasePriceDenominator,
-	)
+func (r *ProductRepo) toDomain(
+	model *mproduct.Product,
+	discountModels []*mproductdiscount.ProductDiscount,
+	scheduledPriceModels []*mproductscheduledprice.ProductScheduledPrice,
+	attributeModels []*mproductattribute.ProductAttribute,
+	variantModels []*mproductvariant.ProductVariant,
+) (*domain.Product, error) {
+	basePrice, err := basePriceFromModel(model)
 	if err != nil {
 		return nil, fmt.Errorf("invalid base price: %w", err)
 	}
+	basePrice = basePrice.WithCurrency(domain.Currency(model.Currency))
 
-	var discount *domain.Discount
-	if model.DiscountPercent.Valid && model.DiscountStartDate.Valid && model.DiscountEndDate.Valid {
-		// Parse NUMERIC string to big.Rat
-		percentStr := string(model.DiscountPercent.Numeric)
-		percent := new(big.Rat)
-		if _, ok := percent.SetString(percentStr); !ok {
-			return nil, fmt.Errorf("invalid discount percentage: %s", percentStr)
+	discounts := make([]*domain.Discount, 0, len(discountModels))
+	for _, dm := range discountModels {
+		discount, err := discountFromModel(dm)
+		if err != nil {
+			return nil, fmt.Errorf("invalid discount %s: %w", dm.DiscountID, err)
 		}
+		discounts = append(discounts, discount)
+	}
+
+	scheduledPrices := make([]*domain.ScheduledPrice, 0, len(scheduledPriceModels))
+	for _, sm := range scheduledPriceModels {
+		scheduled, err := scheduledPriceFromModel(sm)
+		if err != nil {
+			return nil, fmt.Errorf("invalid scheduled price %s: %w", sm.ScheduledPriceID, err)
+		}
+		scheduledPrices = append(scheduledPrices, scheduled)
+	}
+
+	attributes := make(map[string]domain.AttributeValue, len(attributeModels))
+	for _, am := range attributeModels {
+		attribute, err := attributeFromModel(am)
+		if err != nil {
+			return nil, fmt.Errorf("invalid attribute %s: %w", am.AttributeKey, err)
+		}
+		attributes[am.AttributeKey] = attribute
+	}
 
-		discount, err = domain.NewDiscount(
-			percent,
-			model.DiscountStartDate.Time,
-			model.DiscountEndDate.Time,
-		)
+	variants := make([]*domain.Variant, 0, len(variantModels))
+	for _, vm := range variantModels {
+		variant, err := variantFromModel(vm, basePrice.Currency())
 		if err != nil {
-			return nil, fmt.Errorf("invalid discount: %w", err)
+			return nil, fmt.Errorf("invalid variant %s: %w", vm.VariantID, err)
 		}
+		variants = append(variants, variant)
 	}
 
 	var archivedAt *time.Time
@@ -213,7 +488,10 @@ func (r *ProductRepo) toDomain(model *mproduct.Product) (*domain.Product, error)
 		model.Description,
 		model.Category,
 		basePrice,
-		discount,
+		discounts,
+		scheduledPrices,
+		attributes,
+		variants,
 		domain.ProductStatus(model.Status),
 		archivedAt,
 		model.CreatedAt,
@@ -2,16 +2,27 @@ package repo
 
 import (
 	"context"
-	"encoding/base64"
 	"fmt"
 	"math/big"
+	"strings"
+	"time"
 
 	"cloud.google.com/go/spanner"
 	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
 	"product-catalog-service/internal/app/product/contracts"
+	mproductattribute "product-catalog-service/internal/models/m_product_attribute"
+	mproductdiscount "product-catalog-service/internal/models/m_product_discount"
+	mproductscheduledprice "product-catalog-service/internal/models/m_product_scheduled_price"
+	mproductvariant "product-catalog-service/internal/models/m_product_variant"
 	"product-catalog-service/internal/models/mproduct"
 )
 
+// snapshotRetention is how long Spanner keeps old row versions, the
+// database version_retention_period (one hour by default). Listing
+// snapshots older than this cannot be read.
+const snapshotRetention = time.Hour
+
 // ReadModel implements contracts.ReadModel using Spanner for query-side reads.
 type ReadModel struct {
 	client *spanner.Client
@@ -23,19 +34,13 @@ func NewReadModel(client *spanner.Client) *ReadModel {
 }
 
 // GetProductByID returns a single product by ID or an error if it does not exist.
-func (r *ReadModel) GetProductByID(ctx context.Context, id string) (*contracts.ProductRecord, error) {
-	row, err := r.client.Single().ReadRow(ctx, mproduct.TableName, spanner.Key{id}, []string{
-		mproduct.ProductID,
-		mproduct.Name,
-		mproduct.Description,
-		mproduct.Category,
-		mproduct.BasePriceNumerator,
-		mproduct.BasePriceDenominator,
-		mproduct.DiscountPercent,
-		mproduct.DiscountStartDate,
-		mproduct.DiscountEndDate,
-		mproduct.Status,
-	})
+// Discounts and scheduled prices are only read when pricing is selected,
+// attributes and variants when they are selected.
+func (r *ReadModel) GetProductByID(ctx context.Context, id string, fields contracts.ProductFields) (*contracts.ProductRecord, error) {
+	txn := r.client.ReadOnlyTransaction()
+	defer txn.Close()
+
+	row, err := txn.ReadRow(ctx, mproduct.TableName, spanner.Key{id}, productColumns(fields))
 	if err != nil {
 		if spanner.ErrCode(err) == spanner.ErrCode(spanner.ErrNotFound) {
 			return nil, fmt.Errorf("product not found")
@@ -48,7 +53,114 @@ func (r *ReadModel) GetProductByID(ctx context.Context, id string) (*contracts.P
 		return nil, fmt.Errorf("failed to parse product row: %w", err)
 	}
 
-	return r.toRecord(&model), nil
+	var attributes map[string][]*mproductattribute.ProductAttribute
+	if fields.Has(contracts.ProductFieldAttributes) {
+		if attributes, err = readAttributes(ctx, txn, []string{id}); err != nil {
+			return nil, err
+		}
+	}
+	var variants map[string][]*mproductvariant.ProductVariant
+	if fields.Has(contracts.ProductFieldVariants) {
+		if variants, err = readVariants(ctx, txn, []string{id}); err != nil {
+			return nil, err
+		}
+	}
+
+	if !fields.Has(contracts.ProductFieldPricing) {
+		return r.toRecord(&model, nil, nil, attributes[id], variants[id], fields)
+	}
+	discounts, err := readDiscounts(ctx, txn, []string{id})
+	if err != nil {
+		return nil, err
+	}
+	scheduledPrices, err := readScheduledPrices(ctx, txn, []string{id})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.toRecord(&model, discounts[id], scheduledPrices[id], attributes[id], variants[id], fields)
+}
+
+// GetProductsByIDs returns the products with the given IDs in request order
+// using a single key-set read; missing products are nil.
+// Discounts and scheduled prices are only read when pricing is selected.
+func (r *ReadModel) GetProductsByIDs(ctx context.Context, ids []string, fields contracts.ProductFields) ([]*contracts.ProductRecord, error) {
+	records := make([]*contracts.ProductRecord, len(ids))
+	if len(ids) == 0 {
+		return records, nil
+	}
+
+	txn := r.client.ReadOnlyTransaction()
+	defer txn.Close()
+
+	keys := make([]spanner.Key, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, spanner.Key{id})
+	}
+
+	iter := txn.Read(ctx, mproduct.TableName, spanner.KeySetFromKeys(keys...), productColumns(fields))
+	defer iter.Stop()
+
+	models := make(map[string]*mproduct.Product, len(ids))
+	for {
+		row, err := iter.Next()
+		if err != nil {
+			if err == iterator.Done {
+				break
+			}
+			return nil, err
+		}
+
+		var model mproduct.Product
+		if err := row.ToStruct(&model); err != nil {
+			return nil, fmt.Errorf("failed to parse product row: %w", err)
+		}
+		models[model.ProductID] = &model
+	}
+
+	found := make([]string, 0, len(models))
+	for id := range models {
+		found = append(found, id)
+	}
+	var discounts map[string][]*mproductdiscount.ProductDiscount
+	var scheduledPrices map[string][]*mproductscheduledprice.ProductScheduledPrice
+	if fields.Has(contracts.ProductFieldPricing) && len(models) > 0 {
+		var err error
+		if discounts, err = readDiscounts(ctx, txn, found); err != nil {
+			return nil, err
+		}
+		if scheduledPrices, err = readScheduledPrices(ctx, txn, found); err != nil {
+			return nil, err
+		}
+	}
+	var attributes map[string][]*mproductattribute.ProductAttribute
+	if fields.Has(contracts.ProductFieldAttributes) {
+		var err error
+		if attributes, err = readAttributes(ctx, txn, found); err != nil {
+			return nil, err
+		}
+	}
+	var variants map[string][]*mproductvariant.ProductVariant
+	if fields.Has(contracts.ProductFieldVariants) {
+		var err error
+		if variants, err = readVariants(ctx, txn, found); err != nil {
+			return nil, err
+		}
+	}
+
+	converted := make(map[string]*contracts.ProductRecord, len(models))
+	for id, m := range models {
+		record, err := r.toRecord(m, discounts[id], scheduledPrices[id], attributes[id], variants[id], fields)
+		if err != nil {
+			return nil, err
+		}
+		converted[id] = record
+	}
+	for i, id := range ids {
+		records[i] = converted[id]
+	}
+
+	return records, nil
 }
 
 // ListActiveProducts returns active products, optionally filtered by category,
@@ -58,7 +170,31 @@ func (r *ReadModel) ListActiveProducts(
 	category *string,
 	pageSize int,
 	pageToken string,
+	fields contracts.ProductFields,
 ) ([]*contracts.ProductRecord, string, error) {
+	filter := contracts.ProductFilter{
+		Category: category,
+		Statuses: []string{"active"},
+	}
+	page, err := r.ListProducts(ctx, filter, contracts.ProductOrder{}, pageSize, pageToken, time.Time{}, fields)
+	if err != nil {
+		return nil, "", err
+	}
+	return page.Records, page.NextCursor, nil
+}
+
+// ListProducts returns a page of products matching the filter in the given
+// order using cursor-based pagination on the sort key and product_id.
+// Pages after the first are read at the first page's timestamp.
+func (r *ReadModel) ListProducts(
+	ctx context.Context,
+	filter contracts.ProductFilter,
+	order contracts.ProductOrder,
+	pageSize int,
+	cursor string,
+	readTime time.Time,
+	fields contracts.ProductFields,
+) (*contracts.ProductPage, error) {
 	// Handle pagination defaults
 	if pageSize <= 0 {
 		pageSize = 50 // default
@@ -68,34 +204,42 @@ func (r *ReadModel) ListActiveProducts(
 	}
 	limit := pageSize + 1 // fetch one extra to check for next page
 
-	// Build query with proper WHERE clause
-	sql := `SELECT product_id, name, description, category, 
-	           base_price_numerator, base_price_denominator,
-	           discount_percent, discount_start_date, discount_end_date,
-	           status
-	      FROM products
-	      WHERE status = @status`
-	
-	params := map[string]interface{}{
-		"status": "active",
+	order, err := normalizeOrder(order)
+	if err != nil {
+		return nil, err
+	}
+	// The sort key is selected for the next cursor
+	columns := productColumns(fields)
+	if order.Field != contracts.ProductSortID {
+		columns = append(columns, sortColumns[order.Field]+" AS "+sortKeyColumn)
 	}
 
-	// Add category filter if provided
-	if category != nil && *category != "" {
-		sql += " AND category = @category"
-		params["category"] = *category
+	params := map[string]interface{}{}
+	where, err := whereSQL(filter, params)
+	if err != nil {
+		return nil, err
 	}
+	if order.Field == contracts.ProductSortPrice {
+		setAsOf(filter, params)
+	}
+	sql := `SELECT ` + strings.Join(columns, ", ") + `
+	      FROM products
+	      ` + where
 
 	// Handle cursor-based pagination
-	if pageToken != "" {
-		decoded, err := base64.StdEncoding.DecodeString(pageToken)
-		if err == nil {
-			sql += " AND product_id > @cursor"
-			params["cursor"] = string(decoded)
+	if cursor != "" {
+		position, err := decodeCursor(order, cursor)
+		if err != nil {
+			return nil, err
 		}
+		cond, err := cursorSQL(order, position, params)
+		if err != nil {
+			return nil, err
+		}
+		sql += cond
 	}
 
-	sql += " ORDER BY product_id LIMIT @limit"
+	sql += orderSQL(order) + " LIMIT @limit"
 	params["limit"] = limit
 
 	stmt := spanner.Statement{
@@ -103,11 +247,23 @@ func (r *ReadModel) ListActiveProducts(
 		Params: params,
 	}
 
-	iter := r.client.Single().Query(ctx, stmt)
+	// Products and their discounts are read from the same snapshot; later
+	// pages reuse the snapshot of the first one while Spanner keeps it.
+	txn := r.client.ReadOnlyTransaction()
+	if !readTime.IsZero() {
+		if time.Since(readTime) >= snapshotRetention {
+			return nil, fmt.Errorf("%w: it was taken at %s", contracts.ErrSnapshotExpired, readTime.Format(time.RFC3339))
+		}
+		txn = txn.WithTimestampBound(spanner.ReadTimestamp(readTime))
+	}
+	defer txn.Close()
+
+	iter := txn.Query(ctx, stmt)
 	defer iter.Stop()
 
-	var records []*contracts.ProductRecord
-	var lastID string
+	var models []*mproduct.Product
+	var lastKey string
+	hasMore := false
 
 	for {
 		row, err := iter.Next()
@@ -115,54 +271,190 @@ func (r *ReadModel) ListActiveProducts(
 			if err == iterator.Done {
 				break
 			}
-			return nil, "", err
+			// Spanner rejects reads older than its version GC window
+			if !readTime.IsZero() && spanner.ErrCode(err) == codes.FailedPrecondition {
+				return nil, fmt.Errorf("%w: %v", contracts.ErrSnapshotExpired, err)
+			}
+			return nil, err
+		}
+
+		// Check if we've exceeded page size
+		if len(models) >= pageSize {
+			hasMore = true
+			break
 		}
 
+		// The sort key column has no model field
 		var model mproduct.Product
-		if err := row.ToStruct(&model); err != nil {
-			return nil, "", fmt.Errorf("failed to parse product row: %w", err)
+		if err := row.ToStructLenient(&model); err != nil {
+			return nil, fmt.Errorf("failed to parse product row: %w", err)
+		}
+		if lastKey, err = readSortKey(order, row); err != nil {
+			return nil, fmt.Errorf("failed to read sort key of product %s: %w", model.ProductID, err)
 		}
 
-		// Check if we've exceeded page size
-		if len(records) >= pageSize {
-			lastID = model.ProductID
-			break
+		models = append(models, &model)
+	}
+
+	ids := make([]string, 0, len(models))
+	for _, m := range models {
+		ids = append(ids, m.ProductID)
+	}
+	var discounts map[string][]*mproductdiscount.ProductDiscount
+	var scheduledPrices map[string][]*mproductscheduledprice.ProductScheduledPrice
+	if fields.Has(contracts.ProductFieldPricing) {
+		var err error
+		if discounts, err = readDiscounts(ctx, txn, ids); err != nil {
+			return nil, err
+		}
+		if scheduledPrices, err = readScheduledPrices(ctx, txn, ids); err != nil {
+			return nil, err
+		}
+	}
+	var attributes map[string][]*mproductattribute.ProductAttribute
+	if fields.Has(contracts.ProductFieldAttributes) {
+		var err error
+		if attributes, err = readAttributes(ctx, txn, ids); err != nil {
+			return nil, err
+		}
+	}
+	var variants map[string][]*mproductvariant.ProductVariant
+	if fields.Has(contracts.ProductFieldVariants) {
+		var err error
+		if variants, err = readVariants(ctx, txn, ids); err != nil {
+			return nil, err
+		}
+	}
+
+	records := make([]*contracts.ProductRecord, 0, len(models))
+	for _, m := range models {
+		record, err := r.toRecord(m, discounts[m.ProductID], scheduledPrices[m.ProductID], attributes[m.ProductID],
+			variants[m.ProductID], fields)
+		if err != nil {
+			return nil, err
 		}
+		records = append(records, record)
+	}
 
-		records = append(records, r.toRecord(&model))
+	page := &contracts.ProductPage{Records: records}
+	if page.ReadTime, err = txn.Timestamp(); err != nil {
+		return nil, err
 	}
 
-	// Generate next page token if there are more results
-	nextToken := ""
-	if lastID != "" {
-		nextToken = base64.StdEncoding.EncodeToString([]byte(lastID))
+	// Generate next cursor if there are more results
+	if hasMore {
+		if page.NextCursor, err = encodeCursor(order, models[len(models)-1].ProductID, lastKey); err != nil {
+			return nil, err
+		}
 	}
 
-	return records, nextToken, nil
+	return page, nil
 }
 
-// toRecord converts a database model to a ProductRecord.
-func (r *ReadModel) toRecord(model *mproduct.Product) *contracts.ProductRecord {
+// productColumns returns the products table columns holding the selected fields.
+func productColumns(fields contracts.ProductFields) []string {
+	columns := []string{mproduct.ProductID}
+	if fields.Has(contracts.ProductFieldName) {
+		columns = append(columns, mproduct.Name)
+	}
+	if fields.Has(contracts.ProductFieldDescription) {
+		columns = append(columns, mproduct.Description)
+	}
+	if fields.Has(contracts.ProductFieldCategory) {
+		columns = append(columns, mproduct.Category)
+	}
+	if fields.Has(contracts.ProductFieldStatus) {
+		columns = append(columns, mproduct.Status)
+	}
+	if fields.Has(contracts.ProductFieldPricing) {
+		columns = append(columns,
+			mproduct.BasePrice,
+			mproduct.BasePriceNumerator,
+			mproduct.BasePriceDenominator,
+			mproduct.Currency,
+		)
+	}
+	if fields.Has(contracts.ProductFieldTimestamps) {
+		columns = append(columns, mproduct.CreatedAt, mproduct.UpdatedAt, mproduct.ArchivedAt)
+	}
+	return columns
+}
+
+// toRecord converts a database model and its child rows to a ProductRecord.
+// Fields that were not read are left empty.
+func (r *ReadModel) toRecord(
+	model *mproduct.Product,
+	discountModels []*mproductdiscount.ProductDiscount,
+	scheduledPriceModels []*mproductscheduledprice.ProductScheduledPrice,
+	attributeModels []*mproductattribute.ProductAttribute,
+	variantModels []*mproductvariant.ProductVariant,
+	fields contracts.ProductFields,
+) (*contracts.ProductRecord, error) {
 	record := &contracts.ProductRecord{
-		ProductID:            model.ProductID,
-		Name:                 model.Name,
-		Description:          model.Description,
-		Category:             model.Category,
-		BasePriceNumerator:   model.BasePriceNumerator,
-		BasePriceDenominator: model.BasePriceDenominator,
-		Status:               model.Status,
+		ProductID:   model.ProductID,
+		Name:        model.Name,
+		Description: model.Description,
+		Category:    model.Category,
+		Status:      model.Status,
+		CreatedAt:   model.CreatedAt,
+		UpdatedAt:   model.UpdatedAt,
+	}
+	if model.ArchivedAt.Valid {
+		archivedAt := model.ArchivedAt.Time
+		record.ArchivedAt = &archivedAt
+	}
+	if fields.Has(contracts.ProductFieldAttributes) {
+		record.Attributes = make(map[string]contracts.AttributeRecord, len(attributeModels))
+		for _, am := range attributeModels {
+			record.Attributes[am.AttributeKey] = attributeToRecord(am)
+		}
+	}
+	for _, vm := range variantModels {
+		variant, err := variantToRecord(vm)
+		if err != nil {
+			return nil, fmt.Errorf("product %s: %w", model.ProductID, err)
+		}
+		record.Variants = append(record.Variants, variant)
+	}
+
+	if !fields.Has(contracts.ProductFieldPricing) {
+		return record, nil
 	}
 
-	if model.DiscountPercent.Valid && model.DiscountStartDate.Valid && model.DiscountEndDate.Valid {
-		// Parse NUMERIC string to big.Rat
-		percentStr := string(model.DiscountPercent.Numeric)
-		percent := new(big.Rat)
-		if _, ok := percent.SetString(percentStr); ok {
-			record.DiscountPercent = percent
-			record.DiscountStart = &model.DiscountStartDate.Time
-			record.DiscountEnd = &model.DiscountEndDate.Time
+	basePrice, err := basePriceFromModel(model)
+	if err != nil {
+		return nil, fmt.Errorf("product %s: %w", model.ProductID, err)
+	}
+	record.BasePrice = basePrice.Rat()
+	record.Currency = model.Currency
+
+	for _, dm := range discountModels {
+		discount := contracts.DiscountRecord{
+			DiscountID: dm.DiscountID,
+			Kind:       dm.Kind,
+			Start:      dm.StartDate,
+			End:        dm.EndDate,
+			Priority:   int(dm.Priority),
+			Exclusive:  dm.Exclusive,
 		}
+		if dm.Percentage.Valid {
+			discount.Percent = new(big.Rat).Set(&dm.Percentage.Numeric)
+		}
+		amount, err := amountFromModel(dm)
+		if err != nil {
+			return nil, fmt.Errorf("discount %s: %w", dm.DiscountID, err)
+		}
+		discount.Amount = amount.Rat()
+		record.Discounts = append(record.Discounts, discount)
+	}
+
+	for _, sm := range scheduledPriceModels {
+		record.ScheduledPrices = append(record.ScheduledPrices, contracts.ScheduledPriceRecord{
+			ScheduledPriceID: sm.ScheduledPriceID,
+			Price:            new(big.Rat).Set(&sm.Price),
+			EffectiveFrom:    sm.EffectiveFrom,
+		})
 	}
 
-	return record
+	return record, nil
 }
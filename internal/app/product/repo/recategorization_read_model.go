@@ -0,0 +1,32 @@
+package repo
+
+import (
+	"context"
+
+	"product-catalog-service/internal/app/product/contracts"
+)
+
+// GetRecategorization returns a recategorization job by ID.
+func (r *ReadModel) GetRecategorization(ctx context.Context, id string) (*contracts.RecategorizationRecord, error) {
+	model, err := readRecategorizationJob(ctx, r.client, id)
+	if err != nil {
+		return nil, err
+	}
+
+	record := &contracts.RecategorizationRecord{
+		JobID:         model.JobID,
+		FromCategory:  model.FromCategory,
+		ToCategory:    model.ToCategory,
+		Status:        model.Status,
+		Batches:       model.Batches,
+		MovedProducts: model.MovedProducts,
+		Failure:       model.Failure.StringVal,
+		CreatedAt:     model.CreatedAt,
+		UpdatedAt:     model.UpdatedAt,
+	}
+	if model.FinishedAt.Valid {
+		finishedAt := model.FinishedAt.Time
+		record.FinishedAt = &finishedAt
+	}
+	return record, nil
+}
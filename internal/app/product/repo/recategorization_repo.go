@@ -0,0 +1,173 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
+	"product-catalog-service/internal/app/product/contracts"
+	"product-catalog-service/internal/app/product/domain"
+	mrecategorizationjob "product-catalog-service/internal/models/m_recategorization_job"
+)
+
+// RecategorizationRepo implements contracts.RecategorizationRepo using Spanner.
+type RecategorizationRepo struct {
+	client *spanner.Client
+}
+
+var _ contracts.RecategorizationRepo = (*RecategorizationRepo)(nil)
+
+// NewRecategorizationRepo creates a new RecategorizationRepo with the given Spanner client.
+func NewRecategorizationRepo(client *spanner.Client) *RecategorizationRepo {
+	return &RecategorizationRepo{client: client}
+}
+
+// InsertMut returns a mutation to insert a new job.
+// Returns nil if job is nil.
+func (r *RecategorizationRepo) InsertMut(job *domain.Recategorization) *spanner.Mutation {
+	if job == nil {
+		return nil
+	}
+
+	model := &mrecategorizationjob.RecategorizationJob{
+		JobID:         job.ID(),
+		FromCategory:  job.FromCategory(),
+		ToCategory:    job.ToCategory(),
+		Status:        string(job.Status()),
+		Batches:       job.Batches(),
+		MovedProducts: job.MovedProducts(),
+		CreatedAt:     job.CreatedAt(),
+		UpdatedAt:     job.UpdatedAt(),
+	}
+	if finishedAt := job.FinishedAt(); finishedAt != nil {
+		model.FinishedAt = spanner.NullTime{Time: *finishedAt, Valid: true}
+	}
+	if failure := job.Failure(); failure != "" {
+		model.Failure = spanner.NullString{StringVal: failure, Valid: true}
+	}
+
+	return mrecategorizationjob.InsertMut(model)
+}
+
+// UpdateMut returns a mutation to update changed fields of a job.
+// Returns nil if no changes are dirty.
+func (r *RecategorizationRepo) UpdateMut(job *domain.Recategorization) *spanner.Mutation {
+	if job == nil {
+		return nil
+	}
+
+	updates := make(map[string]interface{})
+
+	if job.Changes().Dirty(domain.FieldProgress) {
+		updates[mrecategorizationjob.Batches] = job.Batches()
+		updates[mrecategorizationjob.MovedProducts] = job.MovedProducts()
+	}
+	if job.Changes().Dirty(domain.FieldStatus) {
+		updates[mrecategorizationjob.Status] = string(job.Status())
+		finishedAt := spanner.NullTime{}
+		if job.FinishedAt() != nil {
+			finishedAt = spanner.NullTime{Time: *job.FinishedAt(), Valid: true}
+		}
+		updates[mrecategorizationjob.FinishedAt] = finishedAt
+		failure := spanner.NullString{}
+		if job.Failure() != "" {
+			failure = spanner.NullString{StringVal: job.Failure(), Valid: true}
+		}
+		updates[mrecategorizationjob.Failure] = failure
+	}
+
+	if len(updates) == 0 {
+		return nil
+	}
+
+	updates[mrecategorizationjob.UpdatedAt] = job.UpdatedAt()
+	return mrecategorizationjob.UpdateMut(job.ID(), updates)
+}
+
+// BatchMut returns a mutation inserting the checkpoint of the job's latest
+// batch. The insert fails if the checkpoint exists.
+func (r *RecategorizationRepo) BatchMut(job *domain.Recategorization, movedProducts int) *spanner.Mutation {
+	if job == nil || job.Batches() == 0 {
+		return nil
+	}
+	return mrecategorizationjob.InsertBatchMut(job.ID(), job.Batches(), int64(movedProducts), job.UpdatedAt())
+}
+
+// FindByID loads a job by ID.
+func (r *RecategorizationRepo) FindByID(ctx context.Context, id string) (*domain.Recategorization, error) {
+	model, err := readRecategorizationJob(ctx, r.client, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var finishedAt *time.Time
+	if model.FinishedAt.Valid {
+		finishedAt = &model.FinishedAt.Time
+	}
+	return domain.RehydrateRecategorization(
+		model.JobID,
+		model.FromCategory,
+		model.ToCategory,
+		domain.RecategorizationStatus(model.Status),
+		model.Batches,
+		model.MovedProducts,
+		model.Failure.StringVal,
+		model.CreatedAt,
+		model.UpdatedAt,
+		finishedAt,
+	), nil
+}
+
+// FindIDsByStatus returns the IDs of the jobs in the given status, oldest
+// first.
+func (r *RecategorizationRepo) FindIDsByStatus(ctx context.Context, status domain.RecategorizationStatus) ([]string, error) {
+	stmt := spanner.Statement{
+		SQL: `SELECT job_id FROM recategorization_jobs
+		      WHERE status = @status
+		      ORDER BY created_at, job_id`,
+		Params: map[string]interface{}{
+			"status": string(status),
+		},
+	}
+
+	iter := r.client.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	var ids []string
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var id string
+		if err := row.Column(0, &id); err != nil {
+			return nil, fmt.Errorf("failed to parse job id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+// readRecategorizationJob reads a job row.
+func readRecategorizationJob(ctx context.Context, client *spanner.Client, id string) (*mrecategorizationjob.RecategorizationJob, error) {
+	row, err := client.Single().ReadRow(ctx, mrecategorizationjob.TableName, spanner.Key{id}, mrecategorizationjob.Columns)
+	if err != nil {
+		if spanner.ErrCode(err) == spanner.ErrCode(spanner.ErrNotFound) {
+			return nil, domain.ErrRecategorizationNotFound
+		}
+		return nil, err
+	}
+
+	var model mrecategorizationjob.RecategorizationJob
+	if err := row.ToStruct(&model); err != nil {
+		return nil, fmt.Errorf("failed to parse recategorization job row: %w", err)
+	}
+	return &model, nil
+}
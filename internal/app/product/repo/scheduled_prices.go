@@ -0,0 +1,69 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
+	"product-catalog-service/internal/app/product/domain"
+	mproductscheduledprice "product-catalog-service/internal/models/m_product_scheduled_price"
+)
+
+// readScheduledPrices loads the scheduled prices of the given products
+// grouped by product ID.
+func readScheduledPrices(
+	ctx context.Context,
+	reader rowReader,
+	productIDs []string,
+) (map[string][]*mproductscheduledprice.ProductScheduledPrice, error) {
+	out := make(map[string][]*mproductscheduledprice.ProductScheduledPrice, len(productIDs))
+	if len(productIDs) == 0 {
+		return out, nil
+	}
+
+	keys := make([]spanner.KeySet, 0, len(productIDs))
+	for _, id := range productIDs {
+		keys = append(keys, spanner.Key{id}.AsPrefix())
+	}
+
+	iter := reader.Read(ctx, mproductscheduledprice.TableName, spanner.KeySets(keys...), mproductscheduledprice.Columns())
+	defer iter.Stop()
+
+	for {
+		row, err := iter.Next()
+		if err != nil {
+			if err == iterator.Done {
+				break
+			}
+			return nil, err
+		}
+
+		var model mproductscheduledprice.ProductScheduledPrice
+		if err := row.ToStruct(&model); err != nil {
+			return nil, fmt.Errorf("failed to parse scheduled price row: %w", err)
+		}
+		out[model.ProductID] = append(out[model.ProductID], &model)
+	}
+
+	return out, nil
+}
+
+// scheduledPriceToModel converts a domain scheduled price to its storage row.
+func scheduledPriceToModel(productID string, s *domain.ScheduledPrice) *mproductscheduledprice.ProductScheduledPrice {
+	return &mproductscheduledprice.ProductScheduledPrice{
+		ProductID:        productID,
+		ScheduledPriceID: s.ID(),
+		Price:            *s.Price().Rat(),
+		EffectiveFrom:    s.EffectiveFrom(),
+	}
+}
+
+// scheduledPriceFromModel converts a storage row to a domain scheduled price.
+func scheduledPriceFromModel(model *mproductscheduledprice.ProductScheduledPrice) (*domain.ScheduledPrice, error) {
+	return domain.NewScheduledPrice(
+		model.ScheduledPriceID,
+		domain.NewMoneyFromRat(&model.Price),
+		model.EffectiveFrom,
+	)
+}
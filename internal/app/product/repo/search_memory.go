@@ -0,0 +1,80 @@
+package repo
+
+import (
+	"context"
+	"sync"
+
+	"cloud.google.com/go/spanner"
+	"product-catalog-service/internal/app/product/contracts"
+	"product-catalog-service/internal/models/mproduct"
+	"product-catalog-service/internal/pkg/search"
+)
+
+// searchWeights boosts name matches above category and description ones.
+var searchWeights = map[string]float64{
+	mproduct.Name:        3,
+	mproduct.Category:    2,
+	mproduct.Description: 1,
+}
+
+// MemorySearch implements contracts.ProductSearcher with an embedded
+// in-memory index, for the emulator and tests. Load fills it with the
+// searchable products; Sync then follows changes through the outbox.
+type MemorySearch struct {
+	index *search.Index
+
+	mu   sync.Mutex
+	feed *productFeed
+}
+
+var _ contracts.ProductSearcher = (*MemorySearch)(nil)
+
+// NewMemorySearch creates an empty MemorySearch with the given Spanner client.
+func NewMemorySearch(client *spanner.Client) *MemorySearch {
+	return &MemorySearch{
+		index: search.NewIndex(searchWeights),
+		feed:  newProductFeed(client),
+	}
+}
+
+// SearchProducts implements contracts.ProductSearcher.
+func (s *MemorySearch) SearchProducts(ctx context.Context, query string, offset, limit int) ([]contracts.SearchHit, bool, error) {
+	terms := search.Terms(query)
+	if len(terms) == 0 {
+		return nil, false, nil
+	}
+
+	found, more := s.index.Search(terms, offset, limit)
+	hits := make([]contracts.SearchHit, 0, len(found))
+	for _, h := range found {
+		hits = append(hits, contracts.SearchHit{ProductID: h.ID, Score: h.Score})
+	}
+	return hits, more, nil
+}
+
+// Load indexes every active, unarchived product.
+func (s *MemorySearch) Load(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.feed.load(ctx, s.apply)
+}
+
+// Sync re-indexes the products changed since the last sync. Products that
+// are no longer active or were archived are removed from the index.
+func (s *MemorySearch) Sync(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.feed.sync(ctx, s.apply)
+}
+
+func (s *MemorySearch) apply(id string, record *contracts.ProductRecord) {
+	if record == nil {
+		s.index.Delete(id)
+		return
+	}
+	s.index.Upsert(id, map[string]string{
+		mproduct.Name:        record.Name,
+		mproduct.Description: record.Description,
+		mproduct.Category:    record.Category,
+	})
+}
@@ -0,0 +1,81 @@
+package repo
+
+import (
+	"context"
+	"strings"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
+	"product-catalog-service/internal/app/product/contracts"
+	"product-catalog-service/internal/pkg/search"
+)
+
+// SpannerSearch implements contracts.ProductSearcher with the Spanner
+// search index on products (see 012_product_search.sql). Spanner tokenizes
+// and scores; enhance_query adds stemming and synonyms.
+type SpannerSearch struct {
+	client *spanner.Client
+}
+
+var _ contracts.ProductSearcher = (*SpannerSearch)(nil)
+
+// NewSpannerSearch creates a SpannerSearch with the given Spanner client.
+func NewSpannerSearch(client *spanner.Client) *SpannerSearch {
+	return &SpannerSearch{client: client}
+}
+
+// searchSQL scores name matches above category and description matches,
+// like the embedded index weights.
+const searchSQL = `SELECT product_id,
+	  SCORE(name_tokens, @query, enhance_query => TRUE) * 3 +
+	  SCORE(category_tokens, @query, enhance_query => TRUE) * 2 +
+	  SCORE(description_tokens, @query, enhance_query => TRUE) AS score
+	FROM products
+	WHERE status = 'active' AND archived_at IS NULL
+	  AND SEARCH(search_tokens, @query, enhance_query => TRUE)
+	ORDER BY score DESC, product_id
+	LIMIT @limit OFFSET @offset`
+
+// SearchProducts implements contracts.ProductSearcher.
+func (s *SpannerSearch) SearchProducts(ctx context.Context, query string, offset, limit int) ([]contracts.SearchHit, bool, error) {
+	words := search.Words(query)
+	if len(words) == 0 {
+		return nil, false, nil
+	}
+
+	stmt := spanner.Statement{
+		SQL: searchSQL,
+		Params: map[string]interface{}{
+			// Any word matches; quoting keeps words from being read as
+			// search operators.
+			"query":  `"` + strings.Join(words, `" OR "`) + `"`,
+			"limit":  limit + 1, // fetch one extra to check for more hits
+			"offset": offset,
+		},
+	}
+
+	iter := s.client.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	var hits []contracts.SearchHit
+	for {
+		row, err := iter.Next()
+		if err != nil {
+			if err == iterator.Done {
+				break
+			}
+			return nil, false, err
+		}
+
+		var hit contracts.SearchHit
+		if err := row.Columns(&hit.ProductID, &hit.Score); err != nil {
+			return nil, false, err
+		}
+		hits = append(hits, hit)
+	}
+
+	if len(hits) > limit {
+		return hits[:limit], true, nil
+	}
+	return hits, false, nil
+}
@@ -0,0 +1,72 @@
+package repo
+
+import (
+	"context"
+	"sync"
+
+	"cloud.google.com/go/spanner"
+	"product-catalog-service/internal/app/product/contracts"
+	"product-catalog-service/internal/pkg/search"
+)
+
+// MemorySuggest implements contracts.ProductSuggester with an in-memory
+// prefix index of product names and categories. Load fills it with the
+// active products; Sync then follows changes through the outbox, so
+// suggestions never touch Spanner.
+type MemorySuggest struct {
+	suggester *search.Suggester
+
+	mu   sync.Mutex
+	feed *productFeed
+}
+
+var _ contracts.ProductSuggester = (*MemorySuggest)(nil)
+
+// NewMemorySuggest creates an empty MemorySuggest with the given Spanner client.
+func NewMemorySuggest(client *spanner.Client) *MemorySuggest {
+	return &MemorySuggest{
+		suggester: search.NewSuggester(),
+		feed:      newProductFeed(client),
+	}
+}
+
+// SuggestProducts implements contracts.ProductSuggester.
+func (s *MemorySuggest) SuggestProducts(ctx context.Context, prefix string, limit int) ([]contracts.Suggestion, error) {
+	found := s.suggester.Suggest(prefix, limit)
+	out := make([]contracts.Suggestion, 0, len(found))
+	for _, sg := range found {
+		out = append(out, contracts.Suggestion{
+			Text:         sg.Text,
+			Kind:         contracts.SuggestionKind(sg.Kind),
+			ProductCount: sg.Count,
+			Typos:        sg.Typos,
+		})
+	}
+	return out, nil
+}
+
+// Load indexes the names and categories of every active, unarchived product.
+func (s *MemorySuggest) Load(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.feed.load(ctx, s.apply)
+}
+
+// Sync re-indexes the products changed since the last sync. Products that
+// are no longer active or were archived are removed from the index.
+func (s *MemorySuggest) Sync(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.feed.sync(ctx, s.apply)
+}
+
+func (s *MemorySuggest) apply(id string, record *contracts.ProductRecord) {
+	if record == nil {
+		s.suggester.Delete(id)
+		return
+	}
+	s.suggester.Set(id, map[string]string{
+		string(contracts.SuggestionName):     record.Name,
+		string(contracts.SuggestionCategory): record.Category,
+	})
+}
@@ -0,0 +1,63 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/spanner"
+	"product-catalog-service/internal/app/product/contracts"
+	msweepcursor "product-catalog-service/internal/models/m_sweep_cursor"
+)
+
+// SweepCursorRepo implements contracts.SweepCursorRepo using Spanner.
+type SweepCursorRepo struct {
+	client *spanner.Client
+}
+
+var _ contracts.SweepCursorRepo = (*SweepCursorRepo)(nil)
+
+// NewSweepCursorRepo creates a new SweepCursorRepo with the given Spanner client.
+func NewSweepCursorRepo(client *spanner.Client) *SweepCursorRepo {
+	return &SweepCursorRepo{client: client}
+}
+
+// SaveMut returns a mutation to store a cursor.
+// Returns nil if cursor is nil.
+func (r *SweepCursorRepo) SaveMut(c *contracts.SweepCursor) *spanner.Mutation {
+	if c == nil {
+		return nil
+	}
+
+	return msweepcursor.SaveMut(&msweepcursor.SweepCursor{
+		Name:              c.Name,
+		PositionAt:        c.Position.At,
+		PositionProductID: c.Position.ProductID,
+		UpdatedAt:         c.UpdatedAt,
+	})
+}
+
+// FindByName loads a cursor by name.
+// Returns nil and no error if the sweep never committed a position.
+func (r *SweepCursorRepo) FindByName(ctx context.Context, name string) (*contracts.SweepCursor, error) {
+	row, err := r.client.Single().ReadRow(ctx, msweepcursor.TableName, spanner.Key{name}, msweepcursor.Columns)
+	if err != nil {
+		if spanner.ErrCode(err) == spanner.ErrCode(spanner.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var model msweepcursor.SweepCursor
+	if err := row.ToStruct(&model); err != nil {
+		return nil, fmt.Errorf("failed to parse sweep cursor row: %w", err)
+	}
+
+	return &contracts.SweepCursor{
+		Name: model.Name,
+		Position: contracts.PriceTransition{
+			ProductID: model.PositionProductID,
+			At:        model.PositionAt,
+		},
+		UpdatedAt: model.UpdatedAt,
+	}, nil
+}
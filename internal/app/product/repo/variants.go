@@ -0,0 +1,119 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
+	"product-catalog-service/internal/app/product/contracts"
+	"product-catalog-service/internal/app/product/domain"
+	mproductvariant "product-catalog-service/internal/models/m_product_variant"
+)
+
+// readVariants loads the variants of the given products grouped by product
+// ID, in variant ID order.
+func readVariants(
+	ctx context.Context,
+	reader rowReader,
+	productIDs []string,
+) (map[string][]*mproductvariant.ProductVariant, error) {
+	out := make(map[string][]*mproductvariant.ProductVariant, len(productIDs))
+	if len(productIDs) == 0 {
+		return out, nil
+	}
+
+	keys := make([]spanner.KeySet, 0, len(productIDs))
+	for _, id := range productIDs {
+		keys = append(keys, spanner.Key{id}.AsPrefix())
+	}
+
+	iter := reader.Read(ctx, mproductvariant.TableName, spanner.KeySets(keys...), mproductvariant.Columns())
+	defer iter.Stop()
+
+	for {
+		row, err := iter.Next()
+		if err != nil {
+			if err == iterator.Done {
+				break
+			}
+			return nil, err
+		}
+
+		var model mproductvariant.ProductVariant
+		if err := row.ToStruct(&model); err != nil {
+			return nil, fmt.Errorf("failed to parse variant row: %w", err)
+		}
+		out[model.ProductID] = append(out[model.ProductID], &model)
+	}
+
+	return out, nil
+}
+
+// variantToModel converts a domain variant to its storage row.
+func variantToModel(productID string, v *domain.Variant) *mproductvariant.ProductVariant {
+	model := &mproductvariant.ProductVariant{
+		ProductID: productID,
+		VariantID: v.ID(),
+		SKU:       v.SKU(),
+		Options:   spanner.NullJSON{Value: v.Options(), Valid: true},
+		Status:    string(v.Status()),
+	}
+	if price := v.PriceOverride(); price != nil {
+		model.PriceOverride = spanner.NullNumeric{Numeric: *price.Rat(), Valid: true}
+	}
+	return model
+}
+
+// variantFromModel converts a storage row to a domain variant priced in the
+// given currency.
+func variantFromModel(model *mproductvariant.ProductVariant, currency domain.Currency) (*domain.Variant, error) {
+	options, err := optionsFromJSON(model.Options)
+	if err != nil {
+		return nil, err
+	}
+	var price *domain.Money
+	if model.PriceOverride.Valid {
+		price = domain.NewMoneyFromRat(&model.PriceOverride.Numeric).WithCurrency(currency)
+	}
+	return domain.NewVariant(model.VariantID, model.SKU, options, price, domain.VariantStatus(model.Status))
+}
+
+// variantToRecord converts a storage row to its read-model form.
+func variantToRecord(model *mproductvariant.ProductVariant) (contracts.VariantRecord, error) {
+	options, err := optionsFromJSON(model.Options)
+	if err != nil {
+		return contracts.VariantRecord{}, fmt.Errorf("variant %s: %w", model.VariantID, err)
+	}
+	record := contracts.VariantRecord{
+		VariantID: model.VariantID,
+		SKU:       model.SKU,
+		Options:   options,
+		Status:    model.Status,
+	}
+	if model.PriceOverride.Valid {
+		record.PriceOverride = new(big.Rat).Set(&model.PriceOverride.Numeric)
+	}
+	return record, nil
+}
+
+// optionsFromJSON decodes the options column, a JSON object of strings.
+func optionsFromJSON(j spanner.NullJSON) (map[string]string, error) {
+	if !j.Valid {
+		return nil, fmt.Errorf("variant has no options")
+	}
+	obj, ok := j.Value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("variant options are not a JSON object")
+	}
+	options := make(map[string]string, len(obj))
+	for name, value := range obj {
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("variant option %q is not a string", name)
+		}
+		options[name] = s
+	}
+	return options, nil
+}
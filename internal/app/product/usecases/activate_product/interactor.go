@@ -3,10 +3,10 @@ package activateproduct
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
-	"github.com/Vektor-AI/commitplan"
 	"product-catalog-service/internal/app/product/contracts"
 	"product-catalog-service/internal/app/product/domain"
 	"product-catalog-service/internal/pkg/clock"
@@ -21,6 +21,7 @@ type Request struct {
 // Interactor implements the ActivateProduct usecase following the Golden Mutation Pattern.
 type Interactor struct {
 	repo      contracts.ProductRepo
+	categories contracts.CategoryRepo
 	outboxRepo contracts.OutboxRepo
 	committer *committer.PlanCommitter
 	clock     clock.Clock
@@ -29,12 +30,14 @@ type Interactor struct {
 // New creates a new ActivateProduct interactor.
 func New(
 	repo contracts.ProductRepo,
+	categories contracts.CategoryRepo,
 	outboxRepo contracts.OutboxRepo,
 	committer *committer.PlanCommitter,
 	clock clock.Clock,
 ) *Interactor {
 	return &Interactor{
 		repo:      repo,
+		categories: categories,
 		outboxRepo: outboxRepo,
 		committer: committer,
 		clock:     clock,
@@ -49,12 +52,24 @@ func (it *Interactor) Execute(ctx context.Context, req Request) error {
 		return fmt.Errorf("product not found: %w", err)
 	}
 
-	// 2. Call domain method
+	// 2. Check the product against its category's schema, then call
+	// domain method
+	activating := product.Status() == domain.ProductStatusInactive
+	if activating {
+		if err := it.validateAttributes(ctx, product); err != nil {
+			return err
+		}
+	}
+
 	now := it.clock.Now()
 	product.Activate(now)
 
-	// 3. Build commit plan
-	plan := commitplan.NewPlan()
+	// 3. Build commit plan, re-checking the category schema in the same
+	// transaction so that a concurrent schema change cannot slip in
+	plan := committer.NewCheckedPlan()
+	if activating && product.Category() != "" {
+		plan.Check(it.attributesCheck(product))
+	}
 
 	// 4. Get mutations from repository
 	if mut := it.repo.UpdateMut(product); mut != nil {
@@ -70,7 +85,7 @@ func (it *Interactor) Execute(ctx context.Context, req Request) error {
 	}
 
 	// 6. Apply plan
-	if err := it.committer.Apply(ctx, plan); err != nil {
+	if err := it.committer.ApplyChecked(ctx, plan); err != nil {
 		return err
 	}
 
@@ -78,6 +93,33 @@ func (it *Interactor) Execute(ctx context.Context, req Request) error {
 	return nil
 }
 
+// validateAttributes checks the product's attributes against its
+// category's schema. Legacy categories without a category record have no
+// schema.
+func (it *Interactor) validateAttributes(ctx context.Context, product *domain.Product) error {
+	if product.Category() == "" {
+		return nil
+	}
+	category, err := it.categories.FindBySlug(ctx, product.Category())
+	if err != nil {
+		if errors.Is(err, domain.ErrCategoryNotFound) {
+			return nil
+		}
+		return err
+	}
+	return category.ValidateAttributes(product.Attributes())
+}
+
+// attributesCheck validates the product's attributes against its category
+// as read in the committing transaction. Like validateAttributes, it
+// accepts a legacy category without a category record.
+func (it *Interactor) attributesCheck(product *domain.Product) committer.Check {
+	check := it.categories.SlugCheck(product.Category(), func(c *domain.Category) error {
+		return c.ValidateAttributes(product.Attributes())
+	})
+	return committer.Unless(check, domain.ErrCategoryNotFound)
+}
+
 func enrichEvent(aggregateID string, event domain.DomainEvent) *contracts.EnrichedEvent {
 	payload, _ := json.Marshal(event)
 	return &contracts.EnrichedEvent{
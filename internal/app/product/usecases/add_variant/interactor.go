@@ -0,0 +1,144 @@
+package addvariant
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"product-catalog-service/internal/app/product/contracts"
+	"product-catalog-service/internal/app/product/domain"
+	"product-catalog-service/internal/pkg/clock"
+	"product-catalog-service/internal/pkg/committer"
+)
+
+// Request represents input for adding a variant to a product.
+type Request struct {
+	ProductID string
+	// VariantID identifies the variant within the product.
+	// If empty, a new ID is generated.
+	VariantID string
+	SKU       string
+	// Options maps option names to values, e.g. {"size": "M"}.
+	Options map[string]string
+	// PriceOverride is the variant price as a decimal string, e.g. "24.99".
+	// Empty means the variant sells at the product price.
+	PriceOverride string
+	// Status is empty for an active variant.
+	Status domain.VariantStatus
+}
+
+// Interactor implements the AddVariant usecase following the Golden Mutation Pattern.
+type Interactor struct {
+	repo       contracts.ProductRepo
+	outboxRepo contracts.OutboxRepo
+	committer  *committer.PlanCommitter
+	clock      clock.Clock
+}
+
+// New creates a new AddVariant interactor.
+func New(
+	repo contracts.ProductRepo,
+	outboxRepo contracts.OutboxRepo,
+	committer *committer.PlanCommitter,
+	clock clock.Clock,
+) *Interactor {
+	return &Interactor{
+		repo:       repo,
+		outboxRepo: outboxRepo,
+		committer:  committer,
+		clock:      clock,
+	}
+}
+
+// Execute adds a variant to a product and returns its ID.
+// The SKU must not be used by any other variant of any product.
+func (it *Interactor) Execute(ctx context.Context, req Request) (string, error) {
+	// 1. Load aggregate
+	product, err := it.repo.FindByID(ctx, req.ProductID)
+	if err != nil {
+		return "", fmt.Errorf("product not found: %w", err)
+	}
+
+	// 2. Create variant entity (validates SKU, options and price override)
+	variantID := req.VariantID
+	if variantID == "" {
+		variantID = generateID()
+	}
+	var priceOverride *domain.Money
+	if req.PriceOverride != "" {
+		if priceOverride, err = domain.NewMoneyFromString(req.PriceOverride); err != nil {
+			return "", fmt.Errorf("%w: price override: %v", domain.ErrInvalidVariant, err)
+		}
+	}
+	variant, err := domain.NewVariant(variantID, req.SKU, req.Options, priceOverride, req.Status)
+	if err != nil {
+		return "", err
+	}
+
+	// 3. Check the SKU across products; the unique SKU index backs the check
+	if _, _, err := it.repo.FindVariantBySKU(ctx, variant.SKU()); err == nil {
+		return "", fmt.Errorf("%w: %q", domain.ErrSKUTaken, variant.SKU())
+	} else if !errors.Is(err, domain.ErrVariantNotFound) {
+		return "", err
+	}
+
+	// 4. Call domain method (validates product is not archived and variant is unique)
+	if err := product.AddVariant(variant, it.clock.Now()); err != nil {
+		return "", err
+	}
+
+	// 5. Build commit plan, re-checking the SKU in the same transaction so
+	// that a concurrent add of the same SKU fails with ErrSKUTaken
+	plan := committer.NewCheckedPlan()
+	plan.Check(it.repo.SKUCheck(variant.SKU(), product.ID()))
+
+	// 6. Get mutations from repository
+	if mut := it.repo.UpdateMut(product); mut != nil {
+		plan.Add(mut)
+	}
+	for _, mut := range it.repo.VariantMuts(product) {
+		plan.Add(mut)
+	}
+
+	// 7. Add outbox events
+	for _, event := range product.DomainEvents() {
+		enriched := enrichEvent(product.ID(), event)
+		if outboxMut := it.outboxRepo.InsertMut(enriched); outboxMut != nil {
+			plan.Add(outboxMut)
+		}
+	}
+
+	// 8. Apply plan atomically
+	if err := it.committer.ApplyChecked(ctx, plan); err != nil {
+		return "", err
+	}
+
+	product.ClearDomainEvents()
+	return variantID, nil
+}
+
+func enrichEvent(aggregateID string, event domain.DomainEvent) *contracts.EnrichedEvent {
+	payload, _ := json.Marshal(event)
+	return &contracts.EnrichedEvent{
+		EventID:     generateID(),
+		EventType:   eventType(event),
+		AggregateID: aggregateID,
+		Payload:     payload,
+		Status:      "pending",
+	}
+}
+
+func eventType(event domain.DomainEvent) string {
+	switch event.(type) {
+	case domain.VariantAddedEvent:
+		return "variant.added"
+	default:
+		return "unknown"
+	}
+}
+
+func generateID() string {
+	return fmt.Sprintf("id-%d", time.Now().UnixNano())
+}
@@ -17,16 +17,34 @@ import (
 // Request represents input for applying a discount to a product.
 type Request struct {
 	ProductID string
+	// DiscountID identifies the discount within the product.
+	// If empty, a new ID is generated; an existing ID replaces that discount.
+	DiscountID string
+	// Kind selects the discount kind; empty means percentage.
+	Kind domain.DiscountKind
 	// PercentageNumerator and PercentageDenominator represent the discount percentage as a rational.
-	// E.g., 20% = 20/100, 15.5% = 155/1000.
+	// E.g., 20% = 20/100, 15.5% = 155/1000. Used by percentage discounts.
 	PercentageNumerator   int64
 	PercentageDenominator int64
-	StartDate             time.Time
-	EndDate               time.Time
+	// AmountNumerator and AmountDenominator represent the amount off
+	// (fixed_amount) or the new price (price_override) as a rational.
+	// E.g., 5.00 off = 500/100.
+	AmountNumerator   int64
+	AmountDenominator int64
+	// Amount is the exact amount as a decimal string, e.g. "5.00".
+	// When set it takes precedence over the numerator/denominator pair.
+	Amount string
+
+	StartDate time.Time
+	EndDate   time.Time
+	// Priority orders discounts for combination; higher values win.
+	Priority int
+	// Exclusive discounts are never combined with other discounts.
+	Exclusive bool
 }
 
 // Interactor implements the ApplyDiscount usecase following the Golden Mutation Pattern.
-// Enforces: only one active discount per product at a time (replaces existing).
+// A product may hold several discounts; they are combined by the pricing calculator.
 type Interactor struct {
 	repo      contracts.ProductRepo
 	outboxRepo contracts.OutboxRepo
@@ -49,27 +67,30 @@ func New(
 	}
 }
 
-// Execute applies a percentage-based discount to a product.
+// Execute adds a discount to a product and returns its ID.
 // The discount must have valid start/end dates, and the product must be active.
-// If a discount already exists, it is replaced (only one active discount per product).
-func (it *Interactor) Execute(ctx context.Context, req Request) error {
+// A discount with the same ID as an existing one replaces it.
+func (it *Interactor) Execute(ctx context.Context, req Request) (string, error) {
 	// 1. Load aggregate
 	product, err := it.repo.FindByID(ctx, req.ProductID)
 	if err != nil {
-		return fmt.Errorf("product not found: %w", err)
+		return "", fmt.Errorf("product not found: %w", err)
 	}
 
 	// 2. Create discount value object (validates percentage and dates)
-	percentage := big.NewRat(req.PercentageNumerator, req.PercentageDenominator)
-	discount, err := domain.NewDiscount(percentage, req.StartDate, req.EndDate)
+	discountID := req.DiscountID
+	if discountID == "" {
+		discountID = generateID()
+	}
+	discount, err := newDiscount(discountID, req)
 	if err != nil {
-		return fmt.Errorf("invalid discount: %w", err)
+		return "", fmt.Errorf("invalid discount: %w", err)
 	}
 
 	// 3. Call domain method (validates product is active and discount is valid at current time)
 	now := it.clock.Now()
 	if err := product.ApplyDiscount(discount, now); err != nil {
-		return err
+		return "", err
 	}
 
 	// 4. Build commit plan
@@ -79,6 +100,15 @@ func (it *Interactor) Execute(ctx context.Context, req Request) error {
 	if mut := it.repo.UpdateMut(product); mut != nil {
 		plan.Add(mut)
 	}
+	for _, mut := range it.repo.DiscountMuts(product) {
+		plan.Add(mut)
+	}
+	if mut := it.repo.PriceHistoryMut(product); mut != nil {
+		plan.Add(mut)
+	}
+	for _, mut := range it.repo.PricePeriodMuts(product) {
+		plan.Add(mut)
+	}
 
 	// 6. Add outbox events
 	for _, event := range product.DomainEvents() {
@@ -90,11 +120,67 @@ func (it *Interactor) Execute(ctx context.Context, req Request) error {
 
 	// 7. Apply plan atomically
 	if err := it.committer.Apply(ctx, plan); err != nil {
-		return err
+		return "", err
 	}
 
 	product.ClearDomainEvents()
-	return nil
+	return discountID, nil
+}
+
+// newDiscount builds the discount value object for the requested kind.
+func newDiscount(id string, req Request) (*domain.Discount, error) {
+	switch req.Kind {
+	case domain.DiscountKindFixedAmount:
+		amount, err := amountFromRequest(req)
+		if err != nil {
+			return nil, err
+		}
+		return domain.NewFixedAmountDiscount(
+			id, amount, req.StartDate, req.EndDate, req.Priority, req.Exclusive,
+		)
+	case domain.DiscountKindPriceOverride:
+		price, err := amountFromRequest(req)
+		if err != nil {
+			return nil, err
+		}
+		return domain.NewPriceOverrideDiscount(
+			id, price, req.StartDate, req.EndDate, req.Priority, req.Exclusive,
+		)
+	case domain.DiscountKindPercentage, "":
+		if req.PercentageDenominator <= 0 {
+			return nil, fmt.Errorf("percentage denominator must be > 0")
+		}
+		percentage := big.NewRat(req.PercentageNumerator, req.PercentageDenominator)
+		if err := domain.CheckDecimalScale(percentage); err != nil {
+			return nil, fmt.Errorf("invalid percentage: %w", err)
+		}
+		return domain.NewStackedDiscount(
+			id, percentage, req.StartDate, req.EndDate, req.Priority, req.Exclusive,
+		)
+	default:
+		return nil, fmt.Errorf("unknown discount kind: %s", req.Kind)
+	}
+}
+
+// amountFromRequest parses the discount amount, preferring the exact decimal
+// form, and rejects values that cannot be stored without loss.
+func amountFromRequest(req Request) (*domain.Money, error) {
+	var (
+		amount *domain.Money
+		err    error
+	)
+	if req.Amount != "" {
+		amount, err = domain.NewMoneyFromString(req.Amount)
+	} else {
+		amount, err = domain.NewMoneyFromFraction(req.AmountNumerator, req.AmountDenominator)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := amount.CheckStorable(); err != nil {
+		return nil, fmt.Errorf("invalid amount: %w", err)
+	}
+	return amount, nil
 }
 
 func enrichEvent(aggregateID string, event domain.DomainEvent) *contracts.EnrichedEvent {
@@ -0,0 +1,107 @@
+package backfillpriceperiods
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Vektor-AI/commitplan"
+	"product-catalog-service/internal/app/product/contracts"
+	"product-catalog-service/internal/app/product/domain"
+	"product-catalog-service/internal/pkg/committer"
+)
+
+// DefaultBatchSize is the number of products written per commit. A
+// product's timeline is a delete and a few period inserts, so a batch stays
+// well under Spanner's limit of 80,000 mutations per commit.
+const DefaultBatchSize = 100
+
+// Request represents input for a price period backfill.
+type Request struct {
+	// BatchSize is the number of products written per commit; 0 means
+	// DefaultBatchSize.
+	BatchSize int
+}
+
+// Result describes a completed backfill.
+type Result struct {
+	// Products is the number of products whose timeline was written.
+	Products int
+}
+
+// Interactor implements the BackfillPricePeriods usecase following the Golden Mutation Pattern.
+//
+// Listings filter and sort by the materialized effective price timeline,
+// which is written whenever the pricing of a product changes. Products
+// written before the timeline existed have none; the backfill writes it for
+// every such product in product_id order, batch by batch. Products that
+// already have a timeline are not touched, so the backfill can be rerun
+// and resumes where a failed run stopped.
+type Interactor struct {
+	repo      contracts.ProductRepo
+	committer *committer.PlanCommitter
+}
+
+// New creates a new BackfillPricePeriods interactor.
+func New(
+	repo contracts.ProductRepo,
+	committer *committer.PlanCommitter,
+) *Interactor {
+	return &Interactor{
+		repo:      repo,
+		committer: committer,
+	}
+}
+
+// Execute writes the timeline of every product without one.
+func (it *Interactor) Execute(ctx context.Context, req Request) (*Result, error) {
+	batchSize := req.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	result := &Result{}
+	after := ""
+	for {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		// 1. Find products without a timeline
+		ids, err := it.repo.FindIDsWithoutPricePeriods(ctx, after, batchSize)
+		if err != nil {
+			return result, err
+		}
+		if len(ids) == 0 {
+			return result, nil
+		}
+
+		// 2. Build commit plan
+		plan := commitplan.NewPlan()
+
+		for _, id := range ids {
+			// 3. Load aggregate
+			product, err := it.repo.FindByID(ctx, id)
+			if err != nil {
+				return result, fmt.Errorf("product %s: %w", id, err)
+			}
+
+			// 4. Get mutations from repository. The timeline is derived from
+			// the whole pricing state, which is written as if it had changed.
+			product.Changes().MarkDirty(domain.FieldBasePrice)
+			for _, mut := range it.repo.PricePeriodMuts(product) {
+				plan.Add(mut)
+			}
+		}
+
+		// 5. Apply plan atomically
+		if err := it.committer.Apply(ctx, plan); err != nil {
+			return result, err
+		}
+
+		result.Products += len(ids)
+		after = ids[len(ids)-1]
+		if len(ids) < batchSize {
+			return result, nil
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package createcategory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Vektor-AI/commitplan"
+	"product-catalog-service/internal/app/product/contracts"
+	"product-catalog-service/internal/app/product/domain"
+	"product-catalog-service/internal/pkg/clock"
+	"product-catalog-service/internal/pkg/committer"
+)
+
+// Request represents input for creating a category.
+type Request struct {
+	Name string
+	// Slug is how products reference the category and cannot change; empty
+	// derives it from Name.
+	Slug string
+	// ParentID is empty for a root category.
+	ParentID  string
+	SortOrder int64
+	// AttributeSchema declares the attributes of the category's products.
+	AttributeSchema []domain.AttributeDefinition
+}
+
+// Interactor implements the CreateCategory usecase following the Golden Mutation Pattern.
+type Interactor struct {
+	repo       contracts.CategoryRepo
+	outboxRepo contracts.OutboxRepo
+	committer  *committer.PlanCommitter
+	clock      clock.Clock
+}
+
+// New creates a new CreateCategory interactor.
+func New(
+	repo contracts.CategoryRepo,
+	outboxRepo contracts.OutboxRepo,
+	committer *committer.PlanCommitter,
+	clock clock.Clock,
+) *Interactor {
+	return &Interactor{
+		repo:       repo,
+		outboxRepo: outboxRepo,
+		committer:  committer,
+		clock:      clock,
+	}
+}
+
+// Execute creates a new category and persists it atomically with events.
+func (it *Interactor) Execute(ctx context.Context, req Request) (string, error) {
+	// 1. Create aggregate
+	now := it.clock.Now()
+	category, err := domain.NewCategory(generateID(), req.Name, req.Slug, req.ParentID, req.SortOrder, now)
+	if err != nil {
+		return "", err
+	}
+	if err := category.SetAttributeSchema(req.AttributeSchema, now); err != nil {
+		return "", err
+	}
+
+	// 2. Check references; the unique slug index backs the slug check
+	if category.ParentID() != "" {
+		if _, err := it.repo.FindByID(ctx, category.ParentID()); err != nil {
+			if errors.Is(err, domain.ErrCategoryNotFound) {
+				return "", fmt.Errorf("%w: parent %q does not exist", domain.ErrInvalidCategory, category.ParentID())
+			}
+			return "", err
+		}
+	}
+	if _, err := it.repo.FindBySlug(ctx, category.Slug()); err == nil {
+		return "", fmt.Errorf("%w: %q", domain.ErrCategorySlugTaken, category.Slug())
+	} else if !errors.Is(err, domain.ErrCategoryNotFound) {
+		return "", err
+	}
+
+	// 3. Build commit plan
+	plan := commitplan.NewPlan()
+
+	// 4. Get mutations from repository
+	if mut := it.repo.InsertMut(category); mut != nil {
+		plan.Add(mut)
+	}
+	for _, mut := range it.repo.AttributeSchemaMuts(category) {
+		plan.Add(mut)
+	}
+
+	// 5. Add outbox events
+	for _, event := range category.DomainEvents() {
+		enriched := enrichEvent(category.ID(), event)
+		if outboxMut := it.outboxRepo.InsertMut(enriched); outboxMut != nil {
+			plan.Add(outboxMut)
+		}
+	}
+
+	// 6. Apply plan atomically
+	if err := it.committer.Apply(ctx, plan); err != nil {
+		return "", err
+	}
+
+	category.ClearDomainEvents()
+	return category.ID(), nil
+}
+
+func enrichEvent(aggregateID string, event domain.DomainEvent) *contracts.EnrichedEvent {
+	payload, _ := json.Marshal(event)
+	return &contracts.EnrichedEvent{
+		EventID:     generateID(),
+		EventType:   eventType(event),
+		AggregateID: aggregateID,
+		Payload:     payload,
+		Status:      "pending",
+	}
+}
+
+func eventType(event domain.DomainEvent) string {
+	switch event.(type) {
+	case domain.CategoryCreatedEvent:
+		return "category.created"
+	default:
+		return "unknown"
+	}
+}
+
+func generateID() string {
+	return fmt.Sprintf("id-%d", time.Now().UnixNano())
+}
@@ -3,10 +3,10 @@ package createproduct
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
-	"github.com/Vektor-AI/commitplan"
 	"product-catalog-service/internal/app/product/contracts"
 	"product-catalog-service/internal/app/product/domain"
 	"product-catalog-service/internal/pkg/clock"
@@ -22,11 +22,19 @@ type Request struct {
 	// E.g., $19.99 = 1999/100.
 	BasePriceNumerator   int64
 	BasePriceDenominator int64
+	// BasePrice is the exact base price as a decimal string, e.g. "19.99".
+	// When set it takes precedence over the numerator/denominator pair.
+	BasePrice string
+	// Currency is an ISO 4217 code; empty means domain.DefaultCurrency.
+	Currency string
+	// Attributes are typed product attributes by key, e.g. "color".
+	Attributes map[string]domain.AttributeValue
 }
 
 // Interactor implements the CreateProduct usecase following the Golden Mutation Pattern.
 type Interactor struct {
 	repo      contracts.ProductRepo
+	categories contracts.CategoryRepo
 	outboxRepo contracts.OutboxRepo
 	committer *committer.PlanCommitter
 	clock     clock.Clock
@@ -35,12 +43,14 @@ type Interactor struct {
 // New creates a new CreateProduct interactor.
 func New(
 	repo contracts.ProductRepo,
+	categories contracts.CategoryRepo,
 	outboxRepo contracts.OutboxRepo,
 	committer *committer.PlanCommitter,
 	clock clock.Clock,
 ) *Interactor {
 	return &Interactor{
 		repo:      repo,
+		categories: categories,
 		outboxRepo: outboxRepo,
 		committer: committer,
 		clock:     clock,
@@ -50,13 +60,24 @@ func New(
 // Execute creates a new product and persists it atomically with events.
 func (it *Interactor) Execute(ctx context.Context, req Request) (string, error) {
 	// 1. Create aggregate
-	basePrice, err := domain.NewMoneyFromFraction(
-		req.BasePriceNumerator,
-		req.BasePriceDenominator,
-	)
+	basePrice, err := basePriceFromRequest(req)
 	if err != nil {
 		return "", fmt.Errorf("invalid base price: %w", err)
 	}
+	if err := basePrice.CheckStorable(); err != nil {
+		return "", fmt.Errorf("invalid base price: %w", err)
+	}
+	currency, err := domain.ParseCurrency(req.Currency)
+	if err != nil {
+		return "", err
+	}
+	basePrice = basePrice.WithCurrency(currency)
+	var category *domain.Category
+	if req.Category != "" {
+		if category, err = it.findCategory(ctx, req.Category); err != nil {
+			return "", err
+		}
+	}
 
 	now := it.clock.Now()
 	product := domain.NewProduct(
@@ -68,15 +89,37 @@ func (it *Interactor) Execute(ctx context.Context, req Request) (string, error)
 		now,
 	)
 
-	// 2. Domain validation (already done in constructor)
+	// 2. Domain validation (attributes are checked when set, then against
+	// the category's schema)
+	if err := product.UpdateAttributes(req.Attributes, nil, now); err != nil {
+		return "", err
+	}
+	if category != nil {
+		if err := category.ValidateAttributes(product.Attributes()); err != nil {
+			return "", err
+		}
+	}
 
-	// 3. Build commit plan
-	plan := commitplan.NewPlan()
+	// 3. Build commit plan, re-checking the category schema in the same
+	// transaction so that a concurrent category change cannot slip in
+	plan := committer.NewCheckedPlan()
+	if category != nil {
+		plan.Check(it.categoryCheck(category.Slug(), product))
+	}
 
 	// 4. Get mutations from repository
 	if mut := it.repo.InsertMut(product); mut != nil {
 		plan.Add(mut)
 	}
+	if mut := it.repo.PriceHistoryMut(product); mut != nil {
+		plan.Add(mut)
+	}
+	for _, mut := range it.repo.PricePeriodMuts(product) {
+		plan.Add(mut)
+	}
+	for _, mut := range it.repo.AttributeMuts(product) {
+		plan.Add(mut)
+	}
 
 	// 5. Add outbox events
 	for _, event := range product.DomainEvents() {
@@ -87,7 +130,10 @@ func (it *Interactor) Execute(ctx context.Context, req Request) (string, error)
 	}
 
 	// 6. Apply plan (usecase applies, NOT handler!)
-	if err := it.committer.Apply(ctx, plan); err != nil {
+	if err := it.committer.ApplyChecked(ctx, plan); err != nil {
+		if errors.Is(err, domain.ErrCategoryNotFound) {
+			return "", fmt.Errorf("%w: %q", domain.ErrUnknownCategory, category.Slug())
+		}
 		return "", err
 	}
 
@@ -95,6 +141,14 @@ func (it *Interactor) Execute(ctx context.Context, req Request) (string, error)
 	return product.ID(), nil
 }
 
+// basePriceFromRequest parses the base price, preferring the exact decimal form.
+func basePriceFromRequest(req Request) (*domain.Money, error) {
+	if req.BasePrice != "" {
+		return domain.NewMoneyFromString(req.BasePrice)
+	}
+	return domain.NewMoneyFromFraction(req.BasePriceNumerator, req.BasePriceDenominator)
+}
+
 // enrichEvent converts a domain event to an enriched outbox event.
 func enrichEvent(aggregateID string, event domain.DomainEvent) *contracts.EnrichedEvent {
 	payload, _ := json.Marshal(event)
@@ -122,13 +176,43 @@ func eventType(event domain.DomainEvent) string {
 		return "discount.applied"
 	case domain.DiscountRemovedEvent:
 		return "discount.removed"
+	case domain.DiscountExpiredEvent:
+		return "discount.expired"
+	case domain.EffectivePriceChangedEvent:
+		return "product.effective_price_changed"
+	case domain.ScheduledPriceAddedEvent:
+		return "scheduled_price.added"
+	case domain.ScheduledPriceCancelledEvent:
+		return "scheduled_price.cancelled"
+	case domain.ScheduledPriceEffectiveEvent:
+		return "scheduled_price.effective"
 	default:
 		return "unknown"
 	}
 }
 
+// findCategory loads the category with the given slug, returning
+// domain.ErrUnknownCategory if there is none.
+func (it *Interactor) findCategory(ctx context.Context, slug string) (*domain.Category, error) {
+	category, err := it.categories.FindBySlug(ctx, slug)
+	if err != nil {
+		if errors.Is(err, domain.ErrCategoryNotFound) {
+			return nil, fmt.Errorf("%w: %q", domain.ErrUnknownCategory, slug)
+		}
+		return nil, err
+	}
+	return category, nil
+}
+
 // generateID generates a simple ID. TODO: replace with proper UUID.
 func generateID() string {
 	return fmt.Sprintf("id-%d", time.Now().UnixNano())
 }
 
+// categoryCheck validates the product's attributes against the category
+// as read in the committing transaction.
+func (it *Interactor) categoryCheck(slug string, product *domain.Product) committer.Check {
+	return it.categories.SlugCheck(slug, func(c *domain.Category) error {
+		return c.ValidateAttributes(product.Attributes())
+	})
+}
@@ -0,0 +1,102 @@
+package deletecategory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"product-catalog-service/internal/app/product/contracts"
+	"product-catalog-service/internal/app/product/domain"
+	"product-catalog-service/internal/pkg/clock"
+	"product-catalog-service/internal/pkg/committer"
+)
+
+// Request represents input for deleting a category.
+type Request struct {
+	CategoryID string
+}
+
+// Interactor implements the DeleteCategory usecase following the Golden Mutation Pattern.
+type Interactor struct {
+	repo       contracts.CategoryRepo
+	outboxRepo contracts.OutboxRepo
+	committer  *committer.PlanCommitter
+	clock      clock.Clock
+}
+
+// New creates a new DeleteCategory interactor.
+func New(
+	repo contracts.CategoryRepo,
+	outboxRepo contracts.OutboxRepo,
+	committer *committer.PlanCommitter,
+	clock clock.Clock,
+) *Interactor {
+	return &Interactor{
+		repo:       repo,
+		outboxRepo: outboxRepo,
+		committer:  committer,
+		clock:      clock,
+	}
+}
+
+// Execute deletes a category without subcategories or products.
+func (it *Interactor) Execute(ctx context.Context, req Request) error {
+	// 1. Load aggregate
+	category, err := it.repo.FindByID(ctx, req.CategoryID)
+	if err != nil {
+		return err
+	}
+
+	// 2. Call domain method
+	category.Delete(it.clock.Now())
+
+	// 3. Build commit plan, checking the category is unused in the same transaction
+	plan := committer.NewCheckedPlan()
+	plan.Check(it.repo.UnusedCheck(category))
+
+	// 4. Get mutations from repository
+	if mut := it.repo.DeleteMut(category); mut != nil {
+		plan.Add(mut)
+	}
+
+	// 5. Add outbox events
+	for _, event := range category.DomainEvents() {
+		enriched := enrichEvent(category.ID(), event)
+		if outboxMut := it.outboxRepo.InsertMut(enriched); outboxMut != nil {
+			plan.Add(outboxMut)
+		}
+	}
+
+	// 6. Apply plan atomically
+	if err := it.committer.ApplyChecked(ctx, plan); err != nil {
+		return err
+	}
+
+	category.ClearDomainEvents()
+	return nil
+}
+
+func enrichEvent(aggregateID string, event domain.DomainEvent) *contracts.EnrichedEvent {
+	payload, _ := json.Marshal(event)
+	return &contracts.EnrichedEvent{
+		EventID:     generateID(),
+		EventType:   eventType(event),
+		AggregateID: aggregateID,
+		Payload:     payload,
+		Status:      "pending",
+	}
+}
+
+func eventType(event domain.DomainEvent) string {
+	switch event.(type) {
+	case domain.CategoryDeletedEvent:
+		return "category.deleted"
+	default:
+		return "unknown"
+	}
+}
+
+func generateID() string {
+	return fmt.Sprintf("id-%d", time.Now().UnixNano())
+}
@@ -0,0 +1,118 @@
+package recategorizeproducts
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Vektor-AI/commitplan"
+	"product-catalog-service/internal/app/product/contracts"
+	"product-catalog-service/internal/app/product/domain"
+	"product-catalog-service/internal/pkg/clock"
+	"product-catalog-service/internal/pkg/committer"
+)
+
+// Request represents input for moving every product of a category.
+type Request struct {
+	// FromCategory is the category value to replace; it need not name an
+	// existing category, so legacy values can be migrated.
+	FromCategory string
+	// ToCategory is the slug of an existing category.
+	ToCategory string
+}
+
+// Interactor implements the RecategorizeProducts usecase following the Golden Mutation Pattern.
+//
+// It only records the job; the products are moved in batches by the
+// RunRecategorizations background job.
+type Interactor struct {
+	repo       contracts.RecategorizationRepo
+	categories contracts.CategoryRepo
+	outboxRepo contracts.OutboxRepo
+	committer  *committer.PlanCommitter
+	clock      clock.Clock
+}
+
+// New creates a new RecategorizeProducts interactor.
+func New(
+	repo contracts.RecategorizationRepo,
+	categories contracts.CategoryRepo,
+	outboxRepo contracts.OutboxRepo,
+	committer *committer.PlanCommitter,
+	clock clock.Clock,
+) *Interactor {
+	return &Interactor{
+		repo:       repo,
+		categories: categories,
+		outboxRepo: outboxRepo,
+		committer:  committer,
+		clock:      clock,
+	}
+}
+
+// Execute starts a recategorization job and returns its ID.
+func (it *Interactor) Execute(ctx context.Context, req Request) (string, error) {
+	// 1. Create aggregate
+	job, err := domain.NewRecategorization(generateID(), req.FromCategory, req.ToCategory, it.clock.Now())
+	if err != nil {
+		return "", err
+	}
+
+	// 2. Check the target category exists
+	if _, err := it.categories.FindBySlug(ctx, job.ToCategory()); err != nil {
+		if errors.Is(err, domain.ErrCategoryNotFound) {
+			return "", fmt.Errorf("%w: %q", domain.ErrUnknownCategory, job.ToCategory())
+		}
+		return "", err
+	}
+
+	// 3. Build commit plan
+	plan := commitplan.NewPlan()
+
+	// 4. Get mutations from repository
+	if mut := it.repo.InsertMut(job); mut != nil {
+		plan.Add(mut)
+	}
+
+	// 5. Add outbox events
+	for _, event := range job.DomainEvents() {
+		enriched := enrichEvent(job.ID(), event)
+		if outboxMut := it.outboxRepo.InsertMut(enriched); outboxMut != nil {
+			plan.Add(outboxMut)
+		}
+	}
+
+	// 6. Apply plan atomically
+	if err := it.committer.Apply(ctx, plan); err != nil {
+		return "", err
+	}
+
+	job.ClearDomainEvents()
+	return job.ID(), nil
+}
+
+func enrichEvent(aggregateID string, event domain.DomainEvent) *contracts.EnrichedEvent {
+	payload, _ := json.Marshal(event)
+	return &contracts.EnrichedEvent{
+		EventID:     generateID(),
+		EventType:   eventType(event),
+		AggregateID: aggregateID,
+		Payload:     payload,
+		Status:      "pending",
+	}
+}
+
+func eventType(event domain.DomainEvent) string {
+	switch event.(type) {
+	case domain.RecategorizationStartedEvent:
+		return "recategorization.started"
+	default:
+		return "unknown"
+	}
+}
+
+func generateID() string {
+	return fmt.Sprintf("id-%d", time.Now().UnixNano())
+}
@@ -16,6 +16,8 @@ import (
 // Request represents input for removing a discount from a product.
 type Request struct {
 	ProductID string
+	// DiscountID selects the discount to remove; empty removes all discounts.
+	DiscountID string
 }
 
 // Interactor implements the RemoveDiscount usecase following the Golden Mutation Pattern.
@@ -41,7 +43,7 @@ func New(
 	}
 }
 
-// Execute removes the current discount from a product (if any).
+// Execute removes one discount, or all discounts, from a product.
 // Uses precise decimal arithmetic for pricing calculations via domain service.
 func (it *Interactor) Execute(ctx context.Context, req Request) error {
 	// 1. Load aggregate
@@ -52,7 +54,9 @@ func (it *Interactor) Execute(ctx context.Context, req Request) error {
 
 	// 2. Call domain method (removes discount if present)
 	now := it.clock.Now()
-	product.RemoveDiscount(now)
+	if err := product.RemoveDiscount(req.DiscountID, now); err != nil {
+		return err
+	}
 
 	// 3. Build commit plan
 	plan := commitplan.NewPlan()
@@ -61,6 +65,15 @@ func (it *Interactor) Execute(ctx context.Context, req Request) error {
 	if mut := it.repo.UpdateMut(product); mut != nil {
 		plan.Add(mut)
 	}
+	for _, mut := range it.repo.DiscountMuts(product) {
+		plan.Add(mut)
+	}
+	if mut := it.repo.PriceHistoryMut(product); mut != nil {
+		plan.Add(mut)
+	}
+	for _, mut := range it.repo.PricePeriodMuts(product) {
+		plan.Add(mut)
+	}
 
 	// 5. Add outbox events (only if discount was removed)
 	for _, event := range product.DomainEvents() {
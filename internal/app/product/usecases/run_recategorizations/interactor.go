@@ -0,0 +1,259 @@
+package runrecategorizations
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/Vektor-AI/commitplan"
+	"product-catalog-service/internal/app/product/contracts"
+	"product-catalog-service/internal/app/product/domain"
+	"product-catalog-service/internal/pkg/clock"
+	"product-catalog-service/internal/pkg/committer"
+)
+
+// DefaultBatchSize is the number of products moved per commit. Each product
+// writes about 20 mutations (the product update, its index entries and an
+// outbox event), keeping a batch well under Spanner's limit of 80,000
+// mutations per commit.
+const DefaultBatchSize = 500
+
+// Request represents input for a run of the pending recategorization jobs.
+type Request struct {
+	// BatchSize is the number of products moved per commit; 0 means
+	// DefaultBatchSize.
+	BatchSize int
+}
+
+// Result describes a completed run.
+type Result struct {
+	// Jobs is the number of jobs that finished, failed ones included.
+	Jobs int
+	// FailedJobs is the number of jobs that failed in this run.
+	FailedJobs int
+	// MovedProducts is the number of products moved by this run.
+	MovedProducts int
+}
+
+// Interactor implements the RunRecategorizations usecase following the Golden Mutation Pattern.
+//
+// Every running job is advanced batch by batch until no product is left in
+// its source category. Products are checked against the attribute schema of
+// the target category before they move; a product that does not match fails
+// the job, leaving it and the rest of its batch in the source category. A
+// batch commits the moved products, their events and the job's progress
+// together, so a crashed or failed run resumes after the last committed
+// batch. A batch committed concurrently by another runner makes this run's
+// commit of the same batch fail instead of moving products twice.
+type Interactor struct {
+	repo        contracts.RecategorizationRepo
+	productRepo contracts.ProductRepo
+	categories  contracts.CategoryRepo
+	outboxRepo  contracts.OutboxRepo
+	committer   *committer.PlanCommitter
+	clock       clock.Clock
+}
+
+// New creates a new RunRecategorizations interactor.
+func New(
+	repo contracts.RecategorizationRepo,
+	productRepo contracts.ProductRepo,
+	categories contracts.CategoryRepo,
+	outboxRepo contracts.OutboxRepo,
+	committer *committer.PlanCommitter,
+	clock clock.Clock,
+) *Interactor {
+	return &Interactor{
+		repo:        repo,
+		productRepo: productRepo,
+		categories:  categories,
+		outboxRepo:  outboxRepo,
+		committer:   committer,
+		clock:       clock,
+	}
+}
+
+// Execute runs every running job to completion. A failing job does not stop
+// the others; it is retried on the next run.
+func (it *Interactor) Execute(ctx context.Context, req Request) (*Result, error) {
+	batchSize := req.BatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultBatchSize
+	}
+
+	// 1. Find running jobs
+	ids, err := it.repo.FindIDsByStatus(ctx, domain.RecategorizationRunning)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{}
+	var errs []error
+	for _, id := range ids {
+		moved, status, err := it.runJob(ctx, id, batchSize)
+		result.MovedProducts += moved
+		if err != nil {
+			errs = append(errs, fmt.Errorf("recategorization %s: %w", id, err))
+			continue
+		}
+		result.Jobs++
+		if status == domain.RecategorizationFailed {
+			result.FailedJobs++
+		}
+	}
+	return result, errors.Join(errs...)
+}
+
+// runJob moves the products of one job in batches and completes or fails
+// it. It returns the number of products moved and the final job status.
+func (it *Interactor) runJob(ctx context.Context, id string, batchSize int) (int, domain.RecategorizationStatus, error) {
+	// 2. Load aggregate and the target category, whose schema the moved
+	// products must match. A missing category fails the job once a product
+	// is left to move.
+	job, err := it.repo.FindByID(ctx, id)
+	if err != nil {
+		return 0, "", err
+	}
+	category, err := it.categories.FindBySlug(ctx, job.ToCategory())
+	if err != nil && !errors.Is(err, domain.ErrCategoryNotFound) {
+		return 0, job.Status(), err
+	}
+
+	total := 0
+	for job.Status() == domain.RecategorizationRunning {
+		if err := ctx.Err(); err != nil {
+			return total, job.Status(), err
+		}
+		moved, err := it.runBatch(ctx, job, category, batchSize)
+		if err != nil {
+			return total, job.Status(), err
+		}
+		total += moved
+	}
+	return total, job.Status(), nil
+}
+
+// runBatch moves up to batchSize products of a job, completes the job when
+// none is left, or fails it when a product does not match the target
+// category.
+func (it *Interactor) runBatch(ctx context.Context, job *domain.Recategorization, category *domain.Category, batchSize int) (int, error) {
+	ids, err := it.productRepo.FindIDsByCategory(ctx, job.FromCategory(), batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	// 3. Call domain methods
+	now := it.clock.Now()
+	var failure string
+	var products []*domain.Product
+	for _, id := range ids {
+		product, err := it.productRepo.FindByID(ctx, id)
+		if err != nil {
+			return 0, fmt.Errorf("product %s: %w", id, err)
+		}
+		// Skip products moved since the lookup
+		if product.Category() != job.FromCategory() {
+			continue
+		}
+		if category == nil {
+			failure = fmt.Sprintf("%v: %q", domain.ErrUnknownCategory, job.ToCategory())
+			break
+		}
+		if err := category.ValidateAttributes(product.Attributes()); err != nil {
+			failure = fmt.Sprintf("product %s: %v", id, err)
+			break
+		}
+		product.UpdateDetails("", "", job.ToCategory(), now)
+		products = append(products, product)
+	}
+
+	if failure != "" {
+		products = nil
+		job.Fail(failure, now)
+	} else if len(ids) == 0 {
+		job.Complete(now)
+	} else if _, err := job.RecordBatch(len(products), now); err != nil {
+		return 0, err
+	}
+
+	// 4. Build commit plan
+	plan := commitplan.NewPlan()
+
+	// 5. Get mutations from repositories
+	for _, product := range products {
+		if mut := it.productRepo.UpdateMut(product); mut != nil {
+			plan.Add(mut)
+		}
+	}
+	if mut := it.repo.UpdateMut(job); mut != nil {
+		plan.Add(mut)
+	}
+	if len(ids) > 0 && failure == "" {
+		if mut := it.repo.BatchMut(job, len(products)); mut != nil {
+			plan.Add(mut)
+		}
+	}
+
+	// 6. Add outbox events
+	for _, product := range products {
+		for _, event := range product.DomainEvents() {
+			enriched := enrichEvent(product.ID(), event)
+			if outboxMut := it.outboxRepo.InsertMut(enriched); outboxMut != nil {
+				plan.Add(outboxMut)
+			}
+		}
+	}
+	for _, event := range job.DomainEvents() {
+		enriched := enrichEvent(job.ID(), event)
+		if outboxMut := it.outboxRepo.InsertMut(enriched); outboxMut != nil {
+			plan.Add(outboxMut)
+		}
+	}
+
+	// 7. Apply plan atomically
+	if err := it.committer.Apply(ctx, plan); err != nil {
+		return 0, err
+	}
+
+	for _, product := range products {
+		product.ClearDomainEvents()
+	}
+	job.Changes().Clear()
+	job.ClearDomainEvents()
+	return len(products), nil
+}
+
+func enrichEvent(aggregateID string, event domain.DomainEvent) *contracts.EnrichedEvent {
+	payload, _ := json.Marshal(event)
+	return &contracts.EnrichedEvent{
+		EventID:     generateID(),
+		EventType:   eventType(event),
+		AggregateID: aggregateID,
+		Payload:     payload,
+		Status:      "pending",
+	}
+}
+
+func eventType(event domain.DomainEvent) string {
+	switch event.(type) {
+	case domain.ProductUpdatedEvent:
+		return "product.updated"
+	case domain.RecategorizationCompletedEvent:
+		return "recategorization.completed"
+	case domain.RecategorizationFailedEvent:
+		return "recategorization.failed"
+	default:
+		return "unknown"
+	}
+}
+
+// idSeq keeps the IDs of the many events of a batch distinct even when the
+// clock does not advance between them.
+var idSeq atomic.Uint32
+
+func generateID() string {
+	return fmt.Sprintf("id-%d-%d", time.Now().UnixNano(), idSeq.Add(1)%1000)
+}
@@ -0,0 +1,140 @@
+package scheduleprice
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Vektor-AI/commitplan"
+	"product-catalog-service/internal/app/product/contracts"
+	"product-catalog-service/internal/app/product/domain"
+	"product-catalog-service/internal/pkg/clock"
+	"product-catalog-service/internal/pkg/committer"
+)
+
+// Request represents input for scheduling a future base price.
+type Request struct {
+	ProductID string
+	// ScheduledPriceID identifies the scheduled price within the product.
+	// If empty, a new ID is generated; an existing ID reschedules that price.
+	ScheduledPriceID string
+	// Price is the exact future base price as a decimal string, e.g. "21.99".
+	Price string
+	// EffectiveFrom is when the price replaces the base price; must be in the future.
+	EffectiveFrom time.Time
+}
+
+// Interactor implements the SchedulePrice usecase following the Golden Mutation Pattern.
+// Scheduled prices take effect through the discount sweep.
+type Interactor struct {
+	repo       contracts.ProductRepo
+	outboxRepo contracts.OutboxRepo
+	committer  *committer.PlanCommitter
+	clock      clock.Clock
+}
+
+// New creates a new SchedulePrice interactor.
+func New(
+	repo contracts.ProductRepo,
+	outboxRepo contracts.OutboxRepo,
+	committer *committer.PlanCommitter,
+	clock clock.Clock,
+) *Interactor {
+	return &Interactor{
+		repo:       repo,
+		outboxRepo: outboxRepo,
+		committer:  committer,
+		clock:      clock,
+	}
+}
+
+// Execute schedules a base price and returns the scheduled price ID.
+func (it *Interactor) Execute(ctx context.Context, req Request) (string, error) {
+	// 1. Load aggregate
+	product, err := it.repo.FindByID(ctx, req.ProductID)
+	if err != nil {
+		return "", fmt.Errorf("product not found: %w", err)
+	}
+
+	// 2. Build value object
+	price, err := domain.NewMoneyFromString(req.Price)
+	if err != nil {
+		return "", err
+	}
+	if err := price.CheckStorable(); err != nil {
+		return "", fmt.Errorf("invalid price: %w", err)
+	}
+
+	scheduledPriceID := req.ScheduledPriceID
+	if scheduledPriceID == "" {
+		scheduledPriceID = generateID()
+	}
+	scheduled, err := domain.NewScheduledPrice(scheduledPriceID, price, req.EffectiveFrom)
+	if err != nil {
+		return "", err
+	}
+
+	// 3. Call domain method
+	now := it.clock.Now()
+	if err := product.SchedulePrice(scheduled, now); err != nil {
+		return "", err
+	}
+
+	// 4. Build commit plan
+	plan := commitplan.NewPlan()
+
+	// 5. Get mutations from repository
+	if mut := it.repo.UpdateMut(product); mut != nil {
+		plan.Add(mut)
+	}
+	for _, mut := range it.repo.ScheduledPriceMuts(product) {
+		plan.Add(mut)
+	}
+	if mut := it.repo.PriceHistoryMut(product); mut != nil {
+		plan.Add(mut)
+	}
+	for _, mut := range it.repo.PricePeriodMuts(product) {
+		plan.Add(mut)
+	}
+
+	// 6. Add outbox events
+	for _, event := range product.DomainEvents() {
+		enriched := enrichEvent(product.ID(), event)
+		if outboxMut := it.outboxRepo.InsertMut(enriched); outboxMut != nil {
+			plan.Add(outboxMut)
+		}
+	}
+
+	// 7. Apply plan atomically
+	if err := it.committer.Apply(ctx, plan); err != nil {
+		return "", err
+	}
+
+	product.ClearDomainEvents()
+	return scheduledPriceID, nil
+}
+
+func enrichEvent(aggregateID string, event domain.DomainEvent) *contracts.EnrichedEvent {
+	payload, _ := json.Marshal(event)
+	return &contracts.EnrichedEvent{
+		EventID:     generateID(),
+		EventType:   eventType(event),
+		AggregateID: aggregateID,
+		Payload:     payload,
+		Status:      "pending",
+	}
+}
+
+func eventType(event domain.DomainEvent) string {
+	switch event.(type) {
+	case domain.ScheduledPriceAddedEvent:
+		return "scheduled_price.added"
+	default:
+		return "unknown"
+	}
+}
+
+func generateID() string {
+	return fmt.Sprintf("id-%d", time.Now().UnixNano())
+}
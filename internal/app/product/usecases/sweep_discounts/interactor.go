@@ -0,0 +1,216 @@
+package sweepdiscounts
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Vektor-AI/commitplan"
+	"product-catalog-service/internal/app/product/contracts"
+	"product-catalog-service/internal/app/product/domain"
+	"product-catalog-service/internal/app/product/domain/services"
+	"product-catalog-service/internal/pkg/clock"
+	"product-catalog-service/internal/pkg/committer"
+)
+
+// CursorName names the cursor of the discount sweep in the sweep_cursors table.
+const CursorName = "discount_sweep"
+
+// DefaultLimit is the number of transitions read per page.
+const DefaultLimit = 500
+
+// Request represents input for a discount sweep.
+type Request struct {
+	// Limit is the number of transitions read per page; 0 means DefaultLimit.
+	Limit int
+}
+
+// Result describes a completed sweep.
+type Result struct {
+	// Position is the last transition handled, where the next sweep
+	// resumes.
+	Position contracts.PriceTransition
+	// Transitions is the number of transitions handled.
+	Transitions int
+}
+
+// Interactor implements the SweepDiscounts usecase following the Golden Mutation Pattern.
+//
+// Discount windows starting or ending and scheduled base prices taking
+// effect change the effective price without a command. The sweep walks
+// these transitions in (time, product) order, applies due scheduled prices,
+// clears expired discounts and records the price change, so that downstream
+// consumers learn about it through the outbox.
+//
+// Each transition is committed together with the sweep's cursor, so a
+// failed or restarted sweep resumes after the last committed transition.
+// Events of a transition have IDs derived from it; a transition committed
+// concurrently by another sweeper makes this sweep's commit fail instead of
+// emitting its events twice.
+type Interactor struct {
+	repo       contracts.ProductRepo
+	cursorRepo contracts.SweepCursorRepo
+	outboxRepo contracts.OutboxRepo
+	committer  *committer.PlanCommitter
+	clock      clock.Clock
+	pricing    services.PricingCalculator
+}
+
+// New creates a new SweepDiscounts interactor.
+func New(
+	repo contracts.ProductRepo,
+	cursorRepo contracts.SweepCursorRepo,
+	outboxRepo contracts.OutboxRepo,
+	committer *committer.PlanCommitter,
+	clock clock.Clock,
+	pricing services.PricingCalculator,
+) *Interactor {
+	return &Interactor{
+		repo:       repo,
+		cursorRepo: cursorRepo,
+		outboxRepo: outboxRepo,
+		committer:  committer,
+		clock:      clock,
+		pricing:    pricing,
+	}
+}
+
+// Execute handles every transition after the cursor and at or before now.
+// The first sweep starts before the earliest transition, so discounts that
+// ended and scheduled prices that came due before it are handled too. A
+// failing transition stops the sweep; it is retried on the next run.
+func (it *Interactor) Execute(ctx context.Context, req Request) (*Result, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+
+	// 1. Load the cursor
+	now := it.clock.Now()
+	cursor, err := it.cursorRepo.FindByName(ctx, CursorName)
+	if err != nil {
+		return nil, err
+	}
+	if cursor == nil {
+		cursor = &contracts.SweepCursor{Name: CursorName}
+	}
+
+	result := &Result{Position: cursor.Position}
+	for {
+		// 2. Find the next page of transitions
+		transitions, err := it.repo.FindPriceTransitions(ctx, cursor.Position, now, limit)
+		if err != nil {
+			return result, err
+		}
+
+		for _, t := range transitions {
+			if err := ctx.Err(); err != nil {
+				return result, err
+			}
+			cursor.Position = t
+			if err := it.sweepTransition(ctx, cursor, now); err != nil {
+				return result, fmt.Errorf("product %s at %s: %w", t.ProductID, t.At.Format(time.RFC3339Nano), err)
+			}
+			result.Position = t
+			result.Transitions++
+		}
+
+		if len(transitions) < limit {
+			return result, nil
+		}
+	}
+}
+
+// sweepTransition applies the scheduled prices and clears the discounts
+// due at one transition of a product, records the effective price change
+// and moves the cursor to the transition.
+func (it *Interactor) sweepTransition(ctx context.Context, cursor *contracts.SweepCursor, now time.Time) error {
+	t := cursor.Position
+
+	// 3. Load aggregate
+	product, err := it.repo.FindByID(ctx, t.ProductID)
+	if err != nil {
+		return err
+	}
+
+	// 4. Call domain methods
+	oldPrice := it.pricing.EffectivePrice(product, t.At.Add(-time.Microsecond))
+	product.ApplyDueScheduledPrices(t.At)
+	product.ExpireDiscounts(t.At)
+	newPrice := it.pricing.EffectivePrice(product, t.At)
+	product.RecordEffectivePriceChange(oldPrice, newPrice, t.At)
+
+	// 5. Build commit plan
+	plan := commitplan.NewPlan()
+
+	// 6. Get mutations from repositories
+	if mut := it.repo.UpdateMut(product); mut != nil {
+		plan.Add(mut)
+	}
+	for _, mut := range it.repo.DiscountMuts(product) {
+		plan.Add(mut)
+	}
+	for _, mut := range it.repo.ScheduledPriceMuts(product) {
+		plan.Add(mut)
+	}
+	if mut := it.repo.PriceHistoryMut(product); mut != nil {
+		plan.Add(mut)
+	}
+	for _, mut := range it.repo.PricePeriodMuts(product) {
+		plan.Add(mut)
+	}
+	cursor.UpdatedAt = now
+	if mut := it.cursorRepo.SaveMut(cursor); mut != nil {
+		plan.Add(mut)
+	}
+
+	// 7. Add outbox events
+	for i, event := range product.DomainEvents() {
+		enriched := enrichEvent(eventID(t, i), product.ID(), event)
+		if outboxMut := it.outboxRepo.InsertMut(enriched); outboxMut != nil {
+			plan.Add(outboxMut)
+		}
+	}
+
+	// 8. Apply plan atomically
+	if err := it.committer.Apply(ctx, plan); err != nil {
+		return err
+	}
+
+	product.ClearDomainEvents()
+	return nil
+}
+
+func enrichEvent(id, aggregateID string, event domain.DomainEvent) *contracts.EnrichedEvent {
+	payload, _ := json.Marshal(event)
+	return &contracts.EnrichedEvent{
+		EventID:     id,
+		EventType:   eventType(event),
+		AggregateID: aggregateID,
+		Payload:     payload,
+		Status:      "pending",
+	}
+}
+
+func eventType(event domain.DomainEvent) string {
+	switch event.(type) {
+	case domain.DiscountExpiredEvent:
+		return "discount.expired"
+	case domain.EffectivePriceChangedEvent:
+		return "product.effective_price_changed"
+	case domain.ScheduledPriceEffectiveEvent:
+		return "scheduled_price.effective"
+	default:
+		return "unknown"
+	}
+}
+
+// eventID derives the ID of the index-th event of a transition, so that
+// sweeping a transition again writes the same outbox rows.
+func eventID(t contracts.PriceTransition, index int) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s/%d/%d", t.ProductID, t.At.UnixNano(), index)))
+	return hex.EncodeToString(sum[:16])
+}
@@ -0,0 +1,178 @@
+package updatecategory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"product-catalog-service/internal/app/product/contracts"
+	"product-catalog-service/internal/app/product/domain"
+	"product-catalog-service/internal/pkg/clock"
+	"product-catalog-service/internal/pkg/committer"
+)
+
+// maxAncestors bounds the walk up from a new parent before the commit.
+const maxAncestors = 100
+
+// Request represents input for updating a category. The slug cannot change.
+type Request struct {
+	CategoryID string
+	Name       *string // nil means no change
+	SortOrder  *int64
+	// ParentID moves the category; a pointer to "" makes it a root.
+	ParentID *string
+	// AttributeSchema replaces the attribute definitions; nil means no
+	// change and a pointer to an empty slice clears them.
+	AttributeSchema *[]domain.AttributeDefinition
+}
+
+// Interactor implements the UpdateCategory usecase following the Golden Mutation Pattern.
+type Interactor struct {
+	repo       contracts.CategoryRepo
+	outboxRepo contracts.OutboxRepo
+	committer  *committer.PlanCommitter
+	clock      clock.Clock
+}
+
+// New creates a new UpdateCategory interactor.
+func New(
+	repo contracts.CategoryRepo,
+	outboxRepo contracts.OutboxRepo,
+	committer *committer.PlanCommitter,
+	clock clock.Clock,
+) *Interactor {
+	return &Interactor{
+		repo:       repo,
+		outboxRepo: outboxRepo,
+		committer:  committer,
+		clock:      clock,
+	}
+}
+
+// Execute updates and moves a category atomically with events.
+func (it *Interactor) Execute(ctx context.Context, req Request) error {
+	// 1. Load aggregate
+	category, err := it.repo.FindByID(ctx, req.CategoryID)
+	if err != nil {
+		return err
+	}
+
+	// 2. Call domain methods
+	now := it.clock.Now()
+	name := ""
+	sortOrder := category.SortOrder()
+	if req.Name != nil {
+		name = *req.Name
+	}
+	if req.SortOrder != nil {
+		sortOrder = *req.SortOrder
+	}
+	if err := category.UpdateDetails(name, sortOrder, now); err != nil {
+		return err
+	}
+
+	moved := req.ParentID != nil && *req.ParentID != category.ParentID()
+	if moved {
+		ancestors, err := it.ancestors(ctx, *req.ParentID)
+		if err != nil {
+			return err
+		}
+		if err := category.MoveTo(*req.ParentID, ancestors, now); err != nil {
+			return err
+		}
+	}
+
+	if req.AttributeSchema != nil {
+		if err := category.SetAttributeSchema(*req.AttributeSchema, now); err != nil {
+			return err
+		}
+	}
+
+	// 3. Build commit plan. A move re-walks the new parent's ancestors in
+	// the same transaction, so that two concurrent moves cannot commit a
+	// cycle.
+	plan := committer.NewCheckedPlan()
+	if moved {
+		plan.Check(it.repo.AncestorsCheck(category.ParentID(), func(ancestors []string) error {
+			return category.ValidateParent(category.ParentID(), ancestors)
+		}))
+	}
+
+	// 4. Get mutations from repository
+	if mut := it.repo.UpdateMut(category); mut != nil {
+		plan.Add(mut)
+	}
+	for _, mut := range it.repo.AttributeSchemaMuts(category) {
+		plan.Add(mut)
+	}
+
+	// 5. Add outbox events
+	for _, event := range category.DomainEvents() {
+		enriched := enrichEvent(category.ID(), event)
+		if outboxMut := it.outboxRepo.InsertMut(enriched); outboxMut != nil {
+			plan.Add(outboxMut)
+		}
+	}
+
+	// 6. Apply plan atomically
+	if err := it.committer.ApplyChecked(ctx, plan); err != nil {
+		if errors.Is(err, domain.ErrCategoryNotFound) {
+			return fmt.Errorf("%w: parent %q does not exist", domain.ErrInvalidCategory, category.ParentID())
+		}
+		return err
+	}
+
+	category.ClearDomainEvents()
+	return nil
+}
+
+// ancestors returns the IDs of the ancestors of a prospective parent,
+// nearest first. An empty parentID is the root and has none.
+func (it *Interactor) ancestors(ctx context.Context, parentID string) ([]string, error) {
+	var out []string
+	for id := parentID; id != ""; {
+		if len(out) == maxAncestors {
+			return nil, fmt.Errorf("%w: category tree deeper than %d levels", domain.ErrInvalidCategory, maxAncestors)
+		}
+		parent, err := it.repo.FindByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, domain.ErrCategoryNotFound) {
+				return nil, fmt.Errorf("%w: parent %q does not exist", domain.ErrInvalidCategory, id)
+			}
+			return nil, err
+		}
+		if id != parentID {
+			out = append(out, id)
+		}
+		id = parent.ParentID()
+	}
+	return out, nil
+}
+
+func enrichEvent(aggregateID string, event domain.DomainEvent) *contracts.EnrichedEvent {
+	payload, _ := json.Marshal(event)
+	return &contracts.EnrichedEvent{
+		EventID:     generateID(),
+		EventType:   eventType(event),
+		AggregateID: aggregateID,
+		Payload:     payload,
+		Status:      "pending",
+	}
+}
+
+func eventType(event domain.DomainEvent) string {
+	switch event.(type) {
+	case domain.CategoryUpdatedEvent:
+		return "category.updated"
+	case domain.CategoryMovedEvent:
+		return "category.moved"
+	default:
+		return "unknown"
+	}
+}
+
+func generateID() string {
+	return fmt.Sprintf("id-%d", time.Now().UnixNano())
+}
@@ -3,10 +3,10 @@ package updateproduct
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
-	"github.com/Vektor-AI/commitplan"
 	"product-catalog-service/internal/app/product/contracts"
 	"product-catalog-service/internal/app/product/domain"
 	"product-catalog-service/internal/pkg/clock"
@@ -19,11 +19,17 @@ type Request struct {
 	Name        *string // nil means no change
 	Description *string
 	Category    *string
+	// Attributes sets typed attributes by key, replacing values of the
+	// same key; other attributes are kept.
+	Attributes map[string]domain.AttributeValue
+	// RemoveAttributes lists the keys of attributes to remove.
+	RemoveAttributes []string
 }
 
 // Interactor implements the UpdateProduct usecase following the Golden Mutation Pattern.
 type Interactor struct {
 	repo      contracts.ProductRepo
+	categories contracts.CategoryRepo
 	outboxRepo contracts.OutboxRepo
 	committer *committer.PlanCommitter
 	clock     clock.Clock
@@ -32,12 +38,14 @@ type Interactor struct {
 // New creates a new UpdateProduct interactor.
 func New(
 	repo contracts.ProductRepo,
+	categories contracts.CategoryRepo,
 	outboxRepo contracts.OutboxRepo,
 	committer *committer.PlanCommitter,
 	clock clock.Clock,
 ) *Interactor {
 	return &Interactor{
 		repo:      repo,
+		categories: categories,
 		outboxRepo: outboxRepo,
 		committer: committer,
 		clock:     clock,
@@ -67,16 +75,46 @@ func (it *Interactor) Execute(ctx context.Context, req Request) error {
 	if req.Category != nil {
 		cat = *req.Category
 	}
+	var category *domain.Category
+	if cat != "" && cat != product.Category() {
+		if category, err = it.findCategory(ctx, cat); err != nil {
+			return err
+		}
+	}
 
 	product.UpdateDetails(name, desc, cat, now)
+	if err := product.UpdateAttributes(req.Attributes, req.RemoveAttributes, now); err != nil {
+		return err
+	}
+
+	// Check the updated product against its category's schema. Legacy
+	// categories without a category record have no schema.
+	if category == nil && product.Category() != "" {
+		category, err = it.categories.FindBySlug(ctx, product.Category())
+		if err != nil && !errors.Is(err, domain.ErrCategoryNotFound) {
+			return err
+		}
+	}
+	if category != nil {
+		if err := category.ValidateAttributes(product.Attributes()); err != nil {
+			return err
+		}
+	}
 
-	// 3. Build commit plan
-	plan := commitplan.NewPlan()
+	// 3. Build commit plan, re-checking the category schema in the same
+	// transaction so that a concurrent category change cannot slip in
+	plan := committer.NewCheckedPlan()
+	if category != nil {
+		plan.Check(it.categoryCheck(category.Slug(), product))
+	}
 
 	// 4. Get mutations from repository
 	if mut := it.repo.UpdateMut(product); mut != nil {
 		plan.Add(mut)
 	}
+	for _, mut := range it.repo.AttributeMuts(product) {
+		plan.Add(mut)
+	}
 
 	// 5. Add outbox events
 	for _, event := range product.DomainEvents() {
@@ -87,7 +125,10 @@ func (it *Interactor) Execute(ctx context.Context, req Request) error {
 	}
 
 	// 6. Apply plan
-	if err := it.committer.Apply(ctx, plan); err != nil {
+	if err := it.committer.ApplyChecked(ctx, plan); err != nil {
+		if errors.Is(err, domain.ErrCategoryNotFound) {
+			return fmt.Errorf("%w: %q", domain.ErrUnknownCategory, category.Slug())
+		}
 		return err
 	}
 
@@ -116,6 +157,27 @@ func eventType(event domain.DomainEvent) string {
 	}
 }
 
+// findCategory loads the category with the given slug, returning
+// domain.ErrUnknownCategory if there is none.
+func (it *Interactor) findCategory(ctx context.Context, slug string) (*domain.Category, error) {
+	category, err := it.categories.FindBySlug(ctx, slug)
+	if err != nil {
+		if errors.Is(err, domain.ErrCategoryNotFound) {
+			return nil, fmt.Errorf("%w: %q", domain.ErrUnknownCategory, slug)
+		}
+		return nil, err
+	}
+	return category, nil
+}
+
 func generateID() string {
 	return fmt.Sprintf("id-%d", time.Now().UnixNano())
 }
+
+// categoryCheck validates the product's attributes against the category
+// as read in the committing transaction.
+func (it *Interactor) categoryCheck(slug string, product *domain.Product) committer.Check {
+	return it.categories.SlugCheck(slug, func(c *domain.Category) error {
+		return c.ValidateAttributes(product.Attributes())
+	})
+}
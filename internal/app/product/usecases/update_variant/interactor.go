@@ -0,0 +1,164 @@
+package updatevariant
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"product-catalog-service/internal/app/product/contracts"
+	"product-catalog-service/internal/app/product/domain"
+	"product-catalog-service/internal/pkg/clock"
+	"product-catalog-service/internal/pkg/committer"
+)
+
+// Request represents input for updating a product variant.
+// Nil fields are left unchanged.
+type Request struct {
+	ProductID string
+	VariantID string
+	SKU       *string
+	// Options replaces all option values when non-nil.
+	Options map[string]string
+	// PriceOverride is a decimal string; an empty string clears the
+	// override so the variant sells at the product price.
+	PriceOverride *string
+	Status        *domain.VariantStatus
+}
+
+// Interactor implements the UpdateVariant usecase following the Golden Mutation Pattern.
+type Interactor struct {
+	repo       contracts.ProductRepo
+	outboxRepo contracts.OutboxRepo
+	committer  *committer.PlanCommitter
+	clock      clock.Clock
+}
+
+// New creates a new UpdateVariant interactor.
+func New(
+	repo contracts.ProductRepo,
+	outboxRepo contracts.OutboxRepo,
+	committer *committer.PlanCommitter,
+	clock clock.Clock,
+) *Interactor {
+	return &Interactor{
+		repo:       repo,
+		outboxRepo: outboxRepo,
+		committer:  committer,
+		clock:      clock,
+	}
+}
+
+// Execute updates a variant of a product.
+// A new SKU must not be used by any other variant of any product.
+func (it *Interactor) Execute(ctx context.Context, req Request) error {
+	// 1. Load aggregate
+	product, err := it.repo.FindByID(ctx, req.ProductID)
+	if err != nil {
+		return fmt.Errorf("product not found: %w", err)
+	}
+	current, ok := product.Variant(req.VariantID)
+	if !ok {
+		return domain.ErrVariantNotFound
+	}
+
+	// 2. Build the updated variant entity from the current one
+	sku := current.SKU()
+	if req.SKU != nil {
+		sku = *req.SKU
+	}
+	options := current.Options()
+	if req.Options != nil {
+		options = req.Options
+	}
+	priceOverride := current.PriceOverride()
+	if req.PriceOverride != nil {
+		priceOverride = nil
+		if *req.PriceOverride != "" {
+			if priceOverride, err = domain.NewMoneyFromString(*req.PriceOverride); err != nil {
+				return fmt.Errorf("%w: price override: %v", domain.ErrInvalidVariant, err)
+			}
+		}
+	}
+	status := current.Status()
+	if req.Status != nil {
+		status = *req.Status
+	}
+	variant, err := domain.NewVariant(current.ID(), sku, options, priceOverride, status)
+	if err != nil {
+		return err
+	}
+
+	// 3. Check a changed SKU across products; the unique SKU index backs the check
+	if variant.SKU() != current.SKU() {
+		if productID, _, err := it.repo.FindVariantBySKU(ctx, variant.SKU()); err == nil {
+			if productID != product.ID() {
+				return fmt.Errorf("%w: %q", domain.ErrSKUTaken, variant.SKU())
+			}
+		} else if !errors.Is(err, domain.ErrVariantNotFound) {
+			return err
+		}
+	}
+
+	// 4. Call domain method (validates variant is unique within the product)
+	if err := product.UpdateVariant(variant, it.clock.Now()); err != nil {
+		return err
+	}
+
+	// 5. Build commit plan, re-checking a changed SKU in the same
+	// transaction so that a concurrent write of the same SKU fails with
+	// ErrSKUTaken
+	plan := committer.NewCheckedPlan()
+	if variant.SKU() != current.SKU() {
+		plan.Check(it.repo.SKUCheck(variant.SKU(), product.ID()))
+	}
+
+	// 6. Get mutations from repository
+	if mut := it.repo.UpdateMut(product); mut != nil {
+		plan.Add(mut)
+	}
+	for _, mut := range it.repo.VariantMuts(product) {
+		plan.Add(mut)
+	}
+
+	// 7. Add outbox events
+	for _, event := range product.DomainEvents() {
+		enriched := enrichEvent(product.ID(), event)
+		if outboxMut := it.outboxRepo.InsertMut(enriched); outboxMut != nil {
+			plan.Add(outboxMut)
+		}
+	}
+
+	// 8. Apply plan atomically
+	if err := it.committer.ApplyChecked(ctx, plan); err != nil {
+		return err
+	}
+
+	product.ClearDomainEvents()
+	return nil
+}
+
+func enrichEvent(aggregateID string, event domain.DomainEvent) *contracts.EnrichedEvent {
+	payload, _ := json.Marshal(event)
+	return &contracts.EnrichedEvent{
+		EventID:     generateID(),
+		EventType:   eventType(event),
+		AggregateID: aggregateID,
+		Payload:     payload,
+		Status:      "pending",
+	}
+}
+
+func eventType(event domain.DomainEvent) string {
+	switch event.(type) {
+	case domain.VariantUpdatedEvent:
+		return "variant.updated"
+	default:
+		return "unknown"
+	}
+}
+
+func generateID() string {
+	return fmt.Sprintf("id-%d", time.Now().UnixNano())
+}
@@ -0,0 +1,52 @@
+package mcategory
+
+import (
+	"time"
+
+	"cloud.google.com/go/spanner"
+)
+
+// Category represents a row in the categories table.
+// ParentID is null for root categories.
+type Category struct {
+	CategoryID string             `spanner:"category_id"`
+	Name       string             `spanner:"name"`
+	Slug       string             `spanner:"slug"`
+	ParentID   spanner.NullString `spanner:"parent_id"`
+	SortOrder  int64              `spanner:"sort_order"`
+	CreatedAt  time.Time          `spanner:"created_at"`
+	UpdatedAt  time.Time          `spanner:"updated_at"`
+}
+
+// Columns lists every column of the categories table.
+var Columns = []string{CategoryID, Name, Slug, ParentID, SortOrder, CreatedAt, UpdatedAt}
+
+// InsertMut returns a mutation to insert a new category.
+func InsertMut(c *Category) *spanner.Mutation {
+	if c == nil {
+		return nil
+	}
+	return spanner.Insert(TableName, Columns, []interface{}{
+		c.CategoryID,
+		c.Name,
+		c.Slug,
+		c.ParentID,
+		c.SortOrder,
+		c.CreatedAt,
+		c.UpdatedAt,
+	})
+}
+
+// UpdateMut returns a mutation to update specific fields of a category.
+func UpdateMut(categoryID string, updates map[string]interface{}) *spanner.Mutation {
+	if len(updates) == 0 {
+		return nil
+	}
+	updates[CategoryID] = categoryID
+	return spanner.UpdateMap(TableName, updates)
+}
+
+// DeleteMut returns a mutation to delete a category.
+func DeleteMut(categoryID string) *spanner.Mutation {
+	return spanner.Delete(TableName, spanner.Key{categoryID})
+}
@@ -0,0 +1,17 @@
+package mcategory
+
+// Field name constants for categories table.
+const (
+	TableName = "categories"
+
+	CategoryID = "category_id"
+	Name       = "name"
+	Slug       = "slug"
+	ParentID   = "parent_id"
+	SortOrder  = "sort_order"
+	CreatedAt  = "created_at"
+	UpdatedAt  = "updated_at"
+
+	// SlugIndex is the unique index on slug.
+	SlugIndex = "idx_categories_slug"
+)
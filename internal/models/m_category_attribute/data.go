@@ -0,0 +1,47 @@
+package mcategoryattribute
+
+import (
+	"cloud.google.com/go/spanner"
+)
+
+// CategoryAttribute represents a row in the category_attributes table.
+// AllowedValues is empty except for enum attributes.
+type CategoryAttribute struct {
+	CategoryID    string   `spanner:"category_id"`
+	AttributeKey  string   `spanner:"attribute_key"`
+	Type          string   `spanner:"type"`
+	Required      bool     `spanner:"required"`
+	AllowedValues []string `spanner:"allowed_values"`
+}
+
+// Columns lists all columns read from the table.
+func Columns() []string {
+	return []string{
+		CategoryID,
+		AttributeKey,
+		Type,
+		Required,
+		AllowedValues,
+	}
+}
+
+// InsertOrUpdateMut returns a mutation that writes an attribute definition,
+// replacing any stored definition of the same key.
+func InsertOrUpdateMut(a *CategoryAttribute) *spanner.Mutation {
+	if a == nil {
+		return nil
+	}
+	return spanner.InsertOrUpdate(TableName, Columns(), []interface{}{
+		a.CategoryID,
+		a.AttributeKey,
+		a.Type,
+		a.Required,
+		a.AllowedValues,
+	})
+}
+
+// DeleteMut returns a mutation that deletes one attribute definition of a
+// category.
+func DeleteMut(categoryID, key string) *spanner.Mutation {
+	return spanner.Delete(TableName, spanner.Key{categoryID, key})
+}
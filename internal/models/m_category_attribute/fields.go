@@ -0,0 +1,13 @@
+package mcategoryattribute
+
+// Field name constants for category_attributes table.
+// The table is interleaved in categories and keyed by (category_id, attribute_key).
+const (
+	TableName = "category_attributes"
+
+	CategoryID    = "category_id"
+	AttributeKey  = "attribute_key"
+	Type          = "type"
+	Required      = "required"
+	AllowedValues = "allowed_values"
+)
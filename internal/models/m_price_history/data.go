@@ -0,0 +1,76 @@
+package mpricehistory
+
+import (
+	"math/big"
+	"time"
+
+	"cloud.google.com/go/spanner"
+)
+
+// PriceHistoryEntry represents a row in the price_history table: the full
+// pricing state of a product after a change.
+type PriceHistoryEntry struct {
+	ProductID  string    `spanner:"product_id"`
+	RecordedAt time.Time `spanner:"recorded_at"`
+	EntryID    string    `spanner:"entry_id"`
+	// Reason lists the changed pricing fields, e.g. "base_price,discount".
+	Reason    string  `spanner:"reason"`
+	BasePrice big.Rat `spanner:"base_price"`
+	Currency  string  `spanner:"currency"`
+	// Discounts and ScheduledPrices hold JSON arrays of Discount and
+	// ScheduledPrice.
+	Discounts       string `spanner:"discounts"`
+	ScheduledPrices string `spanner:"scheduled_prices"`
+}
+
+// Discount is the JSON form of a discount in a history entry.
+// Numbers are exact decimal strings.
+type Discount struct {
+	ID         string    `json:"id"`
+	Kind       string    `json:"kind"`
+	Percentage string    `json:"percentage,omitempty"`
+	Amount     string    `json:"amount,omitempty"`
+	StartDate  time.Time `json:"start_date"`
+	EndDate    time.Time `json:"end_date"`
+	Priority   int       `json:"priority"`
+	Exclusive  bool      `json:"exclusive"`
+}
+
+// ScheduledPrice is the JSON form of a scheduled price in a history entry.
+type ScheduledPrice struct {
+	ID            string    `json:"id"`
+	Price         string    `json:"price"`
+	EffectiveFrom time.Time `json:"effective_from"`
+}
+
+// Columns lists all columns read from the table.
+func Columns() []string {
+	return []string{
+		ProductID,
+		RecordedAt,
+		EntryID,
+		Reason,
+		BasePrice,
+		Currency,
+		Discounts,
+		ScheduledPrices,
+	}
+}
+
+// InsertMut returns a mutation to append a history entry.
+// Entries are never updated or deleted.
+func InsertMut(e *PriceHistoryEntry) *spanner.Mutation {
+	if e == nil {
+		return nil
+	}
+	return spanner.Insert(TableName, Columns(), []interface{}{
+		e.ProductID,
+		e.RecordedAt,
+		e.EntryID,
+		e.Reason,
+		e.BasePrice,
+		e.Currency,
+		e.Discounts,
+		e.ScheduledPrices,
+	})
+}
@@ -0,0 +1,17 @@
+package mpricehistory
+
+// Field name constants for price_history table.
+// The table is append-only, interleaved in products and keyed by
+// (product_id, recorded_at, entry_id).
+const (
+	TableName = "price_history"
+
+	ProductID       = "product_id"
+	RecordedAt      = "recorded_at"
+	EntryID         = "entry_id"
+	Reason          = "reason"
+	BasePrice       = "base_price"
+	Currency        = "currency"
+	Discounts       = "discounts"
+	ScheduledPrices = "scheduled_prices"
+)
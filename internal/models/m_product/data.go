@@ -12,11 +12,10 @@ type Product struct {
 	Name                 string
 	Description          string
 	Category             string
-	BasePriceNumerator   int64
-	BasePriceDenominator int64
-	DiscountPercent      *spanner.NullNumeric
-	DiscountStartDate    spanner.NullTime
-	DiscountEndDate      spanner.NullTime
+	BasePrice            spanner.NullNumeric
+	BasePriceNumerator   spanner.NullInt64
+	BasePriceDenominator spanner.NullInt64
+	Currency             string
 	Status               string
 	CreatedAt            time.Time
 	UpdatedAt            time.Time
@@ -33,11 +32,8 @@ func InsertMut(p *Product) *spanner.Mutation {
 		Name,
 		Description,
 		Category,
-		BasePriceNumerator,
-		BasePriceDenominator,
-		DiscountPercent,
-		DiscountStartDate,
-		DiscountEndDate,
+		BasePrice,
+		Currency,
 		Status,
 		CreatedAt,
 		UpdatedAt,
@@ -47,11 +43,8 @@ func InsertMut(p *Product) *spanner.Mutation {
 		p.Name,
 		p.Description,
 		p.Category,
-		p.BasePriceNumerator,
-		p.BasePriceDenominator,
-		p.DiscountPercent,
-		p.DiscountStartDate,
-		p.DiscountEndDate,
+		p.BasePrice,
+		p.Currency,
 		p.Status,
 		p.CreatedAt,
 		p.UpdatedAt,
@@ -9,11 +9,12 @@ const (
 	Name      = "name"
 	Description = "description"
 	Category  = "category"
+	BasePrice            = "base_price"
+	// Legacy rational price columns, read only for rows written before
+	// base_price existed.
 	BasePriceNumerator   = "base_price_numerator"
 	BasePriceDenominator = "base_price_denominator"
-	DiscountPercent      = "discount_percent"
-	DiscountStartDate    = "discount_start_date"
-	DiscountEndDate      = "discount_end_date"
+	Currency             = "currency"
 	Status    = "status"
 	CreatedAt = "created_at"
 	UpdatedAt = "updated_at"
@@ -0,0 +1,51 @@
+package mproductattribute
+
+import (
+	"cloud.google.com/go/spanner"
+)
+
+// ProductAttribute represents a row in the product_attributes table.
+type ProductAttribute struct {
+	ProductID    string              `spanner:"product_id"`
+	AttributeKey string              `spanner:"attribute_key"`
+	Type         string              `spanner:"type"`
+	StringValue  spanner.NullString  `spanner:"string_value"`
+	NumberValue  spanner.NullNumeric `spanner:"number_value"`
+	BoolValue    spanner.NullBool    `spanner:"bool_value"`
+	Unit         spanner.NullString  `spanner:"unit"`
+}
+
+// Columns lists all columns read from the table.
+func Columns() []string {
+	return []string{
+		ProductID,
+		AttributeKey,
+		Type,
+		StringValue,
+		NumberValue,
+		BoolValue,
+		Unit,
+	}
+}
+
+// InsertOrUpdateMut returns a mutation that writes an attribute, replacing
+// any stored value of the same key.
+func InsertOrUpdateMut(a *ProductAttribute) *spanner.Mutation {
+	if a == nil {
+		return nil
+	}
+	return spanner.InsertOrUpdate(TableName, Columns(), []interface{}{
+		a.ProductID,
+		a.AttributeKey,
+		a.Type,
+		a.StringValue,
+		a.NumberValue,
+		a.BoolValue,
+		a.Unit,
+	})
+}
+
+// DeleteMut returns a mutation that deletes one attribute of a product.
+func DeleteMut(productID, key string) *spanner.Mutation {
+	return spanner.Delete(TableName, spanner.Key{productID, key})
+}
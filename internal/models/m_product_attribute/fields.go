@@ -0,0 +1,15 @@
+package mproductattribute
+
+// Field name constants for product_attributes table.
+// The table is interleaved in products and keyed by (product_id, attribute_key).
+const (
+	TableName = "product_attributes"
+
+	ProductID    = "product_id"
+	AttributeKey = "attribute_key"
+	Type         = "type"
+	StringValue  = "string_value"
+	NumberValue  = "number_value"
+	BoolValue    = "bool_value"
+	Unit         = "unit"
+)
@@ -0,0 +1,78 @@
+package mproductdiscount
+
+import (
+	"time"
+
+	"cloud.google.com/go/spanner"
+)
+
+// ProductDiscount represents a row in the product_discounts table.
+type ProductDiscount struct {
+	ProductID         string              `spanner:"product_id"`
+	DiscountID        string              `spanner:"discount_id"`
+	Kind              string              `spanner:"kind"`
+	Percentage        spanner.NullNumeric `spanner:"percentage"`
+	Amount            spanner.NullNumeric `spanner:"amount"`
+	AmountNumerator   spanner.NullInt64   `spanner:"amount_numerator"`
+	AmountDenominator spanner.NullInt64   `spanner:"amount_denominator"`
+	StartDate         time.Time           `spanner:"start_date"`
+	EndDate           time.Time           `spanner:"end_date"`
+	Priority          int64               `spanner:"priority"`
+	Exclusive         bool                `spanner:"exclusive"`
+}
+
+// Columns lists all columns read from the table.
+func Columns() []string {
+	return []string{
+		ProductID,
+		DiscountID,
+		Kind,
+		Percentage,
+		Amount,
+		AmountNumerator,
+		AmountDenominator,
+		StartDate,
+		EndDate,
+		Priority,
+		Exclusive,
+	}
+}
+
+// InsertOrUpdateMut returns a mutation that writes a product discount,
+// replacing any stored discount with the same ID. Legacy amount columns are
+// cleared so that a replaced discount never falls back to a stale amount.
+func InsertOrUpdateMut(d *ProductDiscount) *spanner.Mutation {
+	if d == nil {
+		return nil
+	}
+	return spanner.InsertOrUpdate(TableName, []string{
+		ProductID,
+		DiscountID,
+		Kind,
+		Percentage,
+		Amount,
+		AmountNumerator,
+		AmountDenominator,
+		StartDate,
+		EndDate,
+		Priority,
+		Exclusive,
+	}, []interface{}{
+		d.ProductID,
+		d.DiscountID,
+		d.Kind,
+		d.Percentage,
+		d.Amount,
+		spanner.NullInt64{},
+		spanner.NullInt64{},
+		d.StartDate,
+		d.EndDate,
+		d.Priority,
+		d.Exclusive,
+	})
+}
+
+// DeleteMut returns a mutation that deletes one discount of a product.
+func DeleteMut(productID, discountID string) *spanner.Mutation {
+	return spanner.Delete(TableName, spanner.Key{productID, discountID})
+}
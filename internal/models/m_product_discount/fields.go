@@ -0,0 +1,23 @@
+package mproductdiscount
+
+// Field name constants for product_discounts table.
+// The table is interleaved in products and keyed by (product_id, discount_id).
+const (
+	TableName = "product_discounts"
+
+	ProductID  = "product_id"
+	DiscountID = "discount_id"
+	Kind       = "kind"
+	Percentage = "percentage"
+	// Amount is the amount off for fixed_amount discounts and the target
+	// price for price_override discounts.
+	Amount = "amount"
+	// Legacy rational amount columns, read only for rows written before
+	// amount existed.
+	AmountNumerator   = "amount_numerator"
+	AmountDenominator = "amount_denominator"
+	StartDate         = "start_date"
+	EndDate           = "end_date"
+	Priority          = "priority"
+	Exclusive         = "exclusive"
+)
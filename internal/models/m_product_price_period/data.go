@@ -0,0 +1,40 @@
+package mproductpriceperiod
+
+import (
+	"math/big"
+	"time"
+
+	"cloud.google.com/go/spanner"
+)
+
+// ProductPricePeriod represents a row in the product_price_periods table:
+// the effective price of a product over [ValidFrom, ValidUntil).
+type ProductPricePeriod struct {
+	ProductID      string    `spanner:"product_id"`
+	ValidFrom      time.Time `spanner:"valid_from"`
+	ValidUntil     time.Time `spanner:"valid_until"`
+	EffectivePrice big.Rat   `spanner:"effective_price"`
+}
+
+// InsertMut returns a mutation to insert a price period.
+func InsertMut(p *ProductPricePeriod) *spanner.Mutation {
+	if p == nil {
+		return nil
+	}
+	return spanner.Insert(TableName, []string{
+		ProductID,
+		ValidFrom,
+		ValidUntil,
+		EffectivePrice,
+	}, []interface{}{
+		p.ProductID,
+		p.ValidFrom,
+		p.ValidUntil,
+		p.EffectivePrice,
+	})
+}
+
+// DeleteAllMut returns a mutation that deletes every price period of a product.
+func DeleteAllMut(productID string) *spanner.Mutation {
+	return spanner.Delete(TableName, spanner.Key{productID}.AsPrefix())
+}
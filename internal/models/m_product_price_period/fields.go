@@ -0,0 +1,12 @@
+package mproductpriceperiod
+
+// Field name constants for product_price_periods table.
+// The table is interleaved in products and keyed by (product_id, valid_from).
+const (
+	TableName = "product_price_periods"
+
+	ProductID      = "product_id"
+	ValidFrom      = "valid_from"
+	ValidUntil     = "valid_until"
+	EffectivePrice = "effective_price"
+)
@@ -0,0 +1,49 @@
+package mproductscheduledprice
+
+import (
+	"math/big"
+	"time"
+
+	"cloud.google.com/go/spanner"
+)
+
+// ProductScheduledPrice represents a row in the product_scheduled_prices table.
+type ProductScheduledPrice struct {
+	ProductID        string    `spanner:"product_id"`
+	ScheduledPriceID string    `spanner:"scheduled_price_id"`
+	Price            big.Rat   `spanner:"price"`
+	EffectiveFrom    time.Time `spanner:"effective_from"`
+}
+
+// Columns lists all columns read from the table.
+func Columns() []string {
+	return []string{
+		ProductID,
+		ScheduledPriceID,
+		Price,
+		EffectiveFrom,
+	}
+}
+
+// InsertMut returns a mutation to insert a new scheduled price.
+func InsertMut(s *ProductScheduledPrice) *spanner.Mutation {
+	if s == nil {
+		return nil
+	}
+	return spanner.Insert(TableName, []string{
+		ProductID,
+		ScheduledPriceID,
+		Price,
+		EffectiveFrom,
+	}, []interface{}{
+		s.ProductID,
+		s.ScheduledPriceID,
+		s.Price,
+		s.EffectiveFrom,
+	})
+}
+
+// DeleteAllMut returns a mutation that deletes every scheduled price of a product.
+func DeleteAllMut(productID string) *spanner.Mutation {
+	return spanner.Delete(TableName, spanner.Key{productID}.AsPrefix())
+}
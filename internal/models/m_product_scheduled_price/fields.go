@@ -0,0 +1,12 @@
+package mproductscheduledprice
+
+// Field name constants for product_scheduled_prices table.
+// The table is interleaved in products and keyed by (product_id, scheduled_price_id).
+const (
+	TableName = "product_scheduled_prices"
+
+	ProductID        = "product_id"
+	ScheduledPriceID = "scheduled_price_id"
+	Price            = "price"
+	EffectiveFrom    = "effective_from"
+)
@@ -0,0 +1,49 @@
+package mproductvariant
+
+import (
+	"cloud.google.com/go/spanner"
+)
+
+// ProductVariant represents a row in the product_variants table.
+// Options holds a JSON object of option values by option name.
+type ProductVariant struct {
+	ProductID     string              `spanner:"product_id"`
+	VariantID     string              `spanner:"variant_id"`
+	SKU           string              `spanner:"sku"`
+	Options       spanner.NullJSON    `spanner:"options"`
+	PriceOverride spanner.NullNumeric `spanner:"price_override"`
+	Status        string              `spanner:"status"`
+}
+
+// Columns lists all columns read from the table.
+func Columns() []string {
+	return []string{
+		ProductID,
+		VariantID,
+		SKU,
+		Options,
+		PriceOverride,
+		Status,
+	}
+}
+
+// InsertOrUpdateMut returns a mutation that writes a variant, replacing
+// any stored variant with the same ID.
+func InsertOrUpdateMut(v *ProductVariant) *spanner.Mutation {
+	if v == nil {
+		return nil
+	}
+	return spanner.InsertOrUpdate(TableName, Columns(), []interface{}{
+		v.ProductID,
+		v.VariantID,
+		v.SKU,
+		v.Options,
+		v.PriceOverride,
+		v.Status,
+	})
+}
+
+// DeleteMut returns a mutation that deletes one variant of a product.
+func DeleteMut(productID, variantID string) *spanner.Mutation {
+	return spanner.Delete(TableName, spanner.Key{productID, variantID})
+}
@@ -0,0 +1,17 @@
+package mproductvariant
+
+// Field name constants for product_variants table.
+// The table is interleaved in products and keyed by (product_id, variant_id).
+const (
+	TableName = "product_variants"
+
+	ProductID     = "product_id"
+	VariantID     = "variant_id"
+	SKU           = "sku"
+	Options       = "options"
+	PriceOverride = "price_override"
+	Status        = "status"
+
+	// SKUIndex is the unique index on sku.
+	SKUIndex = "idx_product_variants_sku"
+)
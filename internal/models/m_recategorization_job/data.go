@@ -0,0 +1,63 @@
+package mrecategorizationjob
+
+import (
+	"time"
+
+	"cloud.google.com/go/spanner"
+)
+
+// RecategorizationJob represents a row in the recategorization_jobs table.
+type RecategorizationJob struct {
+	JobID         string             `spanner:"job_id"`
+	FromCategory  string             `spanner:"from_category"`
+	ToCategory    string             `spanner:"to_category"`
+	Status        string             `spanner:"status"`
+	Batches       int64              `spanner:"batches"`
+	MovedProducts int64              `spanner:"moved_products"`
+	CreatedAt     time.Time          `spanner:"created_at"`
+	UpdatedAt     time.Time          `spanner:"updated_at"`
+	FinishedAt    spanner.NullTime   `spanner:"finished_at"`
+	Failure       spanner.NullString `spanner:"failure"`
+}
+
+// Columns lists every column of the recategorization_jobs table.
+var Columns = []string{JobID, FromCategory, ToCategory, Status, Batches, MovedProducts, CreatedAt, UpdatedAt, FinishedAt, Failure}
+
+// InsertMut returns a mutation to insert a new job.
+func InsertMut(j *RecategorizationJob) *spanner.Mutation {
+	if j == nil {
+		return nil
+	}
+	return spanner.Insert(TableName, Columns, []interface{}{
+		j.JobID,
+		j.FromCategory,
+		j.ToCategory,
+		j.Status,
+		j.Batches,
+		j.MovedProducts,
+		j.CreatedAt,
+		j.UpdatedAt,
+		j.FinishedAt,
+		j.Failure,
+	})
+}
+
+// UpdateMut returns a mutation to update specific fields of a job.
+func UpdateMut(jobID string, updates map[string]interface{}) *spanner.Mutation {
+	if len(updates) == 0 {
+		return nil
+	}
+	updates[JobID] = jobID
+	return spanner.UpdateMap(TableName, updates)
+}
+
+// InsertBatchMut returns a mutation recording a committed batch. It fails
+// with AlreadyExists when the batch was committed before.
+func InsertBatchMut(jobID string, batch, movedProducts int64, committedAt time.Time) *spanner.Mutation {
+	return spanner.Insert(BatchTableName, []string{JobID, Batch, MovedProducts, CommittedAt}, []interface{}{
+		jobID,
+		batch,
+		movedProducts,
+		committedAt,
+	})
+}
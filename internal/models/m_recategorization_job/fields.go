@@ -0,0 +1,30 @@
+package mrecategorizationjob
+
+// Field name constants for recategorization_jobs table.
+const (
+	TableName = "recategorization_jobs"
+
+	JobID         = "job_id"
+	FromCategory  = "from_category"
+	ToCategory    = "to_category"
+	Status        = "status"
+	Batches       = "batches"
+	MovedProducts = "moved_products"
+	CreatedAt     = "created_at"
+	UpdatedAt     = "updated_at"
+	FinishedAt    = "finished_at"
+	Failure       = "failure"
+
+	// StatusIndex indexes jobs by status.
+	StatusIndex = "idx_recategorization_jobs_status"
+)
+
+// Field name constants for recategorization_batches table.
+// The table is interleaved in recategorization_jobs and keyed by
+// (job_id, batch).
+const (
+	BatchTableName = "recategorization_batches"
+
+	Batch       = "batch"
+	CommittedAt = "committed_at"
+)
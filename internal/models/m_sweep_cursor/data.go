@@ -0,0 +1,31 @@
+package msweepcursor
+
+import (
+	"time"
+
+	"cloud.google.com/go/spanner"
+)
+
+// SweepCursor represents a row in the sweep_cursors table.
+type SweepCursor struct {
+	Name              string    `spanner:"name"`
+	PositionAt        time.Time `spanner:"position_at"`
+	PositionProductID string    `spanner:"position_product_id"`
+	UpdatedAt         time.Time `spanner:"updated_at"`
+}
+
+// Columns lists every column of the sweep_cursors table.
+var Columns = []string{Name, PositionAt, PositionProductID, UpdatedAt}
+
+// SaveMut returns a mutation to insert or overwrite a cursor.
+func SaveMut(c *SweepCursor) *spanner.Mutation {
+	if c == nil {
+		return nil
+	}
+	return spanner.InsertOrUpdate(TableName, Columns, []interface{}{
+		c.Name,
+		c.PositionAt,
+		c.PositionProductID,
+		c.UpdatedAt,
+	})
+}
@@ -0,0 +1,11 @@
+package msweepcursor
+
+// Field name constants for sweep_cursors table.
+const (
+	TableName = "sweep_cursors"
+
+	Name              = "name"
+	PositionAt        = "position_at"
+	PositionProductID = "position_product_id"
+	UpdatedAt         = "updated_at"
+)
@@ -0,0 +1,68 @@
+package committer
+
+import (
+	"context"
+	"errors"
+
+	"cloud.google.com/go/spanner"
+)
+
+// Check reads inside the transaction that applies a CheckedPlan. An error
+// aborts the transaction without writing anything and is returned by
+// ApplyChecked.
+type Check func(ctx context.Context, txn *spanner.ReadWriteTransaction) error
+
+// CheckedPlan is a set of mutations applied only if its checks pass in the
+// same read-write transaction. Use it when a decision depends on rows the
+// mutations do not touch, so that the rows cannot change between the check
+// and the commit.
+type CheckedPlan struct {
+	checks []Check
+	muts   []*spanner.Mutation
+}
+
+// NewCheckedPlan creates an empty CheckedPlan.
+func NewCheckedPlan() *CheckedPlan {
+	return &CheckedPlan{}
+}
+
+// Check adds a check run before the mutations are written.
+func (p *CheckedPlan) Check(check Check) {
+	if check != nil {
+		p.checks = append(p.checks, check)
+	}
+}
+
+// Add adds mutations to the plan.
+func (p *CheckedPlan) Add(muts ...*spanner.Mutation) {
+	p.muts = append(p.muts, muts...)
+}
+
+// Unless returns a check that passes when check fails with an error
+// matching target, e.g. a lookup whose absence is acceptable.
+func Unless(check Check, target error) Check {
+	return func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		if err := check(ctx, txn); err != nil && !errors.Is(err, target) {
+			return err
+		}
+		return nil
+	}
+}
+
+// ApplyChecked runs the checks of the plan and applies its mutations in one
+// read-write transaction. The transaction may be retried, running the checks
+// again.
+func (c *PlanCommitter) ApplyChecked(ctx context.Context, plan *CheckedPlan) error {
+	if plan == nil {
+		return nil
+	}
+	_, err := c.client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
+		for _, check := range plan.checks {
+			if err := check(ctx, txn); err != nil {
+				return err
+			}
+		}
+		return txn.BufferWrite(plan.muts)
+	})
+	return err
+}
@@ -0,0 +1,142 @@
+// Package filter parses AIP-160 style filter expressions such as
+//
+//	category = "shoes" AND price < 50 AND has_discount = true
+//
+// into a typed AST validated against an allow-list of fields.
+//
+// The supported subset is: comparisons of a field with a literal using
+// = != < <= > >=, grouping with parentheses, AND, OR and NOT (or "-").
+// A schema entry "prefix.*" allows any field "prefix.name", e.g.
+// attributes.color = "red".
+// As in AIP-160, OR binds tighter than AND: "a AND b OR c" means
+// "a AND (b OR c)".
+package filter
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// Type is the type of a filterable field.
+type Type int
+
+const (
+	// TypeString fields compare with quoted strings using = and !=.
+	TypeString Type = iota + 1
+	// TypeNumber fields compare with decimal numbers, e.g. 49.99.
+	TypeNumber
+	// TypeBool fields compare with true or false using = and !=.
+	TypeBool
+	// TypeTimestamp fields compare with quoted RFC 3339 timestamps or
+	// dates, e.g. "2026-01-01" (midnight UTC).
+	TypeTimestamp
+	// TypeAny fields take the type of the literal they are compared with:
+	// a quoted string, a number, or true or false.
+	TypeAny
+)
+
+func (t Type) String() string {
+	switch t {
+	case TypeString:
+		return "string"
+	case TypeNumber:
+		return "number"
+	case TypeBool:
+		return "bool"
+	case TypeTimestamp:
+		return "timestamp"
+	case TypeAny:
+		return "any"
+	default:
+		return "unknown"
+	}
+}
+
+// Schema is the allow-list of filterable fields and their types. A name
+// ending in ".*" allows every field with that prefix followed by a name
+// without dots.
+type Schema map[string]Type
+
+// lookup returns the type of a field, matching wildcard entries too.
+func (s Schema) lookup(field string) (Type, bool) {
+	if t, ok := s[field]; ok {
+		return t, true
+	}
+	i := strings.LastIndexByte(field, '.')
+	if i <= 0 || i == len(field)-1 {
+		return 0, false
+	}
+	t, ok := s[field[:i]+".*"]
+	return t, ok
+}
+
+// Operator is a comparison operator.
+type Operator string
+
+const (
+	OpEqual        Operator = "="
+	OpNotEqual     Operator = "!="
+	OpLess         Operator = "<"
+	OpLessEqual    Operator = "<="
+	OpGreater      Operator = ">"
+	OpGreaterEqual Operator = ">="
+)
+
+// Expr is a node of a parsed filter.
+type Expr interface {
+	isExpr()
+}
+
+// And matches when both sides match.
+type And struct {
+	Left, Right Expr
+}
+
+// Or matches when either side matches.
+type Or struct {
+	Left, Right Expr
+}
+
+// Not matches when Expr does not match.
+type Not struct {
+	Expr Expr
+}
+
+// Comparison compares a field with a literal value of the field type.
+type Comparison struct {
+	Field string
+	Op    Operator
+	Value Value
+}
+
+func (And) isExpr()        {}
+func (Or) isExpr()         {}
+func (Not) isExpr()        {}
+func (Comparison) isExpr() {}
+
+// Value is a typed literal; only the field matching Type is set. Type is
+// never TypeAny.
+type Value struct {
+	Type   Type
+	String string
+	Number *big.Rat
+	Bool   bool
+	Time   time.Time
+}
+
+// Error is a filter syntax or validation error at a byte offset of the
+// filter string.
+type Error struct {
+	Pos int
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("invalid filter at position %d: %s", e.Pos, e.Msg)
+}
+
+func errorf(pos int, format string, args ...interface{}) *Error {
+	return &Error{Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,352 @@
+package filter
+
+import (
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MaxLength bounds the length of a filter string.
+const MaxLength = 2048
+
+// maxDepth bounds the nesting of parentheses and NOT.
+const maxDepth = 32
+
+// Parse parses a filter string and validates it against schema.
+// An empty or blank filter yields a nil Expr.
+// Errors are of type *Error.
+func Parse(input string, schema Schema) (Expr, error) {
+	if len(input) > MaxLength {
+		return nil, errorf(MaxLength, "filter is longer than %d bytes", MaxLength)
+	}
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens, schema: schema}
+	if p.peek().kind == tokEOF {
+		return nil, nil
+	}
+
+	expr, err := p.expression(0)
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, errorf(t.pos, "unexpected %s; expected AND, OR or end of filter", t)
+	}
+	return expr, nil
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokString
+	tokNumber
+	tokOperator
+	tokLParen
+	tokRParen
+	tokAnd
+	tokOr
+	tokNot
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of filter"
+	case tokString:
+		return "string " + t.text
+	default:
+		return "\"" + t.text + "\""
+	}
+}
+
+// lex splits the input into tokens. String tokens keep their unquoted text.
+func lex(input string) ([]token, error) {
+	var tokens []token
+	i := 0
+	for i < len(input) {
+		c := input[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{kind: tokLParen, text: "(", pos: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokRParen, text: ")", pos: i})
+			i++
+		case c == '"' || c == '\'':
+			s, end, err := lexString(input, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokString, text: s, pos: i})
+			i = end
+		case c == '=' || c == '<' || c == '>' || c == '!' || c == ':':
+			op := string(c)
+			if i+1 < len(input) && input[i+1] == '=' && c != '=' && c != ':' {
+				op += "="
+			}
+			if op == "!" {
+				return nil, errorf(i, "unexpected \"!\"; use != or NOT")
+			}
+			tokens = append(tokens, token{kind: tokOperator, text: op, pos: i})
+			i += len(op)
+		case c == '-' && i+1 < len(input) && isDigit(input[i+1]):
+			end := lexNumberEnd(input, i+1)
+			tokens = append(tokens, token{kind: tokNumber, text: input[i:end], pos: i})
+			i = end
+		case c == '-':
+			tokens = append(tokens, token{kind: tokNot, text: "-", pos: i})
+			i++
+		case isDigit(c):
+			end := lexNumberEnd(input, i)
+			tokens = append(tokens, token{kind: tokNumber, text: input[i:end], pos: i})
+			i = end
+		case isIdentStart(c):
+			end := i
+			for end < len(input) && isIdentPart(input[end]) {
+				end++
+			}
+			text := input[i:end]
+			kind := tokIdent
+			switch text {
+			case "AND":
+				kind = tokAnd
+			case "OR":
+				kind = tokOr
+			case "NOT":
+				kind = tokNot
+			}
+			tokens = append(tokens, token{kind: kind, text: text, pos: i})
+			i = end
+		default:
+			return nil, errorf(i, "unexpected character %q", c)
+		}
+	}
+	return append(tokens, token{kind: tokEOF, pos: len(input)}), nil
+}
+
+// lexString reads a quoted string starting at input[start] and returns its
+// unescaped text and the offset after the closing quote.
+func lexString(input string, start int) (string, int, error) {
+	quote := input[start]
+	var b strings.Builder
+	for i := start + 1; i < len(input); i++ {
+		switch input[i] {
+		case '\\':
+			if i+1 >= len(input) {
+				return "", 0, errorf(i, "unterminated escape sequence")
+			}
+			i++
+			b.WriteByte(input[i])
+		case quote:
+			return b.String(), i + 1, nil
+		default:
+			b.WriteByte(input[i])
+		}
+	}
+	return "", 0, errorf(start, "unterminated string")
+}
+
+func lexNumberEnd(input string, i int) int {
+	for i < len(input) && (isDigit(input[i]) || input[i] == '.') {
+		i++
+	}
+	return i
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+func isLetter(c byte) bool { return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' }
+
+func isIdentStart(c byte) bool { return c == '_' || isLetter(c) }
+
+func isIdentPart(c byte) bool { return c == '_' || c == '.' || isLetter(c) || isDigit(c) }
+
+type parser struct {
+	tokens []token
+	pos    int
+	schema Schema
+}
+
+func (p *parser) peek() token { return p.tokens[p.pos] }
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+// expression = factor { AND factor }
+func (p *parser) expression(depth int) (Expr, error) {
+	left, err := p.factor(depth)
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokAnd {
+		p.next()
+		right, err := p.factor(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = And{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+// factor = term { OR term }
+func (p *parser) factor(depth int) (Expr, error) {
+	left, err := p.term(depth)
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokOr {
+		p.next()
+		right, err := p.term(depth)
+		if err != nil {
+			return nil, err
+		}
+		left = Or{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+// term = [ NOT | "-" ] simple
+// simple = "(" expression ")" | comparison
+func (p *parser) term(depth int) (Expr, error) {
+	t := p.peek()
+	if depth >= maxDepth {
+		return nil, errorf(t.pos, "filter is nested deeper than %d levels", maxDepth)
+	}
+
+	switch t.kind {
+	case tokNot:
+		p.next()
+		expr, err := p.term(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return Not{Expr: expr}, nil
+	case tokLParen:
+		p.next()
+		expr, err := p.expression(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokRParen {
+			return nil, errorf(closing.pos, "unexpected %s; expected \")\"", closing)
+		}
+		return expr, nil
+	case tokIdent:
+		return p.comparison()
+	default:
+		return nil, errorf(t.pos, "unexpected %s; expected a field name, NOT or \"(\"", t)
+	}
+}
+
+// comparison = field operator value
+func (p *parser) comparison() (Expr, error) {
+	field := p.next()
+	fieldType, ok := p.schema.lookup(field.text)
+	if !ok {
+		return nil, errorf(field.pos, "unknown field %q; filterable fields are %s", field.text, p.fieldList())
+	}
+
+	opTok := p.next()
+	if opTok.kind != tokOperator {
+		return nil, errorf(opTok.pos, "unexpected %s; expected a comparison operator after %q", opTok, field.text)
+	}
+	if opTok.text == ":" {
+		return nil, errorf(opTok.pos, "the has operator \":\" is not supported")
+	}
+	op := Operator(opTok.text)
+	if (fieldType == TypeString || fieldType == TypeBool) && op != OpEqual && op != OpNotEqual {
+		return nil, errorf(opTok.pos, "operator %s is not supported for %s field %q; use = or !=", op, fieldType, field.text)
+	}
+
+	valueTok := p.next()
+	value, err := parseValue(valueTok, fieldType, field.text)
+	if err != nil {
+		return nil, err
+	}
+	if fieldType == TypeAny && (value.Type == TypeString || value.Type == TypeBool) && op != OpEqual && op != OpNotEqual {
+		return nil, errorf(opTok.pos, "operator %s is not supported for %s values of field %q; use = or !=", op, value.Type, field.text)
+	}
+	return Comparison{Field: field.text, Op: op, Value: value}, nil
+}
+
+func parseValue(t token, fieldType Type, field string) (Value, error) {
+	mismatch := func(want string) error {
+		return errorf(t.pos, "field %q is a %s and must be compared with %s, got %s", field, fieldType, want, t)
+	}
+
+	switch fieldType {
+	case TypeString:
+		if t.kind != tokString {
+			return Value{}, mismatch("a quoted string")
+		}
+		return Value{Type: TypeString, String: t.text}, nil
+	case TypeNumber:
+		if t.kind != tokNumber {
+			return Value{}, mismatch("a number")
+		}
+		n, ok := new(big.Rat).SetString(t.text)
+		if !ok {
+			return Value{}, errorf(t.pos, "invalid number %q", t.text)
+		}
+		return Value{Type: TypeNumber, Number: n}, nil
+	case TypeBool:
+		if t.kind != tokIdent || (t.text != "true" && t.text != "false") {
+			return Value{}, mismatch("true or false")
+		}
+		return Value{Type: TypeBool, Bool: t.text == "true"}, nil
+	case TypeTimestamp:
+		if t.kind != tokString {
+			return Value{}, mismatch("a quoted timestamp")
+		}
+		ts, err := time.Parse(time.RFC3339Nano, t.text)
+		if err != nil {
+			ts, err = time.Parse("2006-01-02", t.text)
+		}
+		if err != nil {
+			return Value{}, errorf(t.pos, "invalid timestamp %q; use RFC 3339, e.g. \"2026-01-01T00:00:00Z\", or a date", t.text)
+		}
+		return Value{Type: TypeTimestamp, Time: ts}, nil
+	case TypeAny:
+		switch {
+		case t.kind == tokString:
+			return parseValue(t, TypeString, field)
+		case t.kind == tokNumber:
+			return parseValue(t, TypeNumber, field)
+		case t.kind == tokIdent && (t.text == "true" || t.text == "false"):
+			return parseValue(t, TypeBool, field)
+		}
+		return Value{}, errorf(t.pos, "field %q must be compared with a quoted string, a number, true or false, got %s", field, t)
+	default:
+		return Value{}, errorf(t.pos, "field %q has an unsupported type", field)
+	}
+}
+
+// fieldList returns the filterable fields for error messages.
+func (p *parser) fieldList() string {
+	names := make([]string, 0, len(p.schema))
+	for name := range p.schema {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
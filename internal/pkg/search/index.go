@@ -0,0 +1,171 @@
+package search
+
+import (
+	"math"
+	"sort"
+	"sync"
+)
+
+// BM25 parameters.
+const (
+	k1 = 1.2
+	b  = 0.75
+)
+
+// Hit is a document matching a search.
+type Hit struct {
+	ID    string
+	Score float64
+}
+
+// Index is an in-memory inverted index over documents with named text
+// fields. It is safe for concurrent use.
+type Index struct {
+	// weights boosts each field's contribution to the score; fields
+	// without a weight are not indexed.
+	weights map[string]float64
+
+	mu sync.RWMutex
+	// postings maps field -> term -> document ID -> term frequency.
+	postings map[string]map[string]map[string]int
+	// lengths maps field -> document ID -> number of terms.
+	lengths map[string]map[string]int
+	// totals maps field -> total number of terms over all documents.
+	totals map[string]int
+	// docs maps document ID -> field -> distinct terms, for removal.
+	docs map[string]map[string][]string
+}
+
+// NewIndex creates an empty index over the fields of weights, e.g.
+// {"name": 3, "description": 1}.
+func NewIndex(weights map[string]float64) *Index {
+	idx := &Index{
+		weights:  weights,
+		postings: map[string]map[string]map[string]int{},
+		lengths:  map[string]map[string]int{},
+		totals:   map[string]int{},
+		docs:     map[string]map[string][]string{},
+	}
+	for field := range weights {
+		idx.postings[field] = map[string]map[string]int{}
+		idx.lengths[field] = map[string]int{}
+	}
+	return idx
+}
+
+// Len returns the number of indexed documents.
+func (idx *Index) Len() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return len(idx.docs)
+}
+
+// Upsert indexes a document, replacing any previous version.
+func (idx *Index) Upsert(id string, fields map[string]string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	idx.remove(id)
+	doc := map[string][]string{}
+	for field, text := range fields {
+		postings, ok := idx.postings[field]
+		if !ok {
+			continue
+		}
+		tokens := Tokenize(text)
+		freqs := map[string]int{}
+		for _, t := range tokens {
+			freqs[t.Term]++
+		}
+		for term, n := range freqs {
+			if postings[term] == nil {
+				postings[term] = map[string]int{}
+			}
+			postings[term][id] = n
+			doc[field] = append(doc[field], term)
+		}
+		idx.lengths[field][id] = len(tokens)
+		idx.totals[field] += len(tokens)
+	}
+	idx.docs[id] = doc
+}
+
+// Delete removes a document; unknown IDs are ignored.
+func (idx *Index) Delete(id string) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.remove(id)
+}
+
+func (idx *Index) remove(id string) {
+	doc, ok := idx.docs[id]
+	if !ok {
+		return
+	}
+	for field, terms := range doc {
+		for _, term := range terms {
+			delete(idx.postings[field][term], id)
+			if len(idx.postings[field][term]) == 0 {
+				delete(idx.postings[field], term)
+			}
+		}
+	}
+	for field, lengths := range idx.lengths {
+		idx.totals[field] -= lengths[id]
+		delete(lengths, id)
+	}
+	delete(idx.docs, id)
+}
+
+// Search returns the documents matching any of the terms, best first with
+// ties broken by ID, skipping offset and returning up to limit hits. more
+// reports whether further hits exist. Terms must be normalized, see Terms.
+//
+// Each field is scored with BM25 and the scores are summed by field weight.
+func (idx *Index) Search(terms []string, offset, limit int) (hits []Hit, more bool) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	n := float64(len(idx.docs))
+	scores := map[string]float64{}
+	for field, weight := range idx.weights {
+		docs := len(idx.lengths[field])
+		if docs == 0 || idx.totals[field] == 0 {
+			continue
+		}
+		avgLen := float64(idx.totals[field]) / float64(docs)
+		for _, term := range terms {
+			postings := idx.postings[field][term]
+			if len(postings) == 0 {
+				continue
+			}
+			df := float64(len(postings))
+			idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+			for id, tf := range postings {
+				f := float64(tf)
+				norm := f + k1*(1-b+b*float64(idx.lengths[field][id])/avgLen)
+				scores[id] += weight * idf * f * (k1 + 1) / norm
+			}
+		}
+	}
+
+	all := make([]Hit, 0, len(scores))
+	for id, score := range scores {
+		all = append(all, Hit{ID: id, Score: score})
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].Score != all[j].Score {
+			return all[i].Score > all[j].Score
+		}
+		return all[i].ID < all[j].ID
+	})
+
+	if offset >= len(all) {
+		return nil, false
+	}
+	end := offset + limit
+	if end >= len(all) {
+		return all[offset:], false
+	}
+	return all[offset:end], true
+}
@@ -0,0 +1,288 @@
+package search
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Suggestion is a phrase completing a prefix.
+type Suggestion struct {
+	// Text is the phrase as it was first indexed.
+	Text string
+	// Kind is the kind the phrase was indexed under, e.g. "name".
+	Kind string
+	// Count is the number of documents holding the phrase.
+	Count int
+	// Typos is the number of edits the prefix needed to match.
+	Typos int
+}
+
+// Suggester is an in-memory prefix index of short phrases, such as product
+// names and categories, for type-ahead completion. A prefix matches a
+// phrase at its start or at the start of any of its words, with a few
+// typos allowed in longer prefixes. It is safe for concurrent use.
+type Suggester struct {
+	mu      sync.RWMutex
+	phrases map[phraseKey]*phrase
+	// docs maps document ID -> the phrases it holds, for removal.
+	docs map[string][]phraseKey
+	// keys are the completion keys sorted by key; rebuilt when stale.
+	keys  []completionKey
+	stale bool
+}
+
+type phraseKey struct {
+	kind string
+	norm string
+}
+
+type phrase struct {
+	text string
+	kind string
+	docs map[string]bool
+}
+
+// completionKey is a normalized phrase suffix starting at a word.
+type completionKey struct {
+	key    string
+	phrase *phrase
+	// start is true for the suffix starting at the phrase's first word.
+	start bool
+}
+
+// NewSuggester creates an empty Suggester.
+func NewSuggester() *Suggester {
+	return &Suggester{
+		phrases: map[phraseKey]*phrase{},
+		docs:    map[string][]phraseKey{},
+	}
+}
+
+// Set replaces the phrases of a document, given by kind, e.g.
+// {"name": "Trail Running Shoes", "category": "shoes"}. Phrases without
+// letters or digits are skipped.
+func (s *Suggester) Set(id string, phrases map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.remove(id)
+	for kind, text := range phrases {
+		norm := normalizePhrase(text)
+		if norm == "" {
+			continue
+		}
+		key := phraseKey{kind: kind, norm: norm}
+		p, ok := s.phrases[key]
+		if !ok {
+			p = &phrase{text: text, kind: kind, docs: map[string]bool{}}
+			s.phrases[key] = p
+			s.stale = true
+		}
+		p.docs[id] = true
+		s.docs[id] = append(s.docs[id], key)
+	}
+}
+
+// Delete removes a document; it is a no-op for unknown IDs.
+func (s *Suggester) Delete(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.remove(id)
+}
+
+func (s *Suggester) remove(id string) {
+	for _, key := range s.docs[id] {
+		p := s.phrases[key]
+		delete(p.docs, id)
+		if len(p.docs) == 0 {
+			delete(s.phrases, key)
+			s.stale = true
+		}
+	}
+	delete(s.docs, id)
+}
+
+// Suggest returns up to limit phrases completing prefix. Phrases matching
+// without typos come first, then those matching at their start, then the
+// phrases held by more documents. Typos are only tolerated after the
+// first letter: one from 4 characters on and two from 8 on.
+func (s *Suggester) Suggest(prefix string, limit int) []Suggestion {
+	q := normalizePhrase(prefix)
+	if q == "" || limit <= 0 {
+		return nil
+	}
+	// A trailing space asks for the next word
+	if strings.TrimRightFunc(prefix, unicode.IsSpace) != prefix {
+		q += " "
+	}
+	query := []rune(q)
+	maxTypos := allowedTypos(len(query))
+
+	s.refresh()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	scope := q
+	if maxTypos > 0 {
+		scope = string(query[0])
+	}
+	lo := sort.Search(len(s.keys), func(i int) bool { return s.keys[i].key >= scope })
+	hi := sort.Search(len(s.keys), func(i int) bool {
+		return s.keys[i].key > scope && !strings.HasPrefix(s.keys[i].key, scope)
+	})
+
+	type candidate struct {
+		Suggestion
+		start bool
+	}
+	best := map[*phrase]candidate{}
+	for _, k := range s.keys[lo:hi] {
+		typos := 0
+		if !strings.HasPrefix(k.key, q) {
+			typos = prefixDistance(query, []rune(k.key), maxTypos)
+			if typos > maxTypos {
+				continue
+			}
+		}
+		if old, seen := best[k.phrase]; seen && (old.Typos < typos || old.Typos == typos && (old.start || !k.start)) {
+			continue
+		}
+		best[k.phrase] = candidate{
+			Suggestion: Suggestion{
+				Text:  k.phrase.text,
+				Kind:  k.phrase.kind,
+				Count: len(k.phrase.docs),
+				Typos: typos,
+			},
+			start: k.start,
+		}
+	}
+
+	candidates := make([]candidate, 0, len(best))
+	for _, c := range best {
+		candidates = append(candidates, c)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.Typos != b.Typos {
+			return a.Typos < b.Typos
+		}
+		if a.start != b.start {
+			return a.start
+		}
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if a.Text != b.Text {
+			return a.Text < b.Text
+		}
+		return a.Kind < b.Kind
+	})
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	out := make([]Suggestion, 0, len(candidates))
+	for _, c := range candidates {
+		out = append(out, c.Suggestion)
+	}
+	return out
+}
+
+// refresh rebuilds the sorted completion keys if phrases changed.
+func (s *Suggester) refresh() {
+	s.mu.RLock()
+	stale := s.stale
+	s.mu.RUnlock()
+	if !stale {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.stale {
+		return
+	}
+	keys := make([]completionKey, 0, len(s.keys))
+	for key, p := range s.phrases {
+		start := true
+		rest := key.norm
+		for {
+			keys = append(keys, completionKey{key: rest, phrase: p, start: start})
+			i := strings.IndexByte(rest, ' ')
+			if i < 0 {
+				break
+			}
+			rest, start = rest[i+1:], false
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].key < keys[j].key })
+	s.keys = keys
+	s.stale = false
+}
+
+// normalizePhrase lowercases text and joins its words of letters and
+// digits with single spaces.
+func normalizePhrase(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, " ")
+}
+
+// allowedTypos returns the number of typos tolerated in a prefix of n
+// characters.
+func allowedTypos(n int) int {
+	switch {
+	case n >= 8:
+		return 2
+	case n >= 4:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// prefixDistance returns the smallest edit distance between q and a prefix
+// of s, counting insertions, deletions, substitutions and transpositions of
+// adjacent characters. It returns max+1 as soon as the distance exceeds max.
+func prefixDistance(q, s []rune, max int) int {
+	if len(s) > len(q)+max {
+		s = s[:len(q)+max]
+	}
+	prev2 := make([]int, len(s)+1)
+	prev := make([]int, len(s)+1)
+	cur := make([]int, len(s)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(q); i++ {
+		cur[0] = i
+		rowMin := cur[0]
+		for j := 1; j <= len(s); j++ {
+			cost := 1
+			if q[i-1] == s[j-1] {
+				cost = 0
+			}
+			d := min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && q[i-1] == s[j-2] && q[i-2] == s[j-1] {
+				d = min(d, prev2[j-2]+1)
+			}
+			cur[j] = d
+			rowMin = min(rowMin, d)
+		}
+		if rowMin > max {
+			return max + 1
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+
+	best := prev[0]
+	for _, d := range prev {
+		best = min(best, d)
+	}
+	return best
+}
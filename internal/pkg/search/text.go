@@ -0,0 +1,151 @@
+// Package search is a small embedded full-text engine: tokenization with
+// light English stemming, a weighted BM25 inverted index and highlighting.
+// It backs product search where Spanner search indexes are unavailable,
+// e.g. on the emulator.
+package search
+
+import (
+	"html"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// stopWords are not indexed or searched.
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "by": true, "for": true, "from": true, "in": true, "is": true,
+	"it": true, "of": true, "on": true, "or": true, "the": true, "to": true,
+	"with": true,
+}
+
+// Token is a word of a text with its normalized term and byte offsets.
+type Token struct {
+	// Term is the lowercased, stemmed word.
+	Term  string
+	Start int
+	End   int
+}
+
+// Tokenize splits text into words of letters and digits and normalizes
+// them. Stop words are dropped.
+func Tokenize(text string) []Token {
+	var tokens []Token
+	start := -1
+	for i, r := range text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			tokens = appendToken(tokens, text, start, i)
+			start = -1
+		}
+	}
+	if start >= 0 {
+		tokens = appendToken(tokens, text, start, len(text))
+	}
+	return tokens
+}
+
+func appendToken(tokens []Token, text string, start, end int) []Token {
+	word := strings.ToLower(text[start:end])
+	if stopWords[word] {
+		return tokens
+	}
+	return append(tokens, Token{Term: Stem(word), Start: start, End: end})
+}
+
+// Words returns the lowercased words of a query without stop words, in
+// order and without duplicates, before stemming.
+func Words(query string) []string {
+	var words []string
+	seen := map[string]bool{}
+	for _, t := range Tokenize(query) {
+		word := strings.ToLower(query[t.Start:t.End])
+		if !seen[word] {
+			seen[word] = true
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+// Terms returns the distinct normalized terms of a query, in order.
+func Terms(query string) []string {
+	var terms []string
+	seen := map[string]bool{}
+	for _, t := range Tokenize(query) {
+		if !seen[t.Term] {
+			seen[t.Term] = true
+			terms = append(terms, t.Term)
+		}
+	}
+	return terms
+}
+
+// Stem reduces an English word to a stem by stripping common plural and
+// verb suffixes, so "running", "runs" and "run" share a term. It is a
+// light stemmer: it prefers leaving a word alone to over-stemming.
+func Stem(word string) string {
+	if utf8.RuneCountInString(word) <= 3 {
+		return word
+	}
+
+	switch {
+	case strings.HasSuffix(word, "ies") && len(word) > 4:
+		word = word[:len(word)-3] + "y"
+	case strings.HasSuffix(word, "sses"):
+		word = word[:len(word)-2]
+	case strings.HasSuffix(word, "shes"), strings.HasSuffix(word, "ches"),
+		strings.HasSuffix(word, "xes"), strings.HasSuffix(word, "zes"):
+		word = word[:len(word)-2]
+	case strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") &&
+		!strings.HasSuffix(word, "us") && !strings.HasSuffix(word, "is"):
+		word = word[:len(word)-1]
+	}
+
+	for _, suffix := range []string{"ing", "ed"} {
+		stem := strings.TrimSuffix(word, suffix)
+		if stem == word || len(stem) < 3 || !hasVowel(stem) {
+			continue
+		}
+		// "running" -> "runn" -> "run"
+		if n := len(stem); stem[n-1] == stem[n-2] && !strings.ContainsRune("lsz", rune(stem[n-1])) {
+			stem = stem[:n-1]
+		}
+		return stem
+	}
+	return word
+}
+
+func hasVowel(s string) bool {
+	return strings.ContainsAny(s, "aeiouy")
+}
+
+// Highlight wraps the words of text whose terms are in terms with pre and
+// post, e.g. "<em>" and "</em>". The result is HTML: text is escaped, so
+// only the markers are markup, while pre and post are written as given.
+func Highlight(text string, terms []string, pre, post string) string {
+	want := make(map[string]bool, len(terms))
+	for _, t := range terms {
+		want[t] = true
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, t := range Tokenize(text) {
+		if !want[t.Term] {
+			continue
+		}
+		b.WriteString(html.EscapeString(text[last:t.Start]))
+		b.WriteString(pre)
+		b.WriteString(html.EscapeString(text[t.Start:t.End]))
+		b.WriteString(post)
+		last = t.End
+	}
+	b.WriteString(html.EscapeString(text[last:]))
+	return b.String()
+}
@@ -3,10 +3,18 @@ package services
 import (
     "cloud.google.com/go/spanner"
     "context"
+    "crypto/rand"
+    "log"
+    "os"
+    "time"
 
     // Domain contracts
     "product-catalog-service/internal/app/product/contracts"
 
+    // Domain services
+    "product-catalog-service/internal/app/product/domain"
+    domainservices "product-catalog-service/internal/app/product/domain/services"
+
     // Repositories
     "product-catalog-service/internal/app/product/repo"
 
@@ -17,25 +25,57 @@ import (
     "product-catalog-service/internal/app/product/usecases/deactivate_product"
     "product-catalog-service/internal/app/product/usecases/apply_discount"
     "product-catalog-service/internal/app/product/usecases/remove_discount"
+    "product-catalog-service/internal/app/product/usecases/schedule_price"
+    "product-catalog-service/internal/app/product/usecases/cancel_scheduled_price"
+    "product-catalog-service/internal/app/product/usecases/add_variant"
+    "product-catalog-service/internal/app/product/usecases/update_variant"
+    "product-catalog-service/internal/app/product/usecases/remove_variant"
+    "product-catalog-service/internal/app/product/usecases/sweep_discounts"
+    "product-catalog-service/internal/app/product/usecases/backfill_price_periods"
+    "product-catalog-service/internal/app/product/usecases/create_category"
+    "product-catalog-service/internal/app/product/usecases/update_category"
+    "product-catalog-service/internal/app/product/usecases/delete_category"
+    "product-catalog-service/internal/app/product/usecases/recategorize_products"
+    "product-catalog-service/internal/app/product/usecases/run_recategorizations"
 
     // Queries
     "product-catalog-service/internal/app/product/queries/get_product"
     "product-catalog-service/internal/app/product/queries/list_products"
+    "product-catalog-service/internal/app/product/queries/get_price_history"
+    "product-catalog-service/internal/app/product/queries/batch_get_products"
+    "product-catalog-service/internal/app/product/queries/search_products"
+    "product-catalog-service/internal/app/product/queries/suggest_products"
+    "product-catalog-service/internal/app/product/queries/get_category"
+    "product-catalog-service/internal/app/product/queries/list_categories"
+    "product-catalog-service/internal/app/product/queries/get_recategorization"
 
     // Infrastructure
     "product-catalog-service/internal/pkg/committer"
     "product-catalog-service/internal/pkg/clock"
+    "product-catalog-service/internal/pkg/pagetoken"
 )
 
+// pageTokenMaxAge bounds how long a listing can be paged through.
+const pageTokenMaxAge = time.Hour
+
 // Options holds all service dependencies
 type Options struct {
     // Shared
     Clock     clock.Clock
     Committer *committer.Committer
+    Pricing   domainservices.PricingCalculator
 
     // Repositories
     ProductRepo contracts.ProductRepo
     OutboxRepo  contracts.OutboxRepo
+    CategoryRepo contracts.CategoryRepo
+    RecategorizationRepo contracts.RecategorizationRepo
+
+    // SearchIndex is the embedded search index to load and keep in sync;
+    // nil when searching with Spanner.
+    SearchIndex *repo.MemorySearch
+    // SuggestIndex is the prefix index to load and keep in sync.
+    SuggestIndex *repo.MemorySuggest
 
     // Usecases (Commands)
     CreateProduct     *create_product.Interactor
@@ -44,10 +84,31 @@ type Options struct {
     DeactivateProduct *deactivate_product.Interactor
     ApplyDiscount     *apply_discount.Interactor
     RemoveDiscount    *remove_discount.Interactor
+    SchedulePrice     *schedule_price.Interactor
+    CancelScheduledPrice *cancel_scheduled_price.Interactor
+    AddVariant        *add_variant.Interactor
+    UpdateVariant     *update_variant.Interactor
+    RemoveVariant     *remove_variant.Interactor
+    CreateCategory    *create_category.Interactor
+    UpdateCategory    *update_category.Interactor
+    DeleteCategory    *delete_category.Interactor
+    RecategorizeProducts *recategorize_products.Interactor
+
+    // Background jobs
+    SweepDiscounts *sweep_discounts.Interactor
+    BackfillPricePeriods *backfill_price_periods.Interactor
+    RunRecategorizations *run_recategorizations.Interactor
 
     // Queries
     GetProduct   *get_product.Query
     ListProducts *list_products.Query
+    GetPriceHistory *get_price_history.Query
+    BatchGetProducts *batch_get_products.Query
+    SearchProducts *search_products.Query
+    SuggestProducts *suggest_products.Query
+    GetCategory     *get_category.Query
+    ListCategories  *list_categories.Query
+    GetRecategorization *get_recategorization.Query
 }
 
 // NewOptions constructs all dependencies
@@ -55,35 +116,132 @@ func NewOptions(ctx context.Context, spannerClient *spanner.Client) *Options {
     // Shared infrastructure
     clk := clock.NewRealClock()
     comm := committer.New(spannerClient)
+    pageTokens := pagetoken.NewCodec(pageTokenKey(), pageTokenMaxAge)
+
+    // Stacked discounts (e.g. clearance + loyalty) apply one after another;
+    // presented prices use banker's rounding.
+    pricing := domainservices.PricingCalculator{
+        Policy:   domainservices.PolicySequential,
+        Rounding: domain.RoundHalfEven,
+    }
 
     // Repositories
-    prodRepo := repo.NewProductRepo(spannerClient)
+    prodRepo := repo.NewProductRepo(spannerClient, pricing)
     outboxRepo := repo.NewOutboxRepo(spannerClient)
+    readModel := repo.NewReadModel(spannerClient)
+    categoryRepo := repo.NewCategoryRepo(spannerClient)
+    recategorizationRepo := repo.NewRecategorizationRepo(spannerClient)
+    sweepCursorRepo := repo.NewSweepCursorRepo(spannerClient)
+
+    var searcher contracts.ProductSearcher
+    var searchIndex *repo.MemorySearch
+    if searchBackend() == "spanner" {
+        searcher = repo.NewSpannerSearch(spannerClient)
+    } else {
+        searchIndex = repo.NewMemorySearch(spannerClient)
+        searcher = searchIndex
+    }
+    suggestIndex := repo.NewMemorySuggest(spannerClient)
 
     // Usecases
-    createProductUC := create_product.NewInteractor(prodRepo, outboxRepo, comm, clk)
-    updateProductUC := update_product.NewInteractor(prodRepo, outboxRepo, comm, clk)
-    activateProductUC := activate_product.NewInteractor(prodRepo, outboxRepo, comm, clk)
+    createProductUC := create_product.NewInteractor(prodRepo, categoryRepo, outboxRepo, comm, clk)
+    updateProductUC := update_product.NewInteractor(prodRepo, categoryRepo, outboxRepo, comm, clk)
+    activateProductUC := activate_product.NewInteractor(prodRepo, categoryRepo, outboxRepo, comm, clk)
     deactivateProductUC := deactivate_product.NewInteractor(prodRepo, outboxRepo, comm, clk)
     applyDiscountUC := apply_discount.NewInteractor(prodRepo, outboxRepo, comm, clk)
     removeDiscountUC := remove_discount.NewInteractor(prodRepo, outboxRepo, comm, clk)
+    schedulePriceUC := schedule_price.New(prodRepo, outboxRepo, comm, clk)
+    cancelScheduledPriceUC := cancel_scheduled_price.New(prodRepo, outboxRepo, comm, clk)
+    addVariantUC := add_variant.New(prodRepo, outboxRepo, comm, clk)
+    updateVariantUC := update_variant.New(prodRepo, outboxRepo, comm, clk)
+    removeVariantUC := remove_variant.New(prodRepo, outboxRepo, comm, clk)
+    sweepDiscountsUC := sweep_discounts.New(prodRepo, sweepCursorRepo, outboxRepo, comm, clk, pricing)
+    backfillPricePeriodsUC := backfill_price_periods.New(prodRepo, comm)
+    createCategoryUC := create_category.New(categoryRepo, outboxRepo, comm, clk)
+    updateCategoryUC := update_category.New(categoryRepo, outboxRepo, comm, clk)
+    deleteCategoryUC := delete_category.New(categoryRepo, outboxRepo, comm, clk)
+    recategorizeProductsUC := recategorize_products.New(recategorizationRepo, categoryRepo, outboxRepo, comm, clk)
+    runRecategorizationsUC := run_recategorizations.New(recategorizationRepo, prodRepo, categoryRepo, outboxRepo, comm, clk)
 
     // Queries
-    getProductQuery := get_product.NewQuery(prodRepo)
-    listProductsQuery := list_products.NewQuery(prodRepo)
+    getProductQuery := get_product.New(readModel, pricing)
+    listProductsQuery := list_products.New(readModel, pricing, pageTokens)
+    getPriceHistoryQuery := get_price_history.New(readModel, pricing)
+    batchGetProductsQuery := batch_get_products.New(readModel, pricing)
+    searchProductsQuery := search_products.New(readModel, searcher, pricing, pageTokens)
+    suggestProductsQuery := suggest_products.New(suggestIndex)
+    getCategoryQuery := get_category.New(readModel)
+    listCategoriesQuery := list_categories.New(readModel)
+    getRecategorizationQuery := get_recategorization.New(readModel)
 
     return &Options{
         Clock:            clk,
         Committer:        comm,
+        Pricing:          pricing,
         ProductRepo:      prodRepo,
         OutboxRepo:       outboxRepo,
+        CategoryRepo:     categoryRepo,
+        RecategorizationRepo: recategorizationRepo,
+        SearchIndex:      searchIndex,
+        SuggestIndex:     suggestIndex,
         CreateProduct:    createProductUC,
         UpdateProduct:    updateProductUC,
         ActivateProduct:  activateProductUC,
         DeactivateProduct: deactivateProductUC,
         ApplyDiscount:    applyDiscountUC,
         RemoveDiscount:   removeDiscountUC,
+        SchedulePrice:    schedulePriceUC,
+        CancelScheduledPrice: cancelScheduledPriceUC,
+        AddVariant:       addVariantUC,
+        UpdateVariant:    updateVariantUC,
+        RemoveVariant:    removeVariantUC,
+        CreateCategory:   createCategoryUC,
+        UpdateCategory:   updateCategoryUC,
+        DeleteCategory:   deleteCategoryUC,
+        RecategorizeProducts: recategorizeProductsUC,
+        SweepDiscounts:   sweepDiscountsUC,
+        BackfillPricePeriods: backfillPricePeriodsUC,
+        RunRecategorizations: runRecategorizationsUC,
         GetProduct:       getProductQuery,
         ListProducts:     listProductsQuery,
+        GetPriceHistory:  getPriceHistoryQuery,
+        BatchGetProducts: batchGetProductsQuery,
+        SearchProducts:   searchProductsQuery,
+        SuggestProducts:  suggestProductsQuery,
+        GetCategory:      getCategoryQuery,
+        ListCategories:   listCategoriesQuery,
+        GetRecategorization: getRecategorizationQuery,
+    }
+}
+
+// searchBackend returns the product search backend from SEARCH_BACKEND:
+// "spanner" for Spanner search indexes or "memory" for the embedded index.
+// The emulator has no search indexes, so it defaults to "memory" there.
+func searchBackend() string {
+    switch backend := os.Getenv("SEARCH_BACKEND"); backend {
+    case "spanner", "memory":
+        return backend
+    case "":
+    default:
+        log.Fatalf("unknown SEARCH_BACKEND %q; use spanner or memory", backend)
+    }
+    if os.Getenv("SPANNER_EMULATOR_HOST") != "" {
+        return "memory"
+    }
+    return "spanner"
+}
+
+// pageTokenKey returns the page token signing key from PAGE_TOKEN_KEY.
+// Without it a random key is used, so tokens only work on this instance
+// until it restarts.
+func pageTokenKey() []byte {
+    if key := os.Getenv("PAGE_TOKEN_KEY"); key != "" {
+        return []byte(key)
+    }
+    log.Println("PAGE_TOKEN_KEY is not set; page tokens are signed with a random key")
+    key := make([]byte, 32)
+    if _, err := rand.Read(key); err != nil {
+        log.Fatalf("failed to generate page token key: %v", err)
     }
+    return key
 }
@@ -0,0 +1,45 @@
+package product
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	productv1 "product-catalog-service/proto/product/v1"
+)
+
+// AddVariant implements the AddVariant gRPC method.
+func (h *ProductHandler) AddVariant(ctx context.Context, req *productv1.AddVariantRequest) (*productv1.AddVariantReply, error) {
+	// 1. Validate proto request
+	if err := validateAddVariantRequest(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// 2. Map proto to application request
+	appReq := mapToAddVariantRequest(req)
+
+	// 3. Call usecase (usecase applies plan internally)
+	variantID, err := h.commands.AddVariant.Execute(ctx, appReq)
+	if err != nil {
+		return nil, mapDomainErrorToGRPC(err)
+	}
+
+	// 4. Return response
+	return &productv1.AddVariantReply{
+		VariantId: variantID,
+	}, nil
+}
+
+func validateAddVariantRequest(req *productv1.AddVariantRequest) error {
+	if req.ProductId == "" {
+		return status.Error(codes.InvalidArgument, "product_id is required")
+	}
+	if req.Sku == "" {
+		return status.Error(codes.InvalidArgument, "sku is required")
+	}
+	if len(req.Options) == 0 {
+		return status.Error(codes.InvalidArgument, "options are required")
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package product
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"product-catalog-service/internal/app/product/domain"
+	productv1 "product-catalog-service/proto/product/v1"
+)
+
+// AdminListProducts implements the AdminListProducts gRPC method.
+func (h *ProductHandler) AdminListProducts(ctx context.Context, req *productv1.AdminListProductsRequest) (*productv1.ListProductsReply, error) {
+	// 1. Validate proto request
+	if err := validateAdminListRequest(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// 2. Map proto to application request
+	appReq := mapToAdminListProductsRequest(req)
+
+	// 3. Call query
+	result, err := h.queries.ListProducts.Execute(ctx, appReq)
+	if err != nil {
+		return nil, mapDomainErrorToGRPC(err)
+	}
+
+	// 4. Map response
+	items := make([]*productv1.ProductListItem, 0, len(result.Items))
+	for _, item := range result.Items {
+		pb := mapProductListItemDTOToProto(item)
+		applyReadMask(pb, req.ReadMask)
+		items = append(items, pb)
+	}
+
+	// 5. Return response
+	return &productv1.ListProductsReply{
+		Items:         items,
+		NextPageToken: result.NextPageToken,
+		Facets:        mapFacetsDTOToProto(result.Facets),
+	}, nil
+}
+
+func validateAdminListRequest(req *productv1.AdminListProductsRequest) error {
+	if req.PageSize < 0 {
+		return status.Error(codes.InvalidArgument, "page_size must be >= 0")
+	}
+	if req.PageSize > 1000 {
+		return status.Error(codes.InvalidArgument, "page_size must be <= 1000")
+	}
+	for _, s := range req.Statuses {
+		switch domain.ProductStatus(s) {
+		case domain.ProductStatusDraft, domain.ProductStatusActive,
+			domain.ProductStatusInactive, domain.ProductStatusArchived:
+		default:
+			return status.Error(codes.InvalidArgument, fmt.Sprintf("unknown status %q", s))
+		}
+	}
+	if err := validateReadMask(req.ReadMask, &productv1.ProductListItem{}); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return nil
+}
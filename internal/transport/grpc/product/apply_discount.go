@@ -23,20 +23,33 @@ func (h *ProductHandler) ApplyDiscount(ctx context.Context, req *productv1.Apply
 	}
 
 	// 3. Call usecase (usecase applies plan internally)
-	if err := h.commands.ApplyDiscount.Execute(ctx, appReq); err != nil {
+	discountID, err := h.commands.ApplyDiscount.Execute(ctx, appReq)
+	if err != nil {
 		return nil, mapDomainErrorToGRPC(err)
 	}
 
 	// 4. Return response
-	return &productv1.ApplyDiscountReply{}, nil
+	return &productv1.ApplyDiscountReply{
+		DiscountId: discountID,
+	}, nil
 }
 
 func validateApplyDiscountRequest(req *productv1.ApplyDiscountRequest) error {
 	if req.ProductId == "" {
 		return status.Error(codes.InvalidArgument, "product_id is required")
 	}
-	if req.PercentageDenominator <= 0 {
-		return status.Error(codes.InvalidArgument, "percentage_denominator must be > 0")
+	switch req.Kind {
+	case productv1.DiscountKind_DISCOUNT_KIND_FIXED_AMOUNT, productv1.DiscountKind_DISCOUNT_KIND_PRICE_OVERRIDE:
+		if req.Amount == nil {
+			return status.Error(codes.InvalidArgument, "amount is required")
+		}
+		if req.Amount.Exact == "" && req.Amount.Denominator <= 0 {
+			return status.Error(codes.InvalidArgument, "amount.exact or amount.denominator must be set")
+		}
+	default:
+		if req.PercentageDenominator <= 0 {
+			return status.Error(codes.InvalidArgument, "percentage_denominator must be > 0")
+		}
 	}
 	if req.StartDate == nil {
 		return status.Error(codes.InvalidArgument, "start_date is required")
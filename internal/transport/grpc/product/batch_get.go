@@ -0,0 +1,82 @@
+package product
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	batchgetproducts "product-catalog-service/internal/app/product/queries/batch_get_products"
+	productv1 "product-catalog-service/proto/product/v1"
+)
+
+// batchUnsupportedFields are Product fields not filled by BatchGetProducts.
+var batchUnsupportedFields = map[string]bool{
+	"lowest_price":      true,
+	"lowest_price_days": true,
+	"discounts":         true,
+	"scheduled_prices":  true,
+}
+
+// BatchGetProducts implements the BatchGetProducts gRPC method.
+func (h *ProductHandler) BatchGetProducts(ctx context.Context, req *productv1.BatchGetProductsRequest) (*productv1.BatchGetProductsReply, error) {
+	// 1. Validate proto request
+	if err := validateBatchGetRequest(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// 2. Map proto to application request
+	appReq := mapToBatchGetProductsRequest(req)
+
+	// 3. Call query
+	result, err := h.queries.BatchGetProducts.Execute(ctx, appReq)
+	if err != nil {
+		return nil, mapDomainErrorToGRPC(err)
+	}
+
+	// 4. Map response
+	reply := &productv1.BatchGetProductsReply{
+		Results: make([]*productv1.BatchGetProductsResult, 0, len(result.Items)),
+		AsOf:    timestamppb.New(result.AsOf),
+	}
+	for _, item := range result.Items {
+		pb := &productv1.BatchGetProductsResult{
+			ProductId: item.ProductID,
+			Found:     item.Found,
+		}
+		if item.Product != nil {
+			pb.Product = mapBatchProductDTOToProto(item.Product)
+			pb.Product.PriceBreakdown.AsOf = reply.AsOf
+			applyReadMask(pb.Product, req.ReadMask)
+		}
+		reply.Results = append(reply.Results, pb)
+	}
+
+	// 5. Return response
+	return reply, nil
+}
+
+func validateBatchGetRequest(req *productv1.BatchGetProductsRequest) error {
+	if len(req.ProductIds) == 0 {
+		return status.Error(codes.InvalidArgument, "product_ids is required")
+	}
+	if len(req.ProductIds) > batchgetproducts.MaxProducts {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("at most %d product_ids are allowed", batchgetproducts.MaxProducts))
+	}
+	for _, id := range req.ProductIds {
+		if id == "" {
+			return status.Error(codes.InvalidArgument, "product_ids must not contain empty ids")
+		}
+	}
+	if err := validateReadMask(req.ReadMask, &productv1.Product{}); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	for _, path := range req.ReadMask.GetPaths() {
+		if batchUnsupportedFields[path] {
+			return status.Error(codes.InvalidArgument, fmt.Sprintf("read_mask: field %q is not available in BatchGetProducts", path))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package product
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	productv1 "product-catalog-service/proto/product/v1"
+)
+
+// CancelScheduledPrice implements the CancelScheduledPrice gRPC method.
+func (h *ProductHandler) CancelScheduledPrice(ctx context.Context, req *productv1.CancelScheduledPriceRequest) (*productv1.CancelScheduledPriceReply, error) {
+	// 1. Validate proto request
+	if err := validateCancelScheduledPriceRequest(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// 2. Map proto to application request
+	appReq := mapToCancelScheduledPriceRequest(req)
+
+	// 3. Call usecase (usecase applies plan internally)
+	if err := h.commands.CancelScheduledPrice.Execute(ctx, appReq); err != nil {
+		return nil, mapDomainErrorToGRPC(err)
+	}
+
+	// 4. Return response
+	return &productv1.CancelScheduledPriceReply{}, nil
+}
+
+func validateCancelScheduledPriceRequest(req *productv1.CancelScheduledPriceRequest) error {
+	if req.ProductId == "" {
+		return status.Error(codes.InvalidArgument, "product_id is required")
+	}
+	if req.ScheduledPriceId == "" {
+		return status.Error(codes.InvalidArgument, "scheduled_price_id is required")
+	}
+	return nil
+}
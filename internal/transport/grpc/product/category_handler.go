@@ -0,0 +1,38 @@
+package product
+
+import (
+	getcategory "product-catalog-service/internal/app/product/queries/get_category"
+	listcategories "product-catalog-service/internal/app/product/queries/list_categories"
+	createcategory "product-catalog-service/internal/app/product/usecases/create_category"
+	deletecategory "product-catalog-service/internal/app/product/usecases/delete_category"
+	updatecategory "product-catalog-service/internal/app/product/usecases/update_category"
+	productv1 "product-catalog-service/proto/product/v1"
+)
+
+// CategoryHandler wires CategoryService gRPC methods to application usecases.
+type CategoryHandler struct {
+	productv1.UnimplementedCategoryServiceServer
+
+	createCategory *createcategory.Interactor
+	updateCategory *updatecategory.Interactor
+	deleteCategory *deletecategory.Interactor
+	getCategory    *getcategory.Query
+	listCategories *listcategories.Query
+}
+
+// NewCategoryHandler creates a new CategoryHandler with all usecases and queries wired.
+func NewCategoryHandler(
+	createCategory *createcategory.Interactor,
+	updateCategory *updatecategory.Interactor,
+	deleteCategory *deletecategory.Interactor,
+	getCategory *getcategory.Query,
+	listCategories *listcategories.Query,
+) *CategoryHandler {
+	return &CategoryHandler{
+		createCategory: createCategory,
+		updateCategory: updateCategory,
+		deleteCategory: deleteCategory,
+		getCategory:    getCategory,
+		listCategories: listCategories,
+	}
+}
@@ -17,7 +17,10 @@ func (h *ProductHandler) CreateProduct(ctx context.Context, req *productv1.Creat
 	}
 
 	// 2. Map proto to application request
-	appReq := mapToCreateProductRequest(req)
+	appReq, err := mapToCreateProductRequest(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 
 	// 3. Call usecase (usecase applies plan internally)
 	productID, err := h.commands.CreateProduct.Execute(ctx, appReq)
@@ -38,8 +41,8 @@ func validateCreateRequest(req *productv1.CreateProductRequest) error {
 	if req.Category == "" {
 		return status.Error(codes.InvalidArgument, "category is required")
 	}
-	if req.BasePriceDenominator <= 0 {
-		return status.Error(codes.InvalidArgument, "base_price_denominator must be > 0")
+	if req.BasePrice == "" && req.BasePriceDenominator <= 0 {
+		return status.Error(codes.InvalidArgument, "base_price or base_price_denominator must be set")
 	}
 	return nil
 }
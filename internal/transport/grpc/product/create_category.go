@@ -0,0 +1,35 @@
+package product
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	productv1 "product-catalog-service/proto/product/v1"
+)
+
+// CreateCategory implements the CreateCategory gRPC method.
+func (h *CategoryHandler) CreateCategory(ctx context.Context, req *productv1.CreateCategoryRequest) (*productv1.CreateCategoryReply, error) {
+	// 1. Validate proto request
+	if req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+
+	// 2. Map proto to application request
+	appReq, err := mapToCreateCategoryRequest(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// 3. Call usecase (usecase applies plan internally)
+	categoryID, err := h.createCategory.Execute(ctx, appReq)
+	if err != nil {
+		return nil, mapDomainErrorToGRPC(err)
+	}
+
+	// 4. Return response
+	return &productv1.CreateCategoryReply{
+		CategoryId: categoryID,
+	}, nil
+}
@@ -0,0 +1,29 @@
+package product
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	productv1 "product-catalog-service/proto/product/v1"
+)
+
+// DeleteCategory implements the DeleteCategory gRPC method.
+func (h *CategoryHandler) DeleteCategory(ctx context.Context, req *productv1.DeleteCategoryRequest) (*productv1.DeleteCategoryReply, error) {
+	// 1. Validate proto request
+	if req.CategoryId == "" {
+		return nil, status.Error(codes.InvalidArgument, "category_id is required")
+	}
+
+	// 2. Map proto to application request
+	appReq := mapToDeleteCategoryRequest(req)
+
+	// 3. Call usecase (usecase applies plan internally)
+	if err := h.deleteCategory.Execute(ctx, appReq); err != nil {
+		return nil, mapDomainErrorToGRPC(err)
+	}
+
+	// 4. Return response
+	return &productv1.DeleteCategoryReply{}, nil
+}
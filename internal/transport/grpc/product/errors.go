@@ -4,10 +4,17 @@ import (
 	"errors"
 	"fmt"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"product-catalog-service/internal/app/product/contracts"
 	"product-catalog-service/internal/app/product/domain"
+	"product-catalog-service/internal/app/product/queries/facets"
+	"product-catalog-service/internal/app/product/queries/listproducts"
+	searchproducts "product-catalog-service/internal/app/product/queries/search_products"
+	suggestproducts "product-catalog-service/internal/app/product/queries/suggest_products"
+	"product-catalog-service/internal/pkg/filter"
 )
 
 // mapDomainErrorToGRPC maps domain errors to gRPC status errors.
@@ -25,6 +32,95 @@ func mapDomainErrorToGRPC(err error) error {
 		return status.Error(codes.InvalidArgument, "invalid discount period")
 	}
 
+	if errors.Is(err, domain.ErrUnsupportedCurrency) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if errors.Is(err, domain.ErrPriceScale) || errors.Is(err, domain.ErrMoneyOverflow) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if errors.Is(err, domain.ErrDiscountNotFound) {
+		return status.Error(codes.NotFound, "discount not found")
+	}
+
+	if errors.Is(err, domain.ErrInvalidPriceSchedule) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if errors.Is(err, domain.ErrScheduledPriceNotFound) {
+		return status.Error(codes.NotFound, "scheduled price not found")
+	}
+
+	if errors.Is(err, domain.ErrProductArchived) {
+		return status.Error(codes.FailedPrecondition, "product is archived")
+	}
+
+	if errors.Is(err, domain.ErrCategoryNotFound) {
+		return status.Error(codes.NotFound, "category not found")
+	}
+
+	if errors.Is(err, domain.ErrInvalidCategory) ||
+		errors.Is(err, domain.ErrUnknownCategory) ||
+		errors.Is(err, domain.ErrCategoryCycle) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if errors.Is(err, domain.ErrCategoryInUse) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	if errors.Is(err, domain.ErrCategorySlugTaken) {
+		return status.Error(codes.AlreadyExists, err.Error())
+	}
+
+	if errors.Is(err, domain.ErrInvalidAttribute) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if errors.Is(err, domain.ErrInvalidVariant) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if errors.Is(err, domain.ErrVariantNotFound) {
+		return status.Error(codes.NotFound, "variant not found")
+	}
+
+	if errors.Is(err, domain.ErrSKUTaken) {
+		return status.Error(codes.AlreadyExists, err.Error())
+	}
+
+	var schemaErr *domain.AttributeSchemaError
+	if errors.As(err, &schemaErr) {
+		return attributeSchemaStatus(schemaErr)
+	}
+
+	if errors.Is(err, domain.ErrInvalidRecategorization) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if errors.Is(err, domain.ErrRecategorizationNotFound) {
+		return status.Error(codes.NotFound, "recategorization job not found")
+	}
+
+	if errors.Is(err, contracts.ErrSnapshotExpired) {
+		return status.Error(codes.FailedPrecondition, "the listing snapshot has expired; restart from the first page without a page token")
+	}
+
+	if errors.Is(err, contracts.ErrInvalidPageToken) ||
+		errors.Is(err, listproducts.ErrInvalidOrderBy) ||
+		errors.Is(err, listproducts.ErrInvalidPriceRange) ||
+		errors.Is(err, searchproducts.ErrInvalidQuery) ||
+		errors.Is(err, suggestproducts.ErrInvalidPrefix) ||
+		errors.Is(err, facets.ErrInvalidPriceBuckets) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var filterErr *filter.Error
+	if errors.As(err, &filterErr) {
+		return status.Error(codes.InvalidArgument, filterErr.Error())
+	}
+
 	// Check for common error patterns
 	if errors.Is(err, errors.New("product not found")) {
 		return status.Error(codes.NotFound, "product not found")
@@ -33,3 +129,20 @@ func mapDomainErrorToGRPC(err error) error {
 	// Default to internal error for unknown errors
 	return status.Error(codes.Internal, fmt.Sprintf("internal error: %v", err))
 }
+
+// attributeSchemaStatus reports each schema violation as a field violation
+// in a google.rpc.BadRequest detail.
+func attributeSchemaStatus(err *domain.AttributeSchemaError) error {
+	st := status.New(codes.InvalidArgument, err.Error())
+	details := &errdetails.BadRequest{}
+	for _, v := range err.Violations {
+		details.FieldViolations = append(details.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Description: v.Description,
+		})
+	}
+	if withDetails, detailErr := st.WithDetails(details); detailErr == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
@@ -26,14 +26,19 @@ func (h *ProductHandler) GetProduct(ctx context.Context, req *productv1.GetProdu
 	}
 
 	// 4. Return response
-	return &productv1.GetProductReply{
+	reply := &productv1.GetProductReply{
 		Product: mapProductDTOToProto(product),
-	}, nil
+	}
+	applyReadMask(reply.Product, req.ReadMask)
+	return reply, nil
 }
 
 func validateGetRequest(req *productv1.GetProductRequest) error {
 	if req.ProductId == "" {
 		return status.Error(codes.InvalidArgument, "product_id is required")
 	}
+	if err := validateReadMask(req.ReadMask, &productv1.Product{}); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
 	return nil
 }
@@ -0,0 +1,32 @@
+package product
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	productv1 "product-catalog-service/proto/product/v1"
+)
+
+// GetCategory implements the GetCategory gRPC method.
+func (h *CategoryHandler) GetCategory(ctx context.Context, req *productv1.GetCategoryRequest) (*productv1.GetCategoryReply, error) {
+	// 1. Validate proto request
+	if req.GetCategoryId() == "" && req.GetSlug() == "" {
+		return nil, status.Error(codes.InvalidArgument, "category_id or slug is required")
+	}
+
+	// 2. Map proto to application request
+	appReq := mapToGetCategoryRequest(req)
+
+	// 3. Call query
+	category, err := h.getCategory.Execute(ctx, appReq)
+	if err != nil {
+		return nil, mapDomainErrorToGRPC(err)
+	}
+
+	// 4. Return response
+	return &productv1.GetCategoryReply{
+		Category: mapCategoryDTOToProto(*category),
+	}, nil
+}
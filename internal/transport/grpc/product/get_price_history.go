@@ -0,0 +1,40 @@
+package product
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	productv1 "product-catalog-service/proto/product/v1"
+)
+
+// GetPriceHistory implements the GetPriceHistory gRPC method.
+func (h *ProductHandler) GetPriceHistory(ctx context.Context, req *productv1.GetPriceHistoryRequest) (*productv1.GetPriceHistoryReply, error) {
+	// 1. Validate proto request
+	if err := validateGetPriceHistoryRequest(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// 2. Map proto to application request
+	appReq := mapToGetPriceHistoryRequest(req)
+
+	// 3. Call query
+	history, err := h.queries.GetPriceHistory.Execute(ctx, appReq)
+	if err != nil {
+		return nil, mapDomainErrorToGRPC(err)
+	}
+
+	// 4. Return response
+	return mapPriceHistoryDTOToProto(history), nil
+}
+
+func validateGetPriceHistoryRequest(req *productv1.GetPriceHistoryRequest) error {
+	if req.ProductId == "" {
+		return status.Error(codes.InvalidArgument, "product_id is required")
+	}
+	if req.From != nil && req.To != nil && !req.From.AsTime().Before(req.To.AsTime()) {
+		return status.Error(codes.InvalidArgument, "from must be before to")
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package product
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	productv1 "product-catalog-service/proto/product/v1"
+)
+
+// GetRecategorization implements the GetRecategorization gRPC method.
+func (h *ProductHandler) GetRecategorization(ctx context.Context, req *productv1.GetRecategorizationRequest) (*productv1.GetRecategorizationReply, error) {
+	// 1. Validate proto request
+	if req.JobId == "" {
+		return nil, status.Error(codes.InvalidArgument, "job_id is required")
+	}
+
+	// 2. Map proto to application request
+	appReq := mapToGetRecategorizationRequest(req)
+
+	// 3. Call query
+	job, err := h.queries.GetRecategorization.Execute(ctx, appReq)
+	if err != nil {
+		return nil, mapDomainErrorToGRPC(err)
+	}
+
+	// 4. Return response
+	return &productv1.GetRecategorizationReply{
+		Recategorization: mapRecategorizationDTOToProto(job),
+	}, nil
+}
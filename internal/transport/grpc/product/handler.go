@@ -8,8 +8,19 @@ import (
 	deactivateproduct "product-catalog-service/internal/app/product/usecases/deactivate_product"
 	applydiscount "product-catalog-service/internal/app/product/usecases/apply_discount"
 	removediscount "product-catalog-service/internal/app/product/usecases/remove_discount"
+	scheduleprice "product-catalog-service/internal/app/product/usecases/schedule_price"
+	cancelscheduledprice "product-catalog-service/internal/app/product/usecases/cancel_scheduled_price"
 	"product-catalog-service/internal/app/product/queries/getproduct"
 	"product-catalog-service/internal/app/product/queries/listproducts"
+	getpricehistory "product-catalog-service/internal/app/product/queries/get_price_history"
+	batchgetproducts "product-catalog-service/internal/app/product/queries/batch_get_products"
+	searchproducts "product-catalog-service/internal/app/product/queries/search_products"
+	suggestproducts "product-catalog-service/internal/app/product/queries/suggest_products"
+	recategorizeproducts "product-catalog-service/internal/app/product/usecases/recategorize_products"
+	addvariant "product-catalog-service/internal/app/product/usecases/add_variant"
+	updatevariant "product-catalog-service/internal/app/product/usecases/update_variant"
+	removevariant "product-catalog-service/internal/app/product/usecases/remove_variant"
+	getrecategorization "product-catalog-service/internal/app/product/queries/get_recategorization"
 )
 
 // ProductHandler wires gRPC methods to application usecases.
@@ -24,12 +35,23 @@ type ProductHandler struct {
 		DeactivateProduct *deactivateproduct.Interactor
 		ApplyDiscount   *applydiscount.Interactor
 		RemoveDiscount  *removediscount.Interactor
+		SchedulePrice   *scheduleprice.Interactor
+		CancelScheduledPrice *cancelscheduledprice.Interactor
+		RecategorizeProducts *recategorizeproducts.Interactor
+		AddVariant      *addvariant.Interactor
+		UpdateVariant   *updatevariant.Interactor
+		RemoveVariant   *removevariant.Interactor
 	}
 
 	// Queries
 	queries struct {
 		GetProduct  *getproduct.Query
 		ListProducts *listproducts.Query
+		GetPriceHistory *getpricehistory.Query
+		BatchGetProducts *batchgetproducts.Query
+		SearchProducts *searchproducts.Query
+		SuggestProducts *suggestproducts.Query
+		GetRecategorization *getrecategorization.Query
 	}
 }
 
@@ -41,8 +63,19 @@ func NewProductHandler(
 	deactivateProduct *deactivateproduct.Interactor,
 	applyDiscount *applydiscount.Interactor,
 	removeDiscount *removediscount.Interactor,
+	schedulePrice *scheduleprice.Interactor,
+	cancelScheduledPrice *cancelscheduledprice.Interactor,
+	recategorizeProducts *recategorizeproducts.Interactor,
+	addVariant *addvariant.Interactor,
+	updateVariant *updatevariant.Interactor,
+	removeVariant *removevariant.Interactor,
 	getProduct *getproduct.Query,
 	listProducts *listproducts.Query,
+	getPriceHistory *getpricehistory.Query,
+	batchGetProducts *batchgetproducts.Query,
+	searchProducts *searchproducts.Query,
+	suggestProducts *suggestproducts.Query,
+	getRecategorization *getrecategorization.Query,
 ) *ProductHandler {
 	return &ProductHandler{
 		commands: struct {
@@ -52,6 +85,12 @@ func NewProductHandler(
 			DeactivateProduct *deactivateproduct.Interactor
 			ApplyDiscount   *applydiscount.Interactor
 			RemoveDiscount  *removediscount.Interactor
+			SchedulePrice   *scheduleprice.Interactor
+			CancelScheduledPrice *cancelscheduledprice.Interactor
+			RecategorizeProducts *recategorizeproducts.Interactor
+			AddVariant      *addvariant.Interactor
+			UpdateVariant   *updatevariant.Interactor
+			RemoveVariant   *removevariant.Interactor
 		}{
 			CreateProduct:   createProduct,
 			UpdateProduct:   updateProduct,
@@ -59,13 +98,32 @@ func NewProductHandler(
 			DeactivateProduct: deactivateProduct,
 			ApplyDiscount:   applyDiscount,
 			RemoveDiscount:  removeDiscount,
+			SchedulePrice:   schedulePrice,
+			CancelScheduledPrice: cancelScheduledPrice,
+			RecategorizeProducts: recategorizeProducts,
+			AddVariant:      addVariant,
+			UpdateVariant:   updateVariant,
+			RemoveVariant:   removeVariant,
 		},
 		queries: struct {
 			GetProduct  *getproduct.Query
 			ListProducts *listproducts.Query
+			GetPriceHistory *getpricehistory.Query
+			BatchGetProducts *batchgetproducts.Query
+		BatchGetProducts *batchgetproducts.Query
+		GetPriceHistory *getpricehistory.Query
+		BatchGetProducts *batchgetproducts.Query
+		SearchProducts *searchproducts.Query
+		SuggestProducts *suggestproducts.Query
+		GetRecategorization *getrecategorization.Query
 		}{
 			GetProduct:  getProduct,
 			ListProducts: listProducts,
+			GetPriceHistory: getPriceHistory,
+			BatchGetProducts: batchGetProducts,
+			SearchProducts: searchProducts,
+			SuggestProducts: suggestProducts,
+			GetRecategorization: getRecategorization,
 		},
 	}
 }
@@ -28,13 +28,16 @@ func (h *ProductHandler) ListProducts(ctx context.Context, req *productv1.ListPr
 	// 4. Map response
 	items := make([]*productv1.ProductListItem, 0, len(result.Items))
 	for _, item := range result.Items {
-		items = append(items, mapProductListItemDTOToProto(item))
+		pb := mapProductListItemDTOToProto(item)
+		applyReadMask(pb, req.ReadMask)
+		items = append(items, pb)
 	}
 
 	// 5. Return response
 	return &productv1.ListProductsReply{
 		Items:         items,
 		NextPageToken: result.NextPageToken,
+		Facets:        mapFacetsDTOToProto(result.Facets),
 	}, nil
 }
 
@@ -45,5 +48,8 @@ func validateListRequest(req *productv1.ListProductsRequest) error {
 	if req.PageSize > 1000 {
 		return status.Error(codes.InvalidArgument, "page_size must be <= 1000")
 	}
+	if err := validateReadMask(req.ReadMask, &productv1.ProductListItem{}); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
 	return nil
 }
@@ -0,0 +1,30 @@
+package product
+
+import (
+	"context"
+
+	productv1 "product-catalog-service/proto/product/v1"
+)
+
+// ListCategories implements the ListCategories gRPC method.
+func (h *CategoryHandler) ListCategories(ctx context.Context, req *productv1.ListCategoriesRequest) (*productv1.ListCategoriesReply, error) {
+	// 1. Map proto to application request
+	appReq := mapToListCategoriesRequest(req)
+
+	// 2. Call query
+	result, err := h.listCategories.Execute(ctx, appReq)
+	if err != nil {
+		return nil, mapDomainErrorToGRPC(err)
+	}
+
+	// 3. Map response
+	categories := make([]*productv1.Category, 0, len(result.Categories))
+	for _, c := range result.Categories {
+		categories = append(categories, mapCategoryDTOToProto(c))
+	}
+
+	// 4. Return response
+	return &productv1.ListCategoriesReply{
+		Categories: categories,
+	}, nil
+}
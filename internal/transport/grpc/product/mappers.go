@@ -1,34 +1,71 @@
 package product
 
 import (
+	"fmt"
 	"time"
 
+	"google.golang.org/protobuf/types/known/timestamppb"
+
 	productv1 "product-catalog-service/proto/product/v1"
+	"product-catalog-service/internal/app/product/domain"
 	createproduct "product-catalog-service/internal/app/product/usecases/create_product"
 	updateproduct "product-catalog-service/internal/app/product/usecases/update_product"
 	activateproduct "product-catalog-service/internal/app/product/usecases/activate_product"
 	deactivateproduct "product-catalog-service/internal/app/product/usecases/deactivate_product"
 	applydiscount "product-catalog-service/internal/app/product/usecases/apply_discount"
 	removediscount "product-catalog-service/internal/app/product/usecases/remove_discount"
+	scheduleprice "product-catalog-service/internal/app/product/usecases/schedule_price"
+	cancelscheduledprice "product-catalog-service/internal/app/product/usecases/cancel_scheduled_price"
 	"product-catalog-service/internal/app/product/queries/getproduct"
 	"product-catalog-service/internal/app/product/queries/listproducts"
+	batchgetproducts "product-catalog-service/internal/app/product/queries/batch_get_products"
+	getpricehistory "product-catalog-service/internal/app/product/queries/get_price_history"
+	"product-catalog-service/internal/app/product/queries/facets"
+	searchproducts "product-catalog-service/internal/app/product/queries/search_products"
+	suggestproducts "product-catalog-service/internal/app/product/queries/suggest_products"
+	createcategory "product-catalog-service/internal/app/product/usecases/create_category"
+	updatecategory "product-catalog-service/internal/app/product/usecases/update_category"
+	deletecategory "product-catalog-service/internal/app/product/usecases/delete_category"
+	getcategory "product-catalog-service/internal/app/product/queries/get_category"
+	listcategories "product-catalog-service/internal/app/product/queries/list_categories"
+	categorytree "product-catalog-service/internal/app/product/queries/category_tree"
+	recategorizeproducts "product-catalog-service/internal/app/product/usecases/recategorize_products"
+	getrecategorization "product-catalog-service/internal/app/product/queries/get_recategorization"
+	"product-catalog-service/internal/app/product/queries/attributes"
+	addvariant "product-catalog-service/internal/app/product/usecases/add_variant"
+	updatevariant "product-catalog-service/internal/app/product/usecases/update_variant"
+	removevariant "product-catalog-service/internal/app/product/usecases/remove_variant"
+	"product-catalog-service/internal/app/product/queries/variants"
 )
 
 // Command mappers: Proto -> Application Request
 
-func mapToCreateProductRequest(req *productv1.CreateProductRequest) createproduct.Request {
+func mapToCreateProductRequest(req *productv1.CreateProductRequest) (createproduct.Request, error) {
+	attrs, err := mapAttributesFromProto(req.Attributes)
+	if err != nil {
+		return createproduct.Request{}, err
+	}
 	return createproduct.Request{
 		Name:                 req.Name,
 		Description:          req.Description,
 		Category:             req.Category,
 		BasePriceNumerator:   req.BasePriceNumerator,
 		BasePriceDenominator: req.BasePriceDenominator,
-	}
+		BasePrice:            req.BasePrice,
+		Currency:             req.Currency,
+		Attributes:           attrs,
+	}, nil
 }
 
-func mapToUpdateProductRequest(req *productv1.UpdateProductRequest) updateproduct.Request {
+func mapToUpdateProductRequest(req *productv1.UpdateProductRequest) (updateproduct.Request, error) {
+	attrs, err := mapAttributesFromProto(req.Attributes)
+	if err != nil {
+		return updateproduct.Request{}, err
+	}
 	appReq := updateproduct.Request{
-		ProductID: req.ProductId,
+		ProductID:        req.ProductId,
+		Attributes:       attrs,
+		RemoveAttributes: req.RemoveAttributes,
 	}
 
 	if req.Name != nil {
@@ -41,7 +78,39 @@ func mapToUpdateProductRequest(req *productv1.UpdateProductRequest) updateproduc
 		appReq.Category = req.Category
 	}
 
-	return appReq
+	return appReq, nil
+}
+
+// mapAttributesFromProto parses typed attribute values; numbers must be
+// decimal strings.
+func mapAttributesFromProto(in map[string]*productv1.AttributeValue) (map[string]domain.AttributeValue, error) {
+	if len(in) == 0 {
+		return nil, nil
+	}
+	out := make(map[string]domain.AttributeValue, len(in))
+	for key, v := range in {
+		var attr domain.AttributeValue
+		var err error
+		switch value := v.GetValue().(type) {
+		case *productv1.AttributeValue_StringValue:
+			attr, err = domain.NewStringAttribute(value.StringValue)
+		case *productv1.AttributeValue_NumberValue:
+			attr, err = domain.ParseAttribute(domain.AttributeNumber, value.NumberValue, "")
+		case *productv1.AttributeValue_BoolValue:
+			attr = domain.NewBoolAttribute(value.BoolValue)
+		case *productv1.AttributeValue_EnumValue:
+			attr, err = domain.NewEnumAttribute(value.EnumValue)
+		case *productv1.AttributeValue_MeasurementValue:
+			attr, err = domain.ParseAttribute(domain.AttributeMeasurement, value.MeasurementValue.GetValue(), value.MeasurementValue.GetUnit())
+		default:
+			err = fmt.Errorf("%w: no value", domain.ErrInvalidAttribute)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("attributes[%q]: %w", key, err)
+		}
+		out[key] = attr
+	}
+	return out, nil
 }
 
 func mapToActivateProductRequest(req *productv1.ActivateProductRequest) activateproduct.Request {
@@ -57,27 +126,162 @@ func mapToDeactivateProductRequest(req *productv1.DeactivateProductRequest) deac
 }
 
 func mapToApplyDiscountRequest(req *productv1.ApplyDiscountRequest) (applydiscount.Request, error) {
-	return applydiscount.Request{
+	appReq := applydiscount.Request{
 		ProductID:            req.ProductId,
 		PercentageNumerator:   req.PercentageNumerator,
 		PercentageDenominator: req.PercentageDenominator,
 		StartDate:             req.StartDate.AsTime(),
 		EndDate:               req.EndDate.AsTime(),
-	}, nil
+		DiscountID:            req.DiscountId,
+		Priority:              int(req.Priority),
+		Exclusive:             req.Exclusive,
+	}
+
+	switch req.Kind {
+	case productv1.DiscountKind_DISCOUNT_KIND_FIXED_AMOUNT:
+		appReq.Kind = domain.DiscountKindFixedAmount
+	case productv1.DiscountKind_DISCOUNT_KIND_PRICE_OVERRIDE:
+		appReq.Kind = domain.DiscountKindPriceOverride
+	default:
+		appReq.Kind = domain.DiscountKindPercentage
+	}
+
+	if req.Amount != nil {
+		appReq.AmountNumerator = req.Amount.Numerator
+		appReq.AmountDenominator = req.Amount.Denominator
+		appReq.Amount = req.Amount.Exact
+	}
+
+	return appReq, nil
 }
 
 func mapToRemoveDiscountRequest(req *productv1.RemoveDiscountRequest) removediscount.Request {
 	return removediscount.Request{
+		ProductID:  req.ProductId,
+		DiscountID: req.DiscountId,
+	}
+}
+
+func mapToSchedulePriceRequest(req *productv1.SchedulePriceRequest) scheduleprice.Request {
+	return scheduleprice.Request{
+		ProductID:        req.ProductId,
+		ScheduledPriceID: req.ScheduledPriceId,
+		Price:            req.Price,
+		EffectiveFrom:    req.EffectiveFrom.AsTime(),
+	}
+}
+
+func mapToCancelScheduledPriceRequest(req *productv1.CancelScheduledPriceRequest) cancelscheduledprice.Request {
+	return cancelscheduledprice.Request{
+		ProductID:        req.ProductId,
+		ScheduledPriceID: req.ScheduledPriceId,
+	}
+}
+
+func mapToAddVariantRequest(req *productv1.AddVariantRequest) addvariant.Request {
+	return addvariant.Request{
+		ProductID:     req.ProductId,
+		VariantID:     req.VariantId,
+		SKU:           req.Sku,
+		Options:       req.Options,
+		PriceOverride: req.PriceOverride,
+		Status:        domain.VariantStatus(req.Status),
+	}
+}
+
+func mapToUpdateVariantRequest(req *productv1.UpdateVariantRequest) updatevariant.Request {
+	appReq := updatevariant.Request{
+		ProductID:     req.ProductId,
+		VariantID:     req.VariantId,
+		SKU:           req.Sku,
+		PriceOverride: req.PriceOverride,
+	}
+	if len(req.Options) > 0 {
+		appReq.Options = req.Options
+	}
+	if req.Status != nil {
+		status := domain.VariantStatus(*req.Status)
+		appReq.Status = &status
+	}
+	return appReq
+}
+
+func mapToRemoveVariantRequest(req *productv1.RemoveVariantRequest) removevariant.Request {
+	return removevariant.Request{
 		ProductID: req.ProductId,
+		VariantID: req.VariantId,
 	}
 }
 
 // Query mappers: Proto -> Application Request
 
+func mapToRecategorizeProductsRequest(req *productv1.RecategorizeProductsRequest) recategorizeproducts.Request {
+	return recategorizeproducts.Request{
+		FromCategory: req.FromCategory,
+		ToCategory:   req.ToCategory,
+	}
+}
+
+func mapToGetRecategorizationRequest(req *productv1.GetRecategorizationRequest) getrecategorization.Request {
+	return getrecategorization.Request{
+		JobID: req.JobId,
+	}
+}
+
 func mapToGetProductRequest(req *productv1.GetProductRequest) getproduct.Request {
-	return getproduct.Request{
+	appReq := getproduct.Request{
+		ProductID:       req.ProductId,
+		Now:             time.Time{}, // Will use current time in query
+		LowestPriceDays: int(req.LowestPriceDays),
+		Fields:          readMaskFields(req.ReadMask),
+	}
+	if req.AsOf != nil {
+		appReq.Now = req.AsOf.AsTime()
+	}
+	return appReq
+}
+
+func mapToBatchGetProductsRequest(req *productv1.BatchGetProductsRequest) batchgetproducts.Request {
+	appReq := batchgetproducts.Request{
+		ProductIDs: req.ProductIds,
+		Fields:     readMaskFields(req.ReadMask),
+	}
+	if req.AsOf != nil {
+		appReq.Now = req.AsOf.AsTime()
+	}
+	return appReq
+}
+
+func mapToGetPriceHistoryRequest(req *productv1.GetPriceHistoryRequest) getpricehistory.Request {
+	appReq := getpricehistory.Request{
 		ProductID: req.ProductId,
-		Now:       time.Time{}, // Will use current time in query
+	}
+	if req.From != nil {
+		appReq.From = req.From.AsTime()
+	}
+	if req.To != nil {
+		appReq.To = req.To.AsTime()
+	}
+	return appReq
+}
+
+func mapToSearchProductsRequest(req *productv1.SearchProductsRequest) searchproducts.Request {
+	appReq := searchproducts.Request{
+		Query:     req.Query,
+		PageSize:  int(req.PageSize),
+		PageToken: req.PageToken,
+		Facets:    mapToFacetsRequest(req.Facets),
+	}
+	if req.AsOf != nil {
+		appReq.Now = req.AsOf.AsTime()
+	}
+	return appReq
+}
+
+func mapToSuggestProductsRequest(req *productv1.SuggestProductsRequest) suggestproducts.Request {
+	return suggestproducts.Request{
+		Prefix: req.Prefix,
+		Limit:  int(req.Limit),
 	}
 }
 
@@ -85,42 +289,504 @@ func mapToListProductsRequest(req *productv1.ListProductsRequest) listproducts.R
 	appReq := listproducts.Request{
 		PageSize:  int(req.PageSize),
 		PageToken: req.PageToken,
-		Now:       time.Time{}, // Will use current time in query
+		Fields:    readMaskFields(req.ReadMask),
+		Filter:    req.Filter,
+		OrderBy:   req.OrderBy,
+		MinPrice:  req.MinPrice,
+		MaxPrice:  req.MaxPrice,
+		Facets:    mapToFacetsRequest(req.Facets),
+		IncludeDescendants: req.IncludeDescendants,
 	}
 
 	if req.Category != nil {
 		appReq.Category = req.Category
 	}
+	if req.AsOf != nil {
+		appReq.Now = req.AsOf.AsTime()
+	}
+
+	return appReq
+}
 
+func mapToAdminListProductsRequest(req *productv1.AdminListProductsRequest) listproducts.Request {
+	appReq := listproducts.Request{
+		PageSize:        int(req.PageSize),
+		PageToken:       req.PageToken,
+		Admin:           true,
+		IncludeArchived: req.IncludeArchived,
+		Filter:          req.Filter,
+		OrderBy:         req.OrderBy,
+		MinPrice:        req.MinPrice,
+		MaxPrice:        req.MaxPrice,
+		Fields:          readMaskFields(req.ReadMask),
+		Facets:          mapToFacetsRequest(req.Facets),
+		IncludeDescendants: req.IncludeDescendants,
+	}
+	if req.Category != nil {
+		appReq.Category = req.Category
+	}
+	if req.AsOf != nil {
+		appReq.Now = req.AsOf.AsTime()
+	}
+	for _, s := range req.Statuses {
+		appReq.Statuses = append(appReq.Statuses, domain.ProductStatus(s))
+	}
 	return appReq
 }
 
+func mapToFacetsRequest(opts *productv1.FacetOptions) facets.Request {
+	if opts == nil {
+		return facets.Request{}
+	}
+	return facets.Request{
+		Category:     opts.Category,
+		Status:       opts.Status,
+		Discount:     opts.Discount,
+		PriceBuckets: opts.PriceBuckets,
+	}
+}
+
 // Response mappers: Application DTO -> Proto
 
 func mapProductDTOToProto(dto *getproduct.ProductDTO) *productv1.Product {
-	return &productv1.Product{
+	product := &productv1.Product{
+		ProductId:      dto.ID,
+		Name:           dto.Name,
+		Description:    dto.Description,
+		Category:       dto.Category,
+		Status:         dto.Status,
+		EffectivePrice: mapMoneyToProto(
+			dto.EffectivePriceNumerator,
+			dto.EffectivePriceDenominator,
+			dto.EffectivePriceExact,
+			dto.Currency,
+			dto.EffectivePriceDecimal,
+			dto.EffectivePriceMinorUnits,
+		),
+		LowestPrice: mapMoneyToProto(
+			0,
+			0,
+			dto.LowestPriceExact,
+			dto.Currency,
+			dto.LowestPriceDecimal,
+			dto.LowestPriceMinorUnits,
+		),
+		LowestPriceDays: int32(dto.LowestPriceDays),
+		PriceBreakdown: &productv1.PriceBreakdown{
+			AsOf:             timestamppb.New(dto.AsOf),
+			BasePrice:        mapMoneyToProto(0, 0, dto.BasePriceExact, dto.Currency, dto.BasePriceDecimal, dto.BasePriceMinorUnits),
+			AppliedDiscounts: make([]*productv1.AppliedDiscount, 0, len(dto.AppliedDiscounts)),
+			DiscountAmount:   mapMoneyToProto(0, 0, dto.DiscountAmountExact, dto.Currency, dto.DiscountAmountDecimal, dto.DiscountAmountMinorUnits),
+			EffectivePrice: mapMoneyToProto(
+				dto.EffectivePriceNumerator,
+				dto.EffectivePriceDenominator,
+				dto.EffectivePriceExact,
+				dto.Currency,
+				dto.EffectivePriceDecimal,
+				dto.EffectivePriceMinorUnits,
+			),
+		},
+	}
+	for _, d := range dto.AppliedDiscounts {
+		product.PriceBreakdown.AppliedDiscounts = append(product.PriceBreakdown.AppliedDiscounts,
+			mapAppliedDiscountToProto(d.ID, d.Kind, d.Percentage, d.Amount, dto.Currency))
+	}
+	product.BasePrice = product.PriceBreakdown.BasePrice
+	for _, d := range dto.Discounts {
+		discount := &productv1.Discount{
+			DiscountId: d.ID,
+			Kind:       mapDiscountKindToProto(d.Kind),
+			Percentage: d.Percentage,
+			StartDate:  timestamppb.New(d.Start),
+			EndDate:    timestamppb.New(d.End),
+			Priority:   int32(d.Priority),
+			Exclusive:  d.Exclusive,
+		}
+		if d.Amount != "" {
+			discount.Amount = &productv1.Money{Exact: d.Amount, Currency: dto.Currency}
+		}
+		product.Discounts = append(product.Discounts, discount)
+	}
+	for _, s := range dto.ScheduledPrices {
+		product.ScheduledPrices = append(product.ScheduledPrices, &productv1.ScheduledPrice{
+			ScheduledPriceId: s.ID,
+			Price:            mapMoneyToProto(0, 0, s.PriceExact, dto.Currency, s.PriceDecimal, s.PriceMinorUnits),
+			EffectiveFrom:    timestamppb.New(s.EffectiveFrom),
+		})
+	}
+	product.CreatedAt = timestamppb.New(dto.CreatedAt)
+	product.UpdatedAt = timestamppb.New(dto.UpdatedAt)
+	if dto.ArchivedAt != nil {
+		product.ArchivedAt = timestamppb.New(*dto.ArchivedAt)
+	}
+	product.Attributes = mapAttributesToProto(dto.Attributes)
+	product.Variants = mapVariantsToProto(dto.Variants, dto.Currency)
+	return product
+}
+
+func mapBatchProductDTOToProto(dto *batchgetproducts.ProductDTO) *productv1.Product {
+	effectivePrice := mapMoneyToProto(
+		dto.EffectivePriceNumerator,
+		dto.EffectivePriceDenominator,
+		dto.EffectivePriceExact,
+		dto.Currency,
+		dto.EffectivePriceDecimal,
+		dto.EffectivePriceMinorUnits,
+	)
+	basePrice := mapMoneyToProto(0, 0, dto.BasePriceExact, dto.Currency, dto.BasePriceDecimal, dto.BasePriceMinorUnits)
+	product := &productv1.Product{
 		ProductId:      dto.ID,
 		Name:           dto.Name,
 		Description:    dto.Description,
 		Category:       dto.Category,
 		Status:         dto.Status,
-		EffectivePrice: mapMoneyToProto(dto.EffectivePriceNumerator, dto.EffectivePriceDenominator),
+		EffectivePrice: effectivePrice,
+		BasePrice:      basePrice,
+		PriceBreakdown: &productv1.PriceBreakdown{
+			BasePrice:        basePrice,
+			AppliedDiscounts: make([]*productv1.AppliedDiscount, 0, len(dto.AppliedDiscounts)),
+			DiscountAmount:   mapMoneyToProto(0, 0, dto.DiscountAmountExact, dto.Currency, dto.DiscountAmountDecimal, dto.DiscountAmountMinorUnits),
+			EffectivePrice:   effectivePrice,
+		},
+		CreatedAt: timestamppb.New(dto.CreatedAt),
+		UpdatedAt: timestamppb.New(dto.UpdatedAt),
+	}
+	for _, d := range dto.AppliedDiscounts {
+		product.PriceBreakdown.AppliedDiscounts = append(product.PriceBreakdown.AppliedDiscounts,
+			mapAppliedDiscountToProto(d.ID, d.Kind, d.Percentage, d.Amount, dto.Currency))
+	}
+	if dto.ArchivedAt != nil {
+		product.ArchivedAt = timestamppb.New(*dto.ArchivedAt)
 	}
+	product.Attributes = mapAttributesToProto(dto.Attributes)
+	product.Variants = mapVariantsToProto(dto.Variants, dto.Currency)
+	return product
+}
+
+// mapVariantsToProto converts product variants priced in the given currency.
+func mapVariantsToProto(in []variants.VariantDTO, currency string) []*productv1.Variant {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]*productv1.Variant, 0, len(in))
+	for _, v := range in {
+		variant := &productv1.Variant{
+			VariantId:      v.ID,
+			Sku:            v.SKU,
+			Options:        v.Options,
+			EffectivePrice: mapMoneyToProto(0, 0, v.EffectivePriceExact, currency, v.EffectivePriceDecimal, v.EffectivePriceMinorUnits),
+			Status:         v.Status,
+		}
+		if v.PriceOverride != "" {
+			variant.PriceOverride = &productv1.Money{Exact: v.PriceOverride, Currency: currency}
+		}
+		out = append(out, variant)
+	}
+	return out
+}
+
+func mapPriceHistoryDTOToProto(dto *getpricehistory.PriceHistoryDTO) *productv1.GetPriceHistoryReply {
+	reply := &productv1.GetPriceHistoryReply{
+		Periods: make([]*productv1.PricePeriod, 0, len(dto.Periods)),
+	}
+	for _, p := range dto.Periods {
+		reply.Periods = append(reply.Periods, &productv1.PricePeriod{
+			Start:          timestamppb.New(p.From),
+			End:            timestamppb.New(p.To),
+			BasePrice:      mapPriceDTOToProto(p.BasePrice),
+			EffectivePrice: mapPriceDTOToProto(p.EffectivePrice),
+		})
+	}
+	if dto.LowestPrice != nil {
+		reply.LowestPrice = mapPriceDTOToProto(*dto.LowestPrice)
+	}
+	return reply
+}
+
+func mapPriceDTOToProto(dto getpricehistory.PriceDTO) *productv1.Money {
+	return mapMoneyToProto(0, 0, dto.Exact, dto.Currency, dto.Decimal, dto.MinorUnits)
 }
 
 func mapProductListItemDTOToProto(dto listproducts.ProductListItemDTO) *productv1.ProductListItem {
-	return &productv1.ProductListItem{
+	item := &productv1.ProductListItem{
 		ProductId:      dto.ID,
 		Name:           dto.Name,
 		Category:       dto.Category,
 		Status:         dto.Status,
-		EffectivePrice: mapMoneyToProto(dto.EffectivePriceNumerator, dto.EffectivePriceDenominator),
+		EffectivePrice: mapMoneyToProto(
+			dto.EffectivePriceNumerator,
+			dto.EffectivePriceDenominator,
+			dto.EffectivePriceExact,
+			dto.Currency,
+			dto.EffectivePriceDecimal,
+			dto.EffectivePriceMinorUnits,
+		),
+		PriceBreakdown: &productv1.PriceBreakdown{
+			AsOf:             timestamppb.New(dto.AsOf),
+			BasePrice:        mapMoneyToProto(0, 0, dto.BasePriceExact, dto.Currency, dto.BasePriceDecimal, dto.BasePriceMinorUnits),
+			AppliedDiscounts: make([]*productv1.AppliedDiscount, 0, len(dto.AppliedDiscounts)),
+			DiscountAmount:   mapMoneyToProto(0, 0, dto.DiscountAmountExact, dto.Currency, dto.DiscountAmountDecimal, dto.DiscountAmountMinorUnits),
+			EffectivePrice: mapMoneyToProto(
+				dto.EffectivePriceNumerator,
+				dto.EffectivePriceDenominator,
+				dto.EffectivePriceExact,
+				dto.Currency,
+				dto.EffectivePriceDecimal,
+				dto.EffectivePriceMinorUnits,
+			),
+		},
+	}
+	for _, d := range dto.AppliedDiscounts {
+		item.PriceBreakdown.AppliedDiscounts = append(item.PriceBreakdown.AppliedDiscounts,
+			mapAppliedDiscountToProto(d.ID, d.Kind, d.Percentage, d.Amount, dto.Currency))
+	}
+	item.Attributes = mapAttributesToProto(dto.Attributes)
+	return item
+}
+
+func mapAttributesToProto(in map[string]attributes.AttributeDTO) map[string]*productv1.AttributeValue {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make(map[string]*productv1.AttributeValue, len(in))
+	for key, a := range in {
+		v := &productv1.AttributeValue{}
+		switch domain.AttributeType(a.Type) {
+		case domain.AttributeNumber:
+			v.Value = &productv1.AttributeValue_NumberValue{NumberValue: a.Number}
+		case domain.AttributeBool:
+			v.Value = &productv1.AttributeValue_BoolValue{BoolValue: a.Bool}
+		case domain.AttributeEnum:
+			v.Value = &productv1.AttributeValue_EnumValue{EnumValue: a.Text}
+		case domain.AttributeMeasurement:
+			v.Value = &productv1.AttributeValue_MeasurementValue{MeasurementValue: &productv1.Measurement{Value: a.Number, Unit: a.Unit}}
+		default:
+			v.Value = &productv1.AttributeValue_StringValue{StringValue: a.Text}
+		}
+		out[key] = v
 	}
+	return out
 }
 
-func mapMoneyToProto(numerator, denominator int64) *productv1.Money {
+func mapSearchResultDTOToProto(dto searchproducts.SearchResultItemDTO) *productv1.SearchResult {
+	return &productv1.SearchResult{
+		Product: &productv1.ProductListItem{
+			ProductId: dto.Product.ID,
+			Name:      dto.Product.Name,
+			Category:  dto.Product.Category,
+			Status:    "active",
+			EffectivePrice: mapMoneyToProto(
+				dto.Product.EffectivePriceNumerator,
+				dto.Product.EffectivePriceDenominator,
+				dto.Product.EffectivePriceExact,
+				dto.Product.Currency,
+				dto.Product.EffectivePriceDecimal,
+				dto.Product.EffectivePriceMinorUnits,
+			),
+		},
+		Score:                dto.Score,
+		NameHighlight:        dto.NameHighlight,
+		DescriptionHighlight: dto.DescriptionHighlight,
+	}
+}
+
+func mapSuggestionDTOToProto(dto suggestproducts.SuggestionDTO) *productv1.ProductSuggestion {
+	kind := productv1.SuggestionKind_SUGGESTION_KIND_NAME
+	if dto.Kind == "category" {
+		kind = productv1.SuggestionKind_SUGGESTION_KIND_CATEGORY
+	}
+	return &productv1.ProductSuggestion{
+		Text:         dto.Text,
+		Kind:         kind,
+		ProductCount: int32(dto.ProductCount),
+		Corrected:    dto.Corrected,
+	}
+}
+
+// mapFacetsDTOToProto returns nil when no facets were requested.
+func mapFacetsDTOToProto(dto *facets.ResultDTO) *productv1.Facets {
+	if dto == nil {
+		return nil
+	}
+	out := &productv1.Facets{
+		Discounted:    dto.Discounted,
+		NotDiscounted: dto.NotDiscounted,
+	}
+	for _, c := range dto.Categories {
+		out.Categories = append(out.Categories, &productv1.FacetCount{Value: c.Value, Count: c.Count})
+	}
+	for _, c := range dto.Statuses {
+		out.Statuses = append(out.Statuses, &productv1.FacetCount{Value: c.Value, Count: c.Count})
+	}
+	for _, b := range dto.PriceBuckets {
+		out.PriceBuckets = append(out.PriceBuckets, &productv1.PriceBucket{From: b.From, To: b.To, Count: b.Count})
+	}
+	return out
+}
+
+func mapRecategorizationDTOToProto(dto *getrecategorization.RecategorizationDTO) *productv1.Recategorization {
+	out := &productv1.Recategorization{
+		JobId:             dto.ID,
+		FromCategory:      dto.FromCategory,
+		ToCategory:        dto.ToCategory,
+		Status:            dto.Status,
+		Failure:           dto.Failure,
+		MovedProducts:     dto.MovedProducts,
+		RemainingProducts: dto.RemainingProducts,
+		CreatedAt:         timestamppb.New(dto.CreatedAt),
+		UpdatedAt:         timestamppb.New(dto.UpdatedAt),
+	}
+	if dto.FinishedAt != nil {
+		out.FinishedAt = timestamppb.New(*dto.FinishedAt)
+	}
+	return out
+}
+
+// mapAppliedDiscountToProto maps a discount of a price breakdown. The amount
+// is exact only; it is not rounded for presentation.
+func mapAppliedDiscountToProto(id, kind, percentage, amount, currency string) *productv1.AppliedDiscount {
+	out := &productv1.AppliedDiscount{
+		DiscountId: id,
+		Kind:       mapDiscountKindToProto(kind),
+		Percentage: percentage,
+	}
+	if amount != "" {
+		out.Amount = &productv1.Money{Exact: amount, Currency: currency}
+	}
+	return out
+}
+
+func mapDiscountKindToProto(kind string) productv1.DiscountKind {
+	switch domain.DiscountKind(kind) {
+	case domain.DiscountKindFixedAmount:
+		return productv1.DiscountKind_DISCOUNT_KIND_FIXED_AMOUNT
+	case domain.DiscountKindPriceOverride:
+		return productv1.DiscountKind_DISCOUNT_KIND_PRICE_OVERRIDE
+	default:
+		return productv1.DiscountKind_DISCOUNT_KIND_PERCENTAGE
+	}
+}
+
+func mapMoneyToProto(numerator, denominator int64, exact, currency, decimal string, minorUnits int64) *productv1.Money {
 	return &productv1.Money{
 		Numerator:   numerator,
 		Denominator: denominator,
+		Exact:       exact,
+		Currency:    currency,
+		Decimal:     decimal,
+		MinorUnits:  minorUnits,
+	}
+}
+
+// Category mappers
+
+func mapToCreateCategoryRequest(req *productv1.CreateCategoryRequest) (createcategory.Request, error) {
+	schema, err := mapAttributeSchemaFromProto(req.AttributeSchema)
+	if err != nil {
+		return createcategory.Request{}, err
+	}
+	return createcategory.Request{
+		Name:            req.Name,
+		Slug:            req.Slug,
+		ParentID:        req.ParentId,
+		SortOrder:       req.SortOrder,
+		AttributeSchema: schema,
+	}, nil
+}
+
+func mapToUpdateCategoryRequest(req *productv1.UpdateCategoryRequest) (updatecategory.Request, error) {
+	appReq := updatecategory.Request{
+		CategoryID: req.CategoryId,
+		Name:       req.Name,
+		SortOrder:  req.SortOrder,
+		ParentID:   req.ParentId,
+	}
+	if req.AttributeSchema != nil {
+		schema, err := mapAttributeSchemaFromProto(req.AttributeSchema)
+		if err != nil {
+			return updatecategory.Request{}, err
+		}
+		appReq.AttributeSchema = &schema
+	}
+	return appReq, nil
+}
+
+var attributeTypesFromProto = map[productv1.AttributeType]domain.AttributeType{
+	productv1.AttributeType_ATTRIBUTE_TYPE_STRING:      domain.AttributeString,
+	productv1.AttributeType_ATTRIBUTE_TYPE_NUMBER:      domain.AttributeNumber,
+	productv1.AttributeType_ATTRIBUTE_TYPE_BOOL:        domain.AttributeBool,
+	productv1.AttributeType_ATTRIBUTE_TYPE_ENUM:        domain.AttributeEnum,
+	productv1.AttributeType_ATTRIBUTE_TYPE_MEASUREMENT: domain.AttributeMeasurement,
+}
+
+var attributeTypesToProto = map[domain.AttributeType]productv1.AttributeType{
+	domain.AttributeString:      productv1.AttributeType_ATTRIBUTE_TYPE_STRING,
+	domain.AttributeNumber:      productv1.AttributeType_ATTRIBUTE_TYPE_NUMBER,
+	domain.AttributeBool:        productv1.AttributeType_ATTRIBUTE_TYPE_BOOL,
+	domain.AttributeEnum:        productv1.AttributeType_ATTRIBUTE_TYPE_ENUM,
+	domain.AttributeMeasurement: productv1.AttributeType_ATTRIBUTE_TYPE_MEASUREMENT,
+}
+
+// mapAttributeSchemaFromProto returns an empty, non-nil schema for a
+// missing or empty message.
+func mapAttributeSchemaFromProto(schema *productv1.AttributeSchema) ([]domain.AttributeDefinition, error) {
+	defs := make([]domain.AttributeDefinition, 0, len(schema.GetAttributes()))
+	for i, a := range schema.GetAttributes() {
+		typ, ok := attributeTypesFromProto[a.Type]
+		if !ok {
+			return nil, fmt.Errorf("attribute_schema.attributes[%d].type is required", i)
+		}
+		d, err := domain.NewAttributeDefinition(a.Key, typ, a.Required, a.AllowedValues)
+		if err != nil {
+			return nil, fmt.Errorf("attribute_schema.attributes[%d]: %w", i, err)
+		}
+		defs = append(defs, d)
+	}
+	return defs, nil
+}
+
+func mapAttributeSchemaToProto(schema []categorytree.AttributeDefinitionDTO) *productv1.AttributeSchema {
+	out := &productv1.AttributeSchema{}
+	for _, d := range schema {
+		out.Attributes = append(out.Attributes, &productv1.AttributeDefinition{
+			Key:           d.Key,
+			Type:          attributeTypesToProto[domain.AttributeType(d.Type)],
+			Required:      d.Required,
+			AllowedValues: d.AllowedValues,
+		})
+	}
+	return out
+}
+
+func mapToDeleteCategoryRequest(req *productv1.DeleteCategoryRequest) deletecategory.Request {
+	return deletecategory.Request{
+		CategoryID: req.CategoryId,
+	}
+}
+
+func mapToGetCategoryRequest(req *productv1.GetCategoryRequest) getcategory.Request {
+	return getcategory.Request{
+		CategoryID: req.GetCategoryId(),
+		Slug:       req.GetSlug(),
+	}
+}
+
+func mapToListCategoriesRequest(req *productv1.ListCategoriesRequest) listcategories.Request {
+	return listcategories.Request{
+		ParentID: req.ParentId,
+	}
+}
+
+func mapCategoryDTOToProto(dto categorytree.CategoryDTO) *productv1.Category {
+	return &productv1.Category{
+		CategoryId:      dto.ID,
+		Name:            dto.Name,
+		Slug:            dto.Slug,
+		ParentId:        dto.ParentID,
+		SortOrder:       dto.SortOrder,
+		Depth:           int32(dto.Depth),
+		CreatedAt:       timestamppb.New(dto.CreatedAt),
+		UpdatedAt:       timestamppb.New(dto.UpdatedAt),
+		AttributeSchema: mapAttributeSchemaToProto(dto.AttributeSchema),
 	}
 }
@@ -0,0 +1,58 @@
+package product
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// Read masks select top-level fields of the returned resource, e.g.
+// "name,effective_price". An empty mask or "*" selects all fields.
+
+// validateReadMask checks that every path of mask names a top-level field
+// of resource.
+func validateReadMask(mask *fieldmaskpb.FieldMask, resource proto.Message) error {
+	fields := resource.ProtoReflect().Descriptor().Fields()
+	for _, path := range mask.GetPaths() {
+		if path == "*" {
+			continue
+		}
+		if fields.ByName(protoreflect.Name(path)) == nil {
+			return fmt.Errorf("read_mask: unknown or nested field %q", path)
+		}
+	}
+	return nil
+}
+
+// readMaskFields returns the field names selected by mask, or nil when all
+// fields are selected.
+func readMaskFields(mask *fieldmaskpb.FieldMask) []string {
+	paths := mask.GetPaths()
+	for _, path := range paths {
+		if path == "*" {
+			return nil
+		}
+	}
+	return paths
+}
+
+// applyReadMask clears the fields of resource not selected by mask.
+func applyReadMask(resource proto.Message, mask *fieldmaskpb.FieldMask) {
+	selected := readMaskFields(mask)
+	if len(selected) == 0 {
+		return
+	}
+	keep := make(map[protoreflect.Name]bool, len(selected))
+	for _, name := range selected {
+		keep[protoreflect.Name(name)] = true
+	}
+	m := resource.ProtoReflect()
+	m.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		if !keep[fd.Name()] {
+			m.Clear(fd)
+		}
+		return true
+	})
+}
@@ -0,0 +1,42 @@
+package product
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	productv1 "product-catalog-service/proto/product/v1"
+)
+
+// RecategorizeProducts implements the RecategorizeProducts gRPC method.
+func (h *ProductHandler) RecategorizeProducts(ctx context.Context, req *productv1.RecategorizeProductsRequest) (*productv1.RecategorizeProductsReply, error) {
+	// 1. Validate proto request
+	if err := validateRecategorizeRequest(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// 2. Map proto to application request
+	appReq := mapToRecategorizeProductsRequest(req)
+
+	// 3. Call usecase (usecase applies plan internally)
+	jobID, err := h.commands.RecategorizeProducts.Execute(ctx, appReq)
+	if err != nil {
+		return nil, mapDomainErrorToGRPC(err)
+	}
+
+	// 4. Return response
+	return &productv1.RecategorizeProductsReply{
+		JobId: jobID,
+	}, nil
+}
+
+func validateRecategorizeRequest(req *productv1.RecategorizeProductsRequest) error {
+	if req.FromCategory == "" {
+		return status.Error(codes.InvalidArgument, "from_category is required")
+	}
+	if req.ToCategory == "" {
+		return status.Error(codes.InvalidArgument, "to_category is required")
+	}
+	return nil
+}
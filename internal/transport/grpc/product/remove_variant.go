@@ -0,0 +1,39 @@
+package product
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	productv1 "product-catalog-service/proto/product/v1"
+)
+
+// RemoveVariant implements the RemoveVariant gRPC method.
+func (h *ProductHandler) RemoveVariant(ctx context.Context, req *productv1.RemoveVariantRequest) (*productv1.RemoveVariantReply, error) {
+	// 1. Validate proto request
+	if err := validateRemoveVariantRequest(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// 2. Map proto to application request
+	appReq := mapToRemoveVariantRequest(req)
+
+	// 3. Call usecase (usecase applies plan internally)
+	if err := h.commands.RemoveVariant.Execute(ctx, appReq); err != nil {
+		return nil, mapDomainErrorToGRPC(err)
+	}
+
+	// 4. Return response
+	return &productv1.RemoveVariantReply{}, nil
+}
+
+func validateRemoveVariantRequest(req *productv1.RemoveVariantRequest) error {
+	if req.ProductId == "" {
+		return status.Error(codes.InvalidArgument, "product_id is required")
+	}
+	if req.VariantId == "" {
+		return status.Error(codes.InvalidArgument, "variant_id is required")
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package product
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	productv1 "product-catalog-service/proto/product/v1"
+)
+
+// SchedulePrice implements the SchedulePrice gRPC method.
+func (h *ProductHandler) SchedulePrice(ctx context.Context, req *productv1.SchedulePriceRequest) (*productv1.SchedulePriceReply, error) {
+	// 1. Validate proto request
+	if err := validateSchedulePriceRequest(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// 2. Map proto to application request
+	appReq := mapToSchedulePriceRequest(req)
+
+	// 3. Call usecase (usecase applies plan internally)
+	scheduledPriceID, err := h.commands.SchedulePrice.Execute(ctx, appReq)
+	if err != nil {
+		return nil, mapDomainErrorToGRPC(err)
+	}
+
+	// 4. Return response
+	return &productv1.SchedulePriceReply{
+		ScheduledPriceId: scheduledPriceID,
+	}, nil
+}
+
+func validateSchedulePriceRequest(req *productv1.SchedulePriceRequest) error {
+	if req.ProductId == "" {
+		return status.Error(codes.InvalidArgument, "product_id is required")
+	}
+	if req.Price == "" {
+		return status.Error(codes.InvalidArgument, "price is required")
+	}
+	if req.EffectiveFrom == nil {
+		return status.Error(codes.InvalidArgument, "effective_from is required")
+	}
+	return nil
+}
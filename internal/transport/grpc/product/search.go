@@ -0,0 +1,58 @@
+package product
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	searchproducts "product-catalog-service/internal/app/product/queries/search_products"
+	productv1 "product-catalog-service/proto/product/v1"
+)
+
+// SearchProducts implements the SearchProducts gRPC method.
+func (h *ProductHandler) SearchProducts(ctx context.Context, req *productv1.SearchProductsRequest) (*productv1.SearchProductsReply, error) {
+	// 1. Validate proto request
+	if err := validateSearchRequest(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// 2. Map proto to application request
+	appReq := mapToSearchProductsRequest(req)
+
+	// 3. Call query
+	result, err := h.queries.SearchProducts.Execute(ctx, appReq)
+	if err != nil {
+		return nil, mapDomainErrorToGRPC(err)
+	}
+
+	// 4. Map response
+	results := make([]*productv1.SearchResult, 0, len(result.Results))
+	for _, r := range result.Results {
+		results = append(results, mapSearchResultDTOToProto(r))
+	}
+
+	// 5. Return response
+	return &productv1.SearchProductsReply{
+		Results:       results,
+		NextPageToken: result.NextPageToken,
+		Facets:        mapFacetsDTOToProto(result.Facets),
+	}, nil
+}
+
+func validateSearchRequest(req *productv1.SearchProductsRequest) error {
+	if req.Query == "" {
+		return status.Error(codes.InvalidArgument, "query is required")
+	}
+	if len(req.Query) > searchproducts.MaxQueryLength {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("query must be at most %d bytes", searchproducts.MaxQueryLength))
+	}
+	if req.PageSize < 0 {
+		return status.Error(codes.InvalidArgument, "page_size must be >= 0")
+	}
+	if req.PageSize > searchproducts.MaxPageSize {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("page_size must be <= %d", searchproducts.MaxPageSize))
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package product
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	suggestproducts "product-catalog-service/internal/app/product/queries/suggest_products"
+	productv1 "product-catalog-service/proto/product/v1"
+)
+
+// SuggestProducts implements the SuggestProducts gRPC method.
+func (h *ProductHandler) SuggestProducts(ctx context.Context, req *productv1.SuggestProductsRequest) (*productv1.SuggestProductsReply, error) {
+	// 1. Validate proto request
+	if err := validateSuggestRequest(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// 2. Map proto to application request
+	appReq := mapToSuggestProductsRequest(req)
+
+	// 3. Call query
+	result, err := h.queries.SuggestProducts.Execute(ctx, appReq)
+	if err != nil {
+		return nil, mapDomainErrorToGRPC(err)
+	}
+
+	// 4. Map response
+	suggestions := make([]*productv1.ProductSuggestion, 0, len(result.Suggestions))
+	for _, s := range result.Suggestions {
+		suggestions = append(suggestions, mapSuggestionDTOToProto(s))
+	}
+
+	// 5. Return response
+	return &productv1.SuggestProductsReply{
+		Suggestions: suggestions,
+	}, nil
+}
+
+func validateSuggestRequest(req *productv1.SuggestProductsRequest) error {
+	if req.Prefix == "" {
+		return status.Error(codes.InvalidArgument, "prefix is required")
+	}
+	if len(req.Prefix) > suggestproducts.MaxPrefixLength {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("prefix must be at most %d bytes", suggestproducts.MaxPrefixLength))
+	}
+	if req.Limit < 0 {
+		return status.Error(codes.InvalidArgument, "limit must be >= 0")
+	}
+	if req.Limit > suggestproducts.MaxLimit {
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("limit must be <= %d", suggestproducts.MaxLimit))
+	}
+	return nil
+}
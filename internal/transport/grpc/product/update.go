@@ -17,7 +17,10 @@ func (h *ProductHandler) UpdateProduct(ctx context.Context, req *productv1.Updat
 	}
 
 	// 2. Map proto to application request
-	appReq := mapToUpdateProductRequest(req)
+	appReq, err := mapToUpdateProductRequest(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 
 	// 3. Call usecase (usecase applies plan internally)
 	if err := h.commands.UpdateProduct.Execute(ctx, appReq); err != nil {
@@ -33,8 +36,9 @@ func validateUpdateRequest(req *productv1.UpdateProductRequest) error {
 		return status.Error(codes.InvalidArgument, "product_id is required")
 	}
 	// At least one field must be provided
-	if req.Name == nil && req.Description == nil && req.Category == nil {
-		return status.Error(codes.InvalidArgument, "at least one field (name, description, category) must be provided")
+	if req.Name == nil && req.Description == nil && req.Category == nil &&
+		len(req.Attributes) == 0 && len(req.RemoveAttributes) == 0 {
+		return status.Error(codes.InvalidArgument, "at least one field (name, description, category, attributes, remove_attributes) must be provided")
 	}
 	return nil
 }
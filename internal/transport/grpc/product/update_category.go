@@ -0,0 +1,42 @@
+package product
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	productv1 "product-catalog-service/proto/product/v1"
+)
+
+// UpdateCategory implements the UpdateCategory gRPC method.
+func (h *CategoryHandler) UpdateCategory(ctx context.Context, req *productv1.UpdateCategoryRequest) (*productv1.UpdateCategoryReply, error) {
+	// 1. Validate proto request
+	if err := validateUpdateCategoryRequest(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// 2. Map proto to application request
+	appReq, err := mapToUpdateCategoryRequest(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// 3. Call usecase (usecase applies plan internally)
+	if err := h.updateCategory.Execute(ctx, appReq); err != nil {
+		return nil, mapDomainErrorToGRPC(err)
+	}
+
+	// 4. Return response
+	return &productv1.UpdateCategoryReply{}, nil
+}
+
+func validateUpdateCategoryRequest(req *productv1.UpdateCategoryRequest) error {
+	if req.CategoryId == "" {
+		return status.Error(codes.InvalidArgument, "category_id is required")
+	}
+	if req.Name == nil && req.SortOrder == nil && req.ParentId == nil && req.AttributeSchema == nil {
+		return status.Error(codes.InvalidArgument, "at least one field (name, sort_order, parent_id, attribute_schema) must be provided")
+	}
+	return nil
+}
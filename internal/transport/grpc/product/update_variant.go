@@ -0,0 +1,42 @@
+package product
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	productv1 "product-catalog-service/proto/product/v1"
+)
+
+// UpdateVariant implements the UpdateVariant gRPC method.
+func (h *ProductHandler) UpdateVariant(ctx context.Context, req *productv1.UpdateVariantRequest) (*productv1.UpdateVariantReply, error) {
+	// 1. Validate proto request
+	if err := validateUpdateVariantRequest(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// 2. Map proto to application request
+	appReq := mapToUpdateVariantRequest(req)
+
+	// 3. Call usecase (usecase applies plan internally)
+	if err := h.commands.UpdateVariant.Execute(ctx, appReq); err != nil {
+		return nil, mapDomainErrorToGRPC(err)
+	}
+
+	// 4. Return response
+	return &productv1.UpdateVariantReply{}, nil
+}
+
+func validateUpdateVariantRequest(req *productv1.UpdateVariantRequest) error {
+	if req.ProductId == "" {
+		return status.Error(codes.InvalidArgument, "product_id is required")
+	}
+	if req.VariantId == "" {
+		return status.Error(codes.InvalidArgument, "variant_id is required")
+	}
+	if req.Sku == nil && len(req.Options) == 0 && req.PriceOverride == nil && req.Status == nil {
+		return status.Error(codes.InvalidArgument, "at least one of sku, options, price_override or status is required")
+	}
+	return nil
+}
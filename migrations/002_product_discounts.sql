@@ -0,0 +1,22 @@
+-- Multiple stacked discounts per product.
+-- The single-discount columns on products (discount_percent,
+-- discount_start_date, discount_end_date) are no longer read or written;
+-- existing discounts are copied over, keyed by their product ID.
+
+CREATE TABLE product_discounts (
+    product_id STRING(36) NOT NULL,
+    discount_id STRING(36) NOT NULL,
+    percentage NUMERIC NOT NULL,
+    start_date TIMESTAMP NOT NULL,
+    end_date TIMESTAMP NOT NULL,
+    priority INT64 NOT NULL,
+    exclusive BOOL NOT NULL,
+) PRIMARY KEY (product_id, discount_id),
+  INTERLEAVE IN PARENT products ON DELETE CASCADE;
+
+INSERT INTO product_discounts (product_id, discount_id, percentage, start_date, end_date, priority, exclusive)
+SELECT product_id, product_id, discount_percent, discount_start_date, discount_end_date, 0, FALSE
+FROM products
+WHERE discount_percent IS NOT NULL
+  AND discount_start_date IS NOT NULL
+  AND discount_end_date IS NOT NULL;
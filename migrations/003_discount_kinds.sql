@@ -0,0 +1,7 @@
+-- Fixed-amount and price-override discounts.
+-- Existing rows are percentage discounts.
+
+ALTER TABLE product_discounts ADD COLUMN kind STRING(20) NOT NULL DEFAULT ('percentage');
+ALTER TABLE product_discounts ADD COLUMN amount_numerator INT64;
+ALTER TABLE product_discounts ADD COLUMN amount_denominator INT64;
+ALTER TABLE product_discounts ALTER COLUMN percentage NUMERIC;
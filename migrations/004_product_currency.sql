@@ -0,0 +1,3 @@
+-- Currency of the product price (ISO 4217). Existing products are priced in USD.
+
+ALTER TABLE products ADD COLUMN currency STRING(3) NOT NULL DEFAULT ('USD');
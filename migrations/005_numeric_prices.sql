@@ -0,0 +1,20 @@
+-- Exact NUMERIC prices. The int64 fraction columns overflow once discounts
+-- grow numerators and denominators; they are no longer written. Existing
+-- rows are backfilled from them, so queries can read base_price alone.
+
+ALTER TABLE products ADD COLUMN base_price NUMERIC;
+ALTER TABLE products ALTER COLUMN base_price_numerator INT64;
+ALTER TABLE products ALTER COLUMN base_price_denominator INT64;
+ALTER TABLE product_discounts ADD COLUMN amount NUMERIC;
+
+UPDATE products
+SET base_price = CAST(base_price_numerator AS NUMERIC) / CAST(base_price_denominator AS NUMERIC)
+WHERE base_price IS NULL
+  AND base_price_numerator IS NOT NULL
+  AND base_price_denominator IS NOT NULL;
+
+UPDATE product_discounts
+SET amount = CAST(amount_numerator AS NUMERIC) / CAST(amount_denominator AS NUMERIC)
+WHERE amount IS NULL
+  AND amount_numerator IS NOT NULL
+  AND amount_denominator IS NOT NULL;
@@ -0,0 +1,5 @@
+-- Indexes for the discount sweeper, which looks up discounts whose window
+-- starts or ends between two sweeps.
+
+CREATE INDEX product_discounts_by_start_date ON product_discounts(start_date);
+CREATE INDEX product_discounts_by_end_date ON product_discounts(end_date);
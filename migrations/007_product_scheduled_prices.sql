@@ -0,0 +1,13 @@
+-- Future base prices. Each row replaces the product base price from
+-- effective_from on; the discount sweeper applies due rows.
+
+CREATE TABLE product_scheduled_prices (
+    product_id STRING(36) NOT NULL,
+    scheduled_price_id STRING(36) NOT NULL,
+    price NUMERIC NOT NULL,
+    effective_from TIMESTAMP NOT NULL,
+) PRIMARY KEY (product_id, scheduled_price_id),
+  INTERLEAVE IN PARENT products ON DELETE CASCADE;
+
+CREATE INDEX product_scheduled_prices_by_effective_from
+  ON product_scheduled_prices(effective_from);
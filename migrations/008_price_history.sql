@@ -0,0 +1,15 @@
+-- Append-only ledger of product pricing. Every change to the base price,
+-- discounts or scheduled prices appends the full pricing state, so the
+-- effective price at any past instant can be recomputed.
+
+CREATE TABLE price_history (
+    product_id STRING(36) NOT NULL,
+    recorded_at TIMESTAMP NOT NULL,
+    entry_id STRING(36) NOT NULL,
+    reason STRING(100) NOT NULL,
+    base_price NUMERIC NOT NULL,
+    currency STRING(3) NOT NULL,
+    discounts STRING(MAX) NOT NULL,
+    scheduled_prices STRING(MAX) NOT NULL,
+) PRIMARY KEY (product_id, recorded_at, entry_id),
+  INTERLEAVE IN PARENT products ON DELETE NO ACTION;
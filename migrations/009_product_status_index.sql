@@ -0,0 +1,4 @@
+-- Index for the back-office listing, which filters products by status and
+-- pages through them by product_id.
+
+CREATE INDEX products_by_status ON products(status, product_id) STORING (archived_at);
@@ -0,0 +1,7 @@
+-- Indexes for ordering listings. Each sort key is tie-broken by product_id
+-- so pages can resume after the last (key, product_id) pair.
+
+CREATE INDEX products_by_name ON products(name, product_id) STORING (status, category, archived_at);
+CREATE INDEX products_by_created_at ON products(created_at, product_id) STORING (status, category, archived_at);
+CREATE INDEX products_by_updated_at ON products(updated_at, product_id) STORING (status, category, archived_at);
+CREATE INDEX products_by_base_price ON products(base_price, product_id) STORING (status, category, archived_at);
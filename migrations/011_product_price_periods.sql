@@ -0,0 +1,13 @@
+-- Effective price timeline of each product, derived from its base price,
+-- discounts and scheduled prices whenever they change. Listings filter and
+-- sort by the effective price at as_of using the period in force then.
+-- Products written before this migration get their periods from the
+-- BackfillPricePeriods job, which the server runs on startup.
+
+CREATE TABLE product_price_periods (
+    product_id STRING(36) NOT NULL,
+    valid_from TIMESTAMP NOT NULL,
+    valid_until TIMESTAMP NOT NULL,
+    effective_price NUMERIC NOT NULL,
+) PRIMARY KEY (product_id, valid_from),
+  INTERLEAVE IN PARENT products ON DELETE CASCADE;
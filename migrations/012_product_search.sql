@@ -0,0 +1,14 @@
+-- Full-text search over product name, description and category.
+-- Cloud Spanner only: the emulator does not support search indexes, so
+-- skip this file locally; the service then uses its embedded index
+-- (see SEARCH_BACKEND).
+
+ALTER TABLE products ADD COLUMN name_tokens TOKENLIST AS (TOKENIZE_FULLTEXT(name)) HIDDEN;
+ALTER TABLE products ADD COLUMN description_tokens TOKENLIST AS (TOKENIZE_FULLTEXT(description)) HIDDEN;
+ALTER TABLE products ADD COLUMN category_tokens TOKENLIST AS (TOKENIZE_FULLTEXT(category)) HIDDEN;
+ALTER TABLE products ADD COLUMN search_tokens TOKENLIST
+  AS (TOKENLIST_CONCAT([name_tokens, description_tokens, category_tokens])) HIDDEN;
+
+CREATE SEARCH INDEX products_search
+  ON products(name_tokens, description_tokens, category_tokens, search_tokens)
+  STORING (status, archived_at);
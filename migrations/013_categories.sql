@@ -0,0 +1,18 @@
+-- Category tree. Products reference a category by its slug in
+-- products.category; slugs are unique and never change.
+-- Existing products keep their free-text category. Create a category with
+-- a matching slug for each value in use (SELECT DISTINCT category FROM
+-- products) before changing those products' categories.
+
+CREATE TABLE categories (
+    category_id STRING(36) NOT NULL,
+    name STRING(100) NOT NULL,
+    slug STRING(100) NOT NULL,
+    parent_id STRING(36),
+    sort_order INT64 NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+) PRIMARY KEY (category_id);
+
+CREATE UNIQUE INDEX idx_categories_slug ON categories(slug);
+CREATE INDEX idx_categories_parent ON categories(parent_id, sort_order);
@@ -0,0 +1,27 @@
+-- Bulk moves of every product from one category to another, run in
+-- batches by a background job. Each committed batch inserts its checkpoint
+-- row together with the product updates, so a batch is applied at most
+-- once even when several instances run the job, and a crashed job resumes
+-- after its last committed batch.
+
+CREATE TABLE recategorization_jobs (
+    job_id STRING(36) NOT NULL,
+    from_category STRING(100) NOT NULL,
+    to_category STRING(100) NOT NULL,
+    status STRING(20) NOT NULL,
+    batches INT64 NOT NULL,
+    moved_products INT64 NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    finished_at TIMESTAMP,
+) PRIMARY KEY (job_id);
+
+CREATE INDEX idx_recategorization_jobs_status ON recategorization_jobs(status);
+
+CREATE TABLE recategorization_batches (
+    job_id STRING(36) NOT NULL,
+    batch INT64 NOT NULL,
+    moved_products INT64 NOT NULL,
+    committed_at TIMESTAMP NOT NULL,
+) PRIMARY KEY (job_id, batch),
+  INTERLEAVE IN PARENT recategorization_jobs ON DELETE CASCADE;
@@ -0,0 +1,19 @@
+-- Typed product attributes such as brand, color or size. Exactly one value
+-- column is set, by type: string_value for string and enum attributes,
+-- number_value for number and measurement attributes, bool_value for bool
+-- attributes. Measurements also store their unit.
+
+CREATE TABLE product_attributes (
+    product_id STRING(36) NOT NULL,
+    attribute_key STRING(64) NOT NULL,
+    type STRING(16) NOT NULL,
+    string_value STRING(1000),
+    number_value NUMERIC,
+    bool_value BOOL,
+    unit STRING(16),
+) PRIMARY KEY (product_id, attribute_key),
+  INTERLEAVE IN PARENT products ON DELETE CASCADE;
+
+-- Attribute filters look up products by key and value.
+CREATE INDEX idx_product_attributes_string ON product_attributes(attribute_key, string_value);
+CREATE INDEX idx_product_attributes_number ON product_attributes(attribute_key, number_value);
@@ -0,0 +1,13 @@
+-- Attribute schemas: the attributes each category declares for its
+-- products. allowed_values is only set for enum attributes. Products are
+-- checked against their category's schema when created, updated or
+-- activated; existing products are not rechecked when a schema changes.
+
+CREATE TABLE category_attributes (
+    category_id STRING(36) NOT NULL,
+    attribute_key STRING(64) NOT NULL,
+    type STRING(16) NOT NULL,
+    required BOOL NOT NULL,
+    allowed_values ARRAY<STRING(100)>,
+) PRIMARY KEY (category_id, attribute_key),
+  INTERLEAVE IN PARENT categories ON DELETE CASCADE;
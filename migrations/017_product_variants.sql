@@ -0,0 +1,16 @@
+-- Product variants: sellable versions of a product such as a T-shirt in
+-- size M and color red. options is a JSON object of option values by
+-- option name, e.g. {"color": "red", "size": "M"}. price_override replaces
+-- the product base price when set. SKUs are unique across all products.
+
+CREATE TABLE product_variants (
+    product_id STRING(36) NOT NULL,
+    variant_id STRING(36) NOT NULL,
+    sku STRING(64) NOT NULL,
+    options JSON NOT NULL,
+    price_override NUMERIC,
+    status STRING(16) NOT NULL,
+) PRIMARY KEY (product_id, variant_id),
+  INTERLEAVE IN PARENT products ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX idx_product_variants_sku ON product_variants(sku);
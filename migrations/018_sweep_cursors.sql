@@ -0,0 +1,12 @@
+-- Position of background sweeps. The discount sweep handles price
+-- transitions in (transition time, product_id) order and commits its
+-- position together with the changes of each transition, so a crashed or
+-- restarted sweep resumes after the last transition it committed.
+
+CREATE TABLE sweep_cursors (
+    name STRING(50) NOT NULL,
+    position_at TIMESTAMP NOT NULL,
+    position_product_id STRING(36) NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+) PRIMARY KEY (name);
+
@@ -0,0 +1,5 @@
+-- A recategorization job fails instead of moving a product that does not
+-- match the target category's attribute schema. failure describes why the
+-- job stopped and is NULL unless status is "failed".
+
+ALTER TABLE recategorization_jobs ADD COLUMN failure STRING(MAX);
@@ -0,0 +1,6 @@
+-- Index for the search and suggestion feeds, which page through
+-- outbox_events in (created_at, event_id) order after the last event they
+-- applied. Storing aggregate_id lets a sync read the index alone instead of
+-- scanning the whole outbox.
+
+CREATE INDEX idx_outbox_created ON outbox_events(created_at, event_id) STORING (aggregate_id);
@@ -3,6 +3,8 @@ package e2e
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"math/big"
 	"testing"
 	"time"
@@ -12,20 +14,41 @@ import (
 	"github.com/stretchr/testify/require"
 	"google.golang.org/api/iterator"
 
+	"product-catalog-service/internal/app/product/contracts"
 	"product-catalog-service/internal/app/product/domain"
 	"product-catalog-service/internal/app/product/domain/services"
+	batchgetproducts "product-catalog-service/internal/app/product/queries/batch_get_products"
+	"product-catalog-service/internal/app/product/queries/facets"
 	"product-catalog-service/internal/app/product/queries/getproduct"
 	"product-catalog-service/internal/app/product/queries/listproducts"
+	searchproducts "product-catalog-service/internal/app/product/queries/search_products"
+	suggestproducts "product-catalog-service/internal/app/product/queries/suggest_products"
 	"product-catalog-service/internal/app/product/repo"
 	createproduct "product-catalog-service/internal/app/product/usecases/create_product"
 	updateproduct "product-catalog-service/internal/app/product/usecases/update_product"
 	activateproduct "product-catalog-service/internal/app/product/usecases/activate_product"
 	deactivateproduct "product-catalog-service/internal/app/product/usecases/deactivate_product"
 	applydiscount "product-catalog-service/internal/app/product/usecases/apply_discount"
+	backfillpriceperiods "product-catalog-service/internal/app/product/usecases/backfill_price_periods"
 	removediscount "product-catalog-service/internal/app/product/usecases/remove_discount"
+	addvariant "product-catalog-service/internal/app/product/usecases/add_variant"
+	updatevariant "product-catalog-service/internal/app/product/usecases/update_variant"
+	removevariant "product-catalog-service/internal/app/product/usecases/remove_variant"
 	archiveproduct "product-catalog-service/internal/app/product/usecases/archive_product"
+	sweepdiscounts "product-catalog-service/internal/app/product/usecases/sweep_discounts"
+	scheduleprice "product-catalog-service/internal/app/product/usecases/schedule_price"
+	createcategory "product-catalog-service/internal/app/product/usecases/create_category"
+	updatecategory "product-catalog-service/internal/app/product/usecases/update_category"
+	deletecategory "product-catalog-service/internal/app/product/usecases/delete_category"
+	getcategory "product-catalog-service/internal/app/product/queries/get_category"
+	listcategories "product-catalog-service/internal/app/product/queries/list_categories"
+	getrecategorization "product-catalog-service/internal/app/product/queries/get_recategorization"
+	recategorizeproducts "product-catalog-service/internal/app/product/usecases/recategorize_products"
+	runrecategorizations "product-catalog-service/internal/app/product/usecases/run_recategorizations"
 	"product-catalog-service/internal/pkg/clock"
 	"product-catalog-service/internal/pkg/committer"
+	"product-catalog-service/internal/pkg/filter"
+	"product-catalog-service/internal/pkg/pagetoken"
 )
 
 var (
@@ -33,6 +56,7 @@ var (
 	testCtx    context.Context
 	testClock  clock.Clock
 	committer_ *committer.PlanCommitter
+	testTokens *pagetoken.Codec
 )
 
 func setupTestDB(t *testing.T) {
@@ -50,6 +74,7 @@ func setupTestDB(t *testing.T) {
 	testCtx = context.Background()
 	testClock = clock.SystemClock{}
 	committer_ = committer.New(client)
+	testTokens = pagetoken.NewCodec([]byte("e2e-page-token-key"), time.Hour)
 }
 
 func teardownTestDB(t *testing.T) {
@@ -89,6 +114,19 @@ func getOutboxEvents(t *testing.T, aggregateID string) []OutboxEvent {
 	return events
 }
 
+// ensureCategories creates root categories with the given slugs unless
+// they exist, so products can be filed under them.
+func ensureCategories(t *testing.T, slugs ...string) {
+	createCategory := createcategory.New(repo.NewCategoryRepo(testDB), repo.NewOutboxRepo(), committer_, testClock)
+	for _, slug := range slugs {
+		_, err := createCategory.Execute(testCtx, createcategory.Request{Name: slug, Slug: slug})
+		if errors.Is(err, domain.ErrCategorySlugTaken) {
+			continue
+		}
+		require.NoError(t, err)
+	}
+}
+
 type OutboxEvent struct {
 	EventID     string
 	EventType   string
@@ -102,14 +140,17 @@ func TestProductCreationFlow(t *testing.T) {
 	setupTestDB(t)
 	defer teardownTestDB(t)
 
-	productRepo := repo.NewProductRepo(testDB)
+	productRepo := repo.NewProductRepo(testDB, services.PricingCalculator{})
 	outboxRepo := repo.NewOutboxRepo()
+	categoryRepo := repo.NewCategoryRepo(testDB)
 	readModel := repo.NewReadModel(testDB)
 	pricing := services.PricingCalculator{}
 
-	createUsecase := createproduct.New(productRepo, outboxRepo, committer_, testClock)
+	createUsecase := createproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
 	getQuery := getproduct.New(readModel, pricing)
 
+	ensureCategories(t, "electronics")
+
 	// Test: Create product
 	productID, err := createUsecase.Execute(testCtx, createproduct.Request{
 		Name:                 "Test Product",
@@ -132,6 +173,7 @@ func TestProductCreationFlow(t *testing.T) {
 	assert.Equal(t, "inactive", product.Status)
 	assert.Equal(t, int64(1999), product.EffectivePriceNumerator)
 	assert.Equal(t, int64(100), product.EffectivePriceDenominator)
+	assert.Equal(t, "19.99", product.EffectivePriceExact)
 
 	// Verify: Outbox event was created
 	events := getOutboxEvents(t, productID)
@@ -145,15 +187,18 @@ func TestProductUpdateFlow(t *testing.T) {
 	setupTestDB(t)
 	defer teardownTestDB(t)
 
-	productRepo := repo.NewProductRepo(testDB)
+	productRepo := repo.NewProductRepo(testDB, services.PricingCalculator{})
 	outboxRepo := repo.NewOutboxRepo()
+	categoryRepo := repo.NewCategoryRepo(testDB)
 	readModel := repo.NewReadModel(testDB)
 	pricing := services.PricingCalculator{}
 
-	createUsecase := createproduct.New(productRepo, outboxRepo, committer_, testClock)
-	updateUsecase := updateproduct.New(productRepo, outboxRepo, committer_, testClock)
+	createUsecase := createproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	updateUsecase := updateproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
 	getQuery := getproduct.New(readModel, pricing)
 
+	ensureCategories(t, "original", "updated")
+
 	// Setup: Create product
 	productID, err := createUsecase.Execute(testCtx, createproduct.Request{
 		Name:                 "Original Name",
@@ -195,16 +240,19 @@ func TestDiscountApplicationFlow(t *testing.T) {
 	setupTestDB(t)
 	defer teardownTestDB(t)
 
-	productRepo := repo.NewProductRepo(testDB)
+	productRepo := repo.NewProductRepo(testDB, services.PricingCalculator{})
 	outboxRepo := repo.NewOutboxRepo()
+	categoryRepo := repo.NewCategoryRepo(testDB)
 	readModel := repo.NewReadModel(testDB)
 	pricing := services.PricingCalculator{}
 
-	createUsecase := createproduct.New(productRepo, outboxRepo, committer_, testClock)
-	activateUsecase := activateproduct.New(productRepo, outboxRepo, committer_, testClock)
+	createUsecase := createproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	activateUsecase := activateproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
 	applyDiscountUsecase := applydiscount.New(productRepo, outboxRepo, committer_, testClock)
 	getQuery := getproduct.New(readModel, pricing)
 
+	ensureCategories(t, "electronics")
+
 	// Setup: Create and activate product
 	productID, err := createUsecase.Execute(testCtx, createproduct.Request{
 		Name:                 "Discounted Product",
@@ -222,7 +270,7 @@ func TestDiscountApplicationFlow(t *testing.T) {
 
 	// Test: Apply 20% discount
 	now := time.Now()
-	err = applyDiscountUsecase.Execute(testCtx, applydiscount.Request{
+	_, err = applyDiscountUsecase.Execute(testCtx, applydiscount.Request{
 		ProductID:            productID,
 		PercentageNumerator:   20,
 		PercentageDenominator: 100, // 20%
@@ -242,6 +290,16 @@ func TestDiscountApplicationFlow(t *testing.T) {
 	assert.Equal(t, int64(8000), product.EffectivePriceNumerator)
 	assert.Equal(t, int64(100), product.EffectivePriceDenominator)
 
+	// Verify: Details include the base price, discount window and timestamps
+	assert.Equal(t, "100", product.BasePriceExact)
+	require.Len(t, product.Discounts, 1)
+	assert.Equal(t, "0.2", product.Discounts[0].Percentage)
+	assert.WithinDuration(t, now.Add(-1*time.Hour), product.Discounts[0].Start, time.Millisecond)
+	assert.WithinDuration(t, now.Add(24*time.Hour), product.Discounts[0].End, time.Millisecond)
+	assert.False(t, product.CreatedAt.IsZero())
+	assert.False(t, product.UpdatedAt.Before(product.CreatedAt))
+	assert.Nil(t, product.ArchivedAt)
+
 	// Verify: Outbox event was created
 	events := getOutboxEvents(t, productID)
 	require.GreaterOrEqual(t, len(events), 3) // created + activated + discount applied
@@ -259,16 +317,19 @@ func TestProductActivationDeactivation(t *testing.T) {
 	setupTestDB(t)
 	defer teardownTestDB(t)
 
-	productRepo := repo.NewProductRepo(testDB)
+	productRepo := repo.NewProductRepo(testDB, services.PricingCalculator{})
 	outboxRepo := repo.NewOutboxRepo()
+	categoryRepo := repo.NewCategoryRepo(testDB)
 	readModel := repo.NewReadModel(testDB)
 	pricing := services.PricingCalculator{}
 
-	createUsecase := createproduct.New(productRepo, outboxRepo, committer_, testClock)
-	activateUsecase := activateproduct.New(productRepo, outboxRepo, committer_, testClock)
+	createUsecase := createproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	activateUsecase := activateproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
 	deactivateUsecase := deactivateproduct.New(productRepo, outboxRepo, committer_, testClock)
 	getQuery := getproduct.New(readModel, pricing)
 
+	ensureCategories(t, "test")
+
 	// Setup: Create product (starts as inactive)
 	productID, err := createUsecase.Execute(testCtx, createproduct.Request{
 		Name:                 "Test Product",
@@ -321,12 +382,15 @@ func TestBusinessRuleValidation(t *testing.T) {
 	setupTestDB(t)
 	defer teardownTestDB(t)
 
-	productRepo := repo.NewProductRepo(testDB)
+	productRepo := repo.NewProductRepo(testDB, services.PricingCalculator{})
 	outboxRepo := repo.NewOutboxRepo()
+	categoryRepo := repo.NewCategoryRepo(testDB)
 
-	createUsecase := createproduct.New(productRepo, outboxRepo, committer_, testClock)
+	createUsecase := createproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
 	applyDiscountUsecase := applydiscount.New(productRepo, outboxRepo, committer_, testClock)
 
+	ensureCategories(t, "test")
+
 	// Setup: Create inactive product
 	productID, err := createUsecase.Execute(testCtx, createproduct.Request{
 		Name:                 "Inactive Product",
@@ -339,7 +403,7 @@ func TestBusinessRuleValidation(t *testing.T) {
 
 	// Test: Cannot apply discount to inactive product
 	now := time.Now()
-	err = applyDiscountUsecase.Execute(testCtx, applydiscount.Request{
+	_, err = applyDiscountUsecase.Execute(testCtx, applydiscount.Request{
 		ProductID:            productID,
 		PercentageNumerator:   10,
 		PercentageDenominator: 100,
@@ -350,7 +414,7 @@ func TestBusinessRuleValidation(t *testing.T) {
 	assert.ErrorIs(t, err, domain.ErrProductNotActive)
 
 	// Test: Invalid discount period (end before start)
-	err = applyDiscountUsecase.Execute(testCtx, applydiscount.Request{
+	_, err = applyDiscountUsecase.Execute(testCtx, applydiscount.Request{
 		ProductID:            productID,
 		PercentageNumerator:   10,
 		PercentageDenominator: 100,
@@ -365,12 +429,15 @@ func TestOutboxEventCreation(t *testing.T) {
 	setupTestDB(t)
 	defer teardownTestDB(t)
 
-	productRepo := repo.NewProductRepo(testDB)
+	productRepo := repo.NewProductRepo(testDB, services.PricingCalculator{})
 	outboxRepo := repo.NewOutboxRepo()
+	categoryRepo := repo.NewCategoryRepo(testDB)
+
+	createUsecase := createproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	updateUsecase := updateproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	activateUsecase := activateproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
 
-	createUsecase := createproduct.New(productRepo, outboxRepo, committer_, testClock)
-	updateUsecase := updateproduct.New(productRepo, outboxRepo, committer_, testClock)
-	activateUsecase := activateproduct.New(productRepo, outboxRepo, committer_, testClock)
+	ensureCategories(t, "test")
 
 	// Test: Create product generates event
 	productID, err := createUsecase.Execute(testCtx, createproduct.Request{
@@ -427,17 +494,20 @@ func TestRemoveDiscount(t *testing.T) {
 	setupTestDB(t)
 	defer teardownTestDB(t)
 
-	productRepo := repo.NewProductRepo(testDB)
+	productRepo := repo.NewProductRepo(testDB, services.PricingCalculator{})
 	outboxRepo := repo.NewOutboxRepo()
+	categoryRepo := repo.NewCategoryRepo(testDB)
 	readModel := repo.NewReadModel(testDB)
 	pricing := services.PricingCalculator{}
 
-	createUsecase := createproduct.New(productRepo, outboxRepo, committer_, testClock)
-	activateUsecase := activateproduct.New(productRepo, outboxRepo, committer_, testClock)
+	createUsecase := createproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	activateUsecase := activateproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
 	applyDiscountUsecase := applydiscount.New(productRepo, outboxRepo, committer_, testClock)
 	removeDiscountUsecase := removediscount.New(productRepo, outboxRepo, committer_, testClock)
 	getQuery := getproduct.New(readModel, pricing)
 
+	ensureCategories(t, "test")
+
 	// Setup: Create, activate, and apply discount
 	productID, err := createUsecase.Execute(testCtx, createproduct.Request{
 		Name:                 "Test Product",
@@ -454,7 +524,7 @@ func TestRemoveDiscount(t *testing.T) {
 	require.NoError(t, err)
 
 	now := time.Now()
-	err = applyDiscountUsecase.Execute(testCtx, applydiscount.Request{
+	_, err = applyDiscountUsecase.Execute(testCtx, applydiscount.Request{
 		ProductID:            productID,
 		PercentageNumerator:   20,
 		PercentageDenominator: 100,
@@ -489,3 +559,1615 @@ func TestRemoveDiscount(t *testing.T) {
 	}
 	assert.True(t, hasRemoved, "discount.removed event should exist")
 }
+
+// fixedClock is a clock.Clock that always returns the same time.
+type fixedClock struct {
+	now time.Time
+}
+
+func (c fixedClock) Now() time.Time {
+	return c.now
+}
+
+// startSweep moves the discount sweep cursor to the given time, so that
+// the next sweep handles the transitions after it. Transitions of earlier
+// tests in the shared database are skipped.
+func startSweep(t *testing.T, at time.Time) {
+	t.Helper()
+	cursor := &contracts.SweepCursor{
+		Name:      sweepdiscounts.CursorName,
+		Position:  contracts.PriceTransition{At: at},
+		UpdatedAt: at,
+	}
+	_, err := testDB.Apply(testCtx, []*spanner.Mutation{repo.NewSweepCursorRepo(testDB).SaveMut(cursor)})
+	require.NoError(t, err)
+}
+
+func TestDiscountSweep(t *testing.T) {
+	setupTestDB(t)
+	defer teardownTestDB(t)
+
+	productRepo := repo.NewProductRepo(testDB, services.PricingCalculator{})
+	outboxRepo := repo.NewOutboxRepo()
+	cursorRepo := repo.NewSweepCursorRepo(testDB)
+	categoryRepo := repo.NewCategoryRepo(testDB)
+	readModel := repo.NewReadModel(testDB)
+	pricing := services.PricingCalculator{}
+
+	createUsecase := createproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	activateUsecase := activateproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	applyDiscountUsecase := applydiscount.New(productRepo, outboxRepo, committer_, testClock)
+	getQuery := getproduct.New(readModel, pricing)
+
+	ensureCategories(t, "test")
+
+	// Setup: Create, activate, and apply a discount ending in an hour
+	productID, err := createUsecase.Execute(testCtx, createproduct.Request{
+		Name:                 "Test Product",
+		Description:          "Test",
+		Category:             "test",
+		BasePriceNumerator:   10000,
+		BasePriceDenominator: 100,
+	})
+	require.NoError(t, err)
+
+	err = activateUsecase.Execute(testCtx, activateproduct.Request{
+		ProductID: productID,
+	})
+	require.NoError(t, err)
+
+	now := time.Now()
+	_, err = applyDiscountUsecase.Execute(testCtx, applydiscount.Request{
+		ProductID:             productID,
+		PercentageNumerator:   20,
+		PercentageDenominator: 100,
+		StartDate:             now.Add(-1 * time.Hour),
+		EndDate:               now.Add(1 * time.Hour),
+	})
+	require.NoError(t, err)
+
+	// Test: Sweep now, then again after the discount ended
+	later := now.Add(2 * time.Hour)
+	startSweep(t, now)
+	sweepUsecase := sweepdiscounts.New(productRepo, cursorRepo, outboxRepo, committer_, fixedClock{now: later}, pricing)
+	result, err := sweepUsecase.Execute(testCtx, sweepdiscounts.Request{})
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, result.Transitions, 1)
+
+	// Verify: A second sweep finds nothing left to do
+	again, err := sweepUsecase.Execute(testCtx, sweepdiscounts.Request{})
+	require.NoError(t, err)
+	assert.Equal(t, 0, again.Transitions)
+	assert.Equal(t, result.Position, again.Position)
+
+	// Verify: Expired discount was cleared from the product
+	stored, err := productRepo.FindByID(testCtx, productID)
+	require.NoError(t, err)
+	assert.Empty(t, stored.Discounts())
+
+	product, err := getQuery.Execute(testCtx, getproduct.Request{
+		ProductID: productID,
+		Now:       later,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "100", product.EffectivePriceExact)
+
+	// Verify: Expiry and price change events were created
+	events := getOutboxEvents(t, productID)
+	var priceChanged *OutboxEvent
+	hasExpired := false
+	for i := range events {
+		switch events[i].EventType {
+		case "discount.expired":
+			hasExpired = true
+		case "product.effective_price_changed":
+			priceChanged = &events[i]
+		}
+	}
+	assert.True(t, hasExpired, "discount.expired event should exist")
+	require.NotNil(t, priceChanged, "product.effective_price_changed event should exist")
+
+	var payload domain.EffectivePriceChangedEvent
+	require.NoError(t, json.Unmarshal(priceChanged.Payload, &payload))
+	assert.Equal(t, "80", payload.OldPrice)
+	assert.Equal(t, "100", payload.NewPrice)
+}
+
+func TestScheduledPriceTakesEffect(t *testing.T) {
+	setupTestDB(t)
+	defer teardownTestDB(t)
+
+	productRepo := repo.NewProductRepo(testDB, services.PricingCalculator{})
+	outboxRepo := repo.NewOutboxRepo()
+	cursorRepo := repo.NewSweepCursorRepo(testDB)
+	categoryRepo := repo.NewCategoryRepo(testDB)
+	readModel := repo.NewReadModel(testDB)
+	pricing := services.PricingCalculator{}
+
+	createUsecase := createproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	schedulePriceUsecase := scheduleprice.New(productRepo, outboxRepo, committer_, testClock)
+	getQuery := getproduct.New(readModel, pricing)
+
+	ensureCategories(t, "test")
+
+	// Setup: Create a product and schedule a new price for tomorrow
+	productID, err := createUsecase.Execute(testCtx, createproduct.Request{
+		Name:      "Test Product",
+		Category:  "test",
+		BasePrice: "100.00",
+	})
+	require.NoError(t, err)
+
+	now := time.Now()
+	tomorrow := now.Add(24 * time.Hour)
+	_, err = schedulePriceUsecase.Execute(testCtx, scheduleprice.Request{
+		ProductID:     productID,
+		Price:         "120.00",
+		EffectiveFrom: tomorrow,
+	})
+	require.NoError(t, err)
+
+	// Verify: The scheduled price is used from its effective time on
+	product, err := getQuery.Execute(testCtx, getproduct.Request{ProductID: productID, Now: now})
+	require.NoError(t, err)
+	assert.Equal(t, "100", product.EffectivePriceExact)
+
+	product, err = getQuery.Execute(testCtx, getproduct.Request{ProductID: productID, Now: tomorrow})
+	require.NoError(t, err)
+	assert.Equal(t, "120", product.EffectivePriceExact)
+
+	// Test: Sweep before and after the effective time
+	later := tomorrow.Add(time.Minute)
+	startSweep(t, tomorrow.Add(-time.Minute))
+	sweepUsecase := sweepdiscounts.New(productRepo, cursorRepo, outboxRepo, committer_, fixedClock{now: later}, pricing)
+	_, err = sweepUsecase.Execute(testCtx, sweepdiscounts.Request{})
+	require.NoError(t, err)
+
+	// Verify: The scheduled price became the base price
+	stored, err := productRepo.FindByID(testCtx, productID)
+	require.NoError(t, err)
+	assert.Equal(t, "120", stored.BasePrice().String())
+	assert.Empty(t, stored.ScheduledPrices())
+
+	hasEffective := false
+	for _, e := range getOutboxEvents(t, productID) {
+		if e.EventType == "scheduled_price.effective" {
+			hasEffective = true
+			break
+		}
+	}
+	assert.True(t, hasEffective, "scheduled_price.effective event should exist")
+}
+
+func TestPricePreviewAsOf(t *testing.T) {
+	setupTestDB(t)
+	defer teardownTestDB(t)
+
+	productRepo := repo.NewProductRepo(testDB, services.PricingCalculator{})
+	outboxRepo := repo.NewOutboxRepo()
+	categoryRepo := repo.NewCategoryRepo(testDB)
+	readModel := repo.NewReadModel(testDB)
+	pricing := services.PricingCalculator{}
+
+	createUsecase := createproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	applyDiscountUsecase := applydiscount.New(productRepo, outboxRepo, committer_, testClock)
+	getQuery := getproduct.New(readModel, pricing)
+
+	ensureCategories(t, "test")
+
+	// Setup: Create a product with a 25% discount starting tomorrow
+	productID, err := createUsecase.Execute(testCtx, createproduct.Request{
+		Name:      "Test Product",
+		Category:  "test",
+		BasePrice: "80.00",
+	})
+	require.NoError(t, err)
+
+	now := time.Now()
+	tomorrow := now.Add(24 * time.Hour)
+	discountID, err := applyDiscountUsecase.Execute(testCtx, applydiscount.Request{
+		ProductID:             productID,
+		PercentageNumerator:   25,
+		PercentageDenominator: 100,
+		StartDate:             tomorrow,
+		EndDate:               tomorrow.Add(24 * time.Hour),
+	})
+	require.NoError(t, err)
+
+	// Verify: No discount applies now
+	product, err := getQuery.Execute(testCtx, getproduct.Request{ProductID: productID, Now: now})
+	require.NoError(t, err)
+	assert.Equal(t, "80", product.EffectivePriceExact)
+	assert.Equal(t, "0", product.DiscountAmountExact)
+	assert.Empty(t, product.AppliedDiscounts)
+
+	// Verify: The preview inside the discount window shows the breakdown
+	preview := tomorrow.Add(time.Hour)
+	product, err = getQuery.Execute(testCtx, getproduct.Request{ProductID: productID, Now: preview})
+	require.NoError(t, err)
+	assert.Equal(t, preview, product.AsOf)
+	assert.Equal(t, "80", product.BasePriceExact)
+	assert.Equal(t, "20", product.DiscountAmountExact)
+	assert.Equal(t, "60", product.EffectivePriceExact)
+	require.Len(t, product.AppliedDiscounts, 1)
+	assert.Equal(t, discountID, product.AppliedDiscounts[0].ID)
+	assert.Equal(t, "0.25", product.AppliedDiscounts[0].Percentage)
+}
+
+func TestGetProductFields(t *testing.T) {
+	setupTestDB(t)
+	defer teardownTestDB(t)
+
+	productRepo := repo.NewProductRepo(testDB, services.PricingCalculator{})
+	outboxRepo := repo.NewOutboxRepo()
+	categoryRepo := repo.NewCategoryRepo(testDB)
+	readModel := repo.NewReadModel(testDB)
+	pricing := services.PricingCalculator{}
+
+	createUsecase := createproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	getQuery := getproduct.New(readModel, pricing)
+
+	ensureCategories(t, "test")
+
+	productID, err := createUsecase.Execute(testCtx, createproduct.Request{
+		Name:        "Test Product",
+		Description: "A test product",
+		Category:    "test",
+		BasePrice:   "19.99",
+	})
+	require.NoError(t, err)
+
+	// Test: Request only the name
+	product, err := getQuery.Execute(testCtx, getproduct.Request{
+		ProductID: productID,
+		Fields:    []string{"name"},
+	})
+	require.NoError(t, err)
+
+	// Verify: Other fields are empty and no price was calculated
+	assert.Equal(t, productID, product.ID)
+	assert.Equal(t, "Test Product", product.Name)
+	assert.Empty(t, product.Description)
+	assert.Empty(t, product.EffectivePriceExact)
+	assert.True(t, product.CreatedAt.IsZero())
+
+	// Test: Request the price
+	product, err = getQuery.Execute(testCtx, getproduct.Request{
+		ProductID: productID,
+		Fields:    []string{"effective_price"},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, product.Name)
+	assert.Equal(t, "19.99", product.EffectivePriceExact)
+
+	// Test: Unknown fields are rejected
+	_, err = getQuery.Execute(testCtx, getproduct.Request{
+		ProductID: productID,
+		Fields:    []string{"sku"},
+	})
+	assert.Error(t, err)
+}
+
+func TestBatchGetProducts(t *testing.T) {
+	setupTestDB(t)
+	defer teardownTestDB(t)
+
+	productRepo := repo.NewProductRepo(testDB, services.PricingCalculator{})
+	outboxRepo := repo.NewOutboxRepo()
+	categoryRepo := repo.NewCategoryRepo(testDB)
+	readModel := repo.NewReadModel(testDB)
+	pricing := services.PricingCalculator{}
+
+	createUsecase := createproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	batchQuery := batchgetproducts.New(readModel, pricing)
+
+	ensureCategories(t, "test")
+
+	firstID, err := createUsecase.Execute(testCtx, createproduct.Request{
+		Name:      "First",
+		Category:  "test",
+		BasePrice: "10.00",
+	})
+	require.NoError(t, err)
+	secondID, err := createUsecase.Execute(testCtx, createproduct.Request{
+		Name:      "Second",
+		Category:  "test",
+		BasePrice: "20.00",
+	})
+	require.NoError(t, err)
+
+	// Test: Look up both products and a missing one
+	now := time.Now()
+	result, err := batchQuery.Execute(testCtx, batchgetproducts.Request{
+		ProductIDs: []string{secondID, "missing", firstID},
+		Now:        now,
+	})
+	require.NoError(t, err)
+
+	// Verify: Results follow request order with a not-found marker
+	assert.Equal(t, now, result.AsOf)
+	require.Len(t, result.Items, 3)
+
+	assert.Equal(t, secondID, result.Items[0].ProductID)
+	require.True(t, result.Items[0].Found)
+	assert.Equal(t, "Second", result.Items[0].Product.Name)
+	assert.Equal(t, "20", result.Items[0].Product.EffectivePriceExact)
+
+	assert.Equal(t, "missing", result.Items[1].ProductID)
+	assert.False(t, result.Items[1].Found)
+	assert.Nil(t, result.Items[1].Product)
+
+	assert.Equal(t, firstID, result.Items[2].ProductID)
+	require.True(t, result.Items[2].Found)
+	assert.Equal(t, "10", result.Items[2].Product.EffectivePriceExact)
+}
+
+func TestAdminListProducts(t *testing.T) {
+	setupTestDB(t)
+	defer teardownTestDB(t)
+
+	productRepo := repo.NewProductRepo(testDB, services.PricingCalculator{})
+	outboxRepo := repo.NewOutboxRepo()
+	categoryRepo := repo.NewCategoryRepo(testDB)
+	readModel := repo.NewReadModel(testDB)
+	pricing := services.PricingCalculator{}
+
+	createUsecase := createproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	activateUsecase := activateproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	listQuery := listproducts.New(readModel, pricing, testTokens)
+
+	ensureCategories(t, "admin-test")
+
+	// Setup: One active and one inactive product
+	activeID, err := createUsecase.Execute(testCtx, createproduct.Request{
+		Name:      "Active",
+		Category:  "admin-test",
+		BasePrice: "10.00",
+	})
+	require.NoError(t, err)
+	require.NoError(t, activateUsecase.Execute(testCtx, activateproduct.Request{ProductID: activeID}))
+
+	inactiveID, err := createUsecase.Execute(testCtx, createproduct.Request{
+		Name:      "Inactive",
+		Category:  "admin-test",
+		BasePrice: "10.00",
+	})
+	require.NoError(t, err)
+
+	category := "admin-test"
+	ids := func(result *listproducts.ListResultDTO) []string {
+		out := make([]string, 0, len(result.Items))
+		for _, item := range result.Items {
+			out = append(out, item.ID)
+		}
+		return out
+	}
+
+	// Verify: The storefront listing only shows the active product
+	result, err := listQuery.Execute(testCtx, listproducts.Request{Category: &category})
+	require.NoError(t, err)
+	assert.Equal(t, []string{activeID}, ids(result))
+
+	// Verify: The admin listing shows both, or filters by status
+	result, err = listQuery.Execute(testCtx, listproducts.Request{Category: &category, Admin: true})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{activeID, inactiveID}, ids(result))
+
+	result, err = listQuery.Execute(testCtx, listproducts.Request{
+		Category: &category,
+		Admin:    true,
+		Statuses: []domain.ProductStatus{domain.ProductStatusInactive},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{inactiveID}, ids(result))
+
+	// Verify: Storefront requests ignore admin filters
+	result, err = listQuery.Execute(testCtx, listproducts.Request{
+		Category: &category,
+		Statuses: []domain.ProductStatus{domain.ProductStatusInactive},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{activeID}, ids(result))
+}
+
+func TestListProductsFilter(t *testing.T) {
+	setupTestDB(t)
+	defer teardownTestDB(t)
+
+	productRepo := repo.NewProductRepo(testDB, services.PricingCalculator{})
+	outboxRepo := repo.NewOutboxRepo()
+	categoryRepo := repo.NewCategoryRepo(testDB)
+	readModel := repo.NewReadModel(testDB)
+	pricing := services.PricingCalculator{}
+
+	createUsecase := createproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	activateUsecase := activateproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	applyDiscountUsecase := applydiscount.New(productRepo, outboxRepo, committer_, testClock)
+	listQuery := listproducts.New(readModel, pricing, testTokens)
+
+	ensureCategories(t, "shoes", "hats")
+
+	// Setup: A cheap discounted shoe, an expensive shoe and a cheap hat
+	create := func(name, category, price string) string {
+		id, err := createUsecase.Execute(testCtx, createproduct.Request{
+			Name:      name,
+			Category:  category,
+			BasePrice: price,
+		})
+		require.NoError(t, err)
+		require.NoError(t, activateUsecase.Execute(testCtx, activateproduct.Request{ProductID: id}))
+		return id
+	}
+	cheapShoeID := create("Cheap Shoe", "shoes", "40.00")
+	create("Expensive Shoe", "shoes", "120.00")
+	create("Cheap Hat", "hats", "20.00")
+
+	now := time.Now()
+	_, err := applyDiscountUsecase.Execute(testCtx, applydiscount.Request{
+		ProductID:             cheapShoeID,
+		PercentageNumerator:   10,
+		PercentageDenominator: 100, // 10%
+		StartDate:             now.Add(-1 * time.Hour),
+		EndDate:               now.Add(24 * time.Hour),
+	})
+	require.NoError(t, err)
+
+	// Verify: Comparisons on strings, numbers and discounts combine with AND
+	result, err := listQuery.Execute(testCtx, listproducts.Request{
+		Filter: `category = "shoes" AND price < 50 AND has_discount = true`,
+		Now:    now,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Items, 1)
+	assert.Equal(t, cheapShoeID, result.Items[0].ID)
+
+	// Verify: price compares the discounted price (36), not the base price
+	result, err = listQuery.Execute(testCtx, listproducts.Request{
+		Filter: `category = "shoes" AND price <= 36`,
+		Now:    now,
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Items, 1)
+	assert.Equal(t, cheapShoeID, result.Items[0].ID)
+
+	// Verify: Unknown fields are rejected with a position
+	_, err = listQuery.Execute(testCtx, listproducts.Request{Filter: `colour = "red"`})
+	var filterErr *filter.Error
+	require.ErrorAs(t, err, &filterErr)
+	assert.Equal(t, 0, filterErr.Pos)
+}
+
+func TestProductAttributes(t *testing.T) {
+	setupTestDB(t)
+	defer teardownTestDB(t)
+
+	productRepo := repo.NewProductRepo(testDB, services.PricingCalculator{})
+	outboxRepo := repo.NewOutboxRepo()
+	categoryRepo := repo.NewCategoryRepo(testDB)
+	readModel := repo.NewReadModel(testDB)
+	pricing := services.PricingCalculator{}
+
+	createUsecase := createproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	updateUsecase := updateproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	activateUsecase := activateproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	getQuery := getproduct.New(readModel, pricing)
+	listQuery := listproducts.New(readModel, pricing, testTokens)
+
+	ensureCategories(t, "shoes")
+	tag := fmt.Sprintf("%d", time.Now().UnixNano())
+
+	attr := func(typ domain.AttributeType, value, unit string) domain.AttributeValue {
+		v, err := domain.ParseAttribute(typ, value, unit)
+		require.NoError(t, err)
+		return v
+	}
+
+	// Setup: A red and a blue shoe with typed attributes
+	create := func(color, size string) string {
+		id, err := createUsecase.Execute(testCtx, createproduct.Request{
+			Name:      "Attribute Shoe " + tag,
+			Category:  "shoes",
+			BasePrice: "50.00",
+			Attributes: map[string]domain.AttributeValue{
+				"color":      attr(domain.AttributeString, color, ""),
+				"size":       attr(domain.AttributeNumber, size, ""),
+				"waterproof": attr(domain.AttributeBool, "true", ""),
+				"weight":     attr(domain.AttributeMeasurement, "0.8", "kg"),
+			},
+		})
+		require.NoError(t, err)
+		require.NoError(t, activateUsecase.Execute(testCtx, activateproduct.Request{ProductID: id}))
+		return id
+	}
+	redID := create("red", "42")
+	blueID := create("blue", "38")
+
+	// Verify: Attributes are read back with their types
+	product, err := getQuery.Execute(testCtx, getproduct.Request{ProductID: redID})
+	require.NoError(t, err)
+	require.Len(t, product.Attributes, 4)
+	assert.Equal(t, "string", product.Attributes["color"].Type)
+	assert.Equal(t, "red", product.Attributes["color"].Text)
+	assert.Equal(t, "42", product.Attributes["size"].Number)
+	assert.True(t, product.Attributes["waterproof"].Bool)
+	assert.Equal(t, "0.8", product.Attributes["weight"].Number)
+	assert.Equal(t, "kg", product.Attributes["weight"].Unit)
+
+	// Verify: Attributes filter by key with typed comparisons
+	result, err := listQuery.Execute(testCtx, listproducts.Request{
+		Filter: fmt.Sprintf(`name = "Attribute Shoe %s" AND attributes.color = "red"`, tag),
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Items, 1)
+	assert.Equal(t, redID, result.Items[0].ID)
+
+	result, err = listQuery.Execute(testCtx, listproducts.Request{
+		Filter: fmt.Sprintf(`name = "Attribute Shoe %s" AND attributes.size < 40`, tag),
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Items, 1)
+	assert.Equal(t, blueID, result.Items[0].ID)
+
+	// Test: Update one attribute and remove another
+	err = updateUsecase.Execute(testCtx, updateproduct.Request{
+		ProductID:        redID,
+		Attributes:       map[string]domain.AttributeValue{"color": attr(domain.AttributeString, "green", "")},
+		RemoveAttributes: []string{"waterproof"},
+	})
+	require.NoError(t, err)
+
+	// Verify: Only the changed attributes were touched
+	product, err = getQuery.Execute(testCtx, getproduct.Request{ProductID: redID})
+	require.NoError(t, err)
+	require.Len(t, product.Attributes, 3)
+	assert.Equal(t, "green", product.Attributes["color"].Text)
+	assert.NotContains(t, product.Attributes, "waterproof")
+
+	result, err = listQuery.Execute(testCtx, listproducts.Request{
+		Filter: fmt.Sprintf(`name = "Attribute Shoe %s" AND attributes.waterproof = true`, tag),
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Items, 1)
+	assert.Equal(t, blueID, result.Items[0].ID)
+
+	// Verify: Ordering operators are rejected on string values
+	_, err = listQuery.Execute(testCtx, listproducts.Request{Filter: `attributes.color > "red"`})
+	var filterErr *filter.Error
+	require.ErrorAs(t, err, &filterErr)
+}
+
+func TestProductVariants(t *testing.T) {
+	setupTestDB(t)
+	defer teardownTestDB(t)
+
+	productRepo := repo.NewProductRepo(testDB, services.PricingCalculator{})
+	outboxRepo := repo.NewOutboxRepo()
+	categoryRepo := repo.NewCategoryRepo(testDB)
+	readModel := repo.NewReadModel(testDB)
+	pricing := services.PricingCalculator{}
+
+	createUsecase := createproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	activateUsecase := activateproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	applyDiscountUsecase := applydiscount.New(productRepo, outboxRepo, committer_, testClock)
+	addVariantUsecase := addvariant.New(productRepo, outboxRepo, committer_, testClock)
+	updateVariantUsecase := updatevariant.New(productRepo, outboxRepo, committer_, testClock)
+	removeVariantUsecase := removevariant.New(productRepo, outboxRepo, committer_, testClock)
+	getQuery := getproduct.New(readModel, pricing)
+
+	ensureCategories(t, "shirts")
+	tag := fmt.Sprintf("%d", time.Now().UnixNano())
+
+	// Setup: An active T-shirt at 20.00 with a 10% discount
+	create := func() string {
+		id, err := createUsecase.Execute(testCtx, createproduct.Request{
+			Name:      "Variant Tee " + tag,
+			Category:  "shirts",
+			BasePrice: "20.00",
+		})
+		require.NoError(t, err)
+		require.NoError(t, activateUsecase.Execute(testCtx, activateproduct.Request{ProductID: id}))
+		return id
+	}
+	productID := create()
+	now := testClock.Now()
+	_, err := applyDiscountUsecase.Execute(testCtx, applydiscount.Request{
+		ProductID:             productID,
+		PercentageNumerator:   10,
+		PercentageDenominator: 100,
+		StartDate:             now.Add(-1 * time.Hour),
+		EndDate:               now.Add(24 * time.Hour),
+	})
+	require.NoError(t, err)
+
+	// Test: Add a variant at the product price and one with its own price
+	mediumID, err := addVariantUsecase.Execute(testCtx, addvariant.Request{
+		ProductID: productID,
+		SKU:       "TEE-M-" + tag,
+		Options:   map[string]string{"size": "M", "color": "red"},
+	})
+	require.NoError(t, err)
+	_, err = addVariantUsecase.Execute(testCtx, addvariant.Request{
+		ProductID:     productID,
+		VariantID:     "xxl",
+		SKU:           "TEE-XXL-" + tag,
+		Options:       map[string]string{"size": "XXL", "color": "red"},
+		PriceOverride: "25.00",
+	})
+	require.NoError(t, err)
+
+	// Verify: Variants are priced with the product discounts
+	product, err := getQuery.Execute(testCtx, getproduct.Request{ProductID: productID, Fields: []string{"variants"}})
+	require.NoError(t, err)
+	require.Len(t, product.Variants, 2)
+	byID := map[string]int{}
+	for i, v := range product.Variants {
+		byID[v.ID] = i
+	}
+	medium := product.Variants[byID[mediumID]]
+	assert.Equal(t, "TEE-M-"+tag, medium.SKU)
+	assert.Equal(t, "", medium.PriceOverride)
+	assert.Equal(t, "18.00", medium.EffectivePriceDecimal)
+	xxl := product.Variants[byID["xxl"]]
+	assert.Equal(t, "25", xxl.PriceOverride)
+	assert.Equal(t, "22.50", xxl.EffectivePriceDecimal)
+	assert.Equal(t, "active", xxl.Status)
+
+	// Verify: SKUs are unique across products
+	otherID := create()
+	_, err = addVariantUsecase.Execute(testCtx, addvariant.Request{
+		ProductID: otherID,
+		SKU:       "TEE-M-" + tag,
+		Options:   map[string]string{"size": "M"},
+	})
+	assert.ErrorIs(t, err, domain.ErrSKUTaken)
+
+	// Verify: Options are unique within the product
+	_, err = addVariantUsecase.Execute(testCtx, addvariant.Request{
+		ProductID: productID,
+		SKU:       "TEE-M2-" + tag,
+		Options:   map[string]string{"color": "red", "size": "M"},
+	})
+	assert.ErrorIs(t, err, domain.ErrInvalidVariant)
+
+	// Test: Clear the override and deactivate the XXL variant
+	clearPrice := ""
+	inactive := domain.VariantStatusInactive
+	err = updateVariantUsecase.Execute(testCtx, updatevariant.Request{
+		ProductID:     productID,
+		VariantID:     "xxl",
+		PriceOverride: &clearPrice,
+		Status:        &inactive,
+	})
+	require.NoError(t, err)
+
+	product, err = getQuery.Execute(testCtx, getproduct.Request{ProductID: productID, Fields: []string{"variants"}})
+	require.NoError(t, err)
+	xxl = product.Variants[byID["xxl"]]
+	assert.Equal(t, "", xxl.PriceOverride)
+	assert.Equal(t, "18.00", xxl.EffectivePriceDecimal)
+	assert.Equal(t, "inactive", xxl.Status)
+
+	// Test: Remove the medium variant, freeing its SKU
+	err = removeVariantUsecase.Execute(testCtx, removevariant.Request{ProductID: productID, VariantID: mediumID})
+	require.NoError(t, err)
+	err = removeVariantUsecase.Execute(testCtx, removevariant.Request{ProductID: productID, VariantID: mediumID})
+	assert.ErrorIs(t, err, domain.ErrVariantNotFound)
+
+	_, err = addVariantUsecase.Execute(testCtx, addvariant.Request{
+		ProductID: otherID,
+		SKU:       "TEE-M-" + tag,
+		Options:   map[string]string{"size": "M"},
+	})
+	require.NoError(t, err)
+
+	product, err = getQuery.Execute(testCtx, getproduct.Request{ProductID: productID, Fields: []string{"variants"}})
+	require.NoError(t, err)
+	require.Len(t, product.Variants, 1)
+	assert.Equal(t, "xxl", product.Variants[0].ID)
+
+	// Verify: Variant changes were written to the outbox
+	var variantEvents []string
+	for _, e := range getOutboxEvents(t, productID) {
+		if e.EventType == "variant.added" || e.EventType == "variant.updated" || e.EventType == "variant.removed" {
+			variantEvents = append(variantEvents, e.EventType)
+		}
+	}
+	assert.Equal(t, []string{"variant.added", "variant.added", "variant.updated", "variant.removed"}, variantEvents)
+}
+
+func TestListProductsOrderBy(t *testing.T) {
+	setupTestDB(t)
+	defer teardownTestDB(t)
+
+	productRepo := repo.NewProductRepo(testDB, services.PricingCalculator{})
+	outboxRepo := repo.NewOutboxRepo()
+	categoryRepo := repo.NewCategoryRepo(testDB)
+	readModel := repo.NewReadModel(testDB)
+	pricing := services.PricingCalculator{}
+
+	createUsecase := createproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	activateUsecase := activateproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	listQuery := listproducts.New(readModel, pricing, testTokens)
+
+	ensureCategories(t, "order-test")
+
+	// Setup: Three products whose name and price orders differ
+	ids := map[string]string{}
+	for name, price := range map[string]string{"Bravo": "30.00", "Alpha": "20.00", "Charlie": "10.00"} {
+		id, err := createUsecase.Execute(testCtx, createproduct.Request{
+			Name:      name,
+			Category:  "order-test",
+			BasePrice: price,
+		})
+		require.NoError(t, err)
+		require.NoError(t, activateUsecase.Execute(testCtx, activateproduct.Request{ProductID: id}))
+		ids[name] = id
+	}
+
+	category := "order-test"
+	listAll := func(orderBy string) []string {
+		var out []string
+		token := ""
+		for {
+			result, err := listQuery.Execute(testCtx, listproducts.Request{
+				Category:  &category,
+				OrderBy:   orderBy,
+				PageSize:  1,
+				PageToken: token,
+			})
+			require.NoError(t, err)
+			for _, item := range result.Items {
+				out = append(out, item.ID)
+			}
+			if result.NextPageToken == "" {
+				return out
+			}
+			token = result.NextPageToken
+		}
+	}
+
+	// Verify: Pages follow the sort key in either direction
+	assert.Equal(t, []string{ids["Alpha"], ids["Bravo"], ids["Charlie"]}, listAll("name"))
+	assert.Equal(t, []string{ids["Charlie"], ids["Bravo"], ids["Alpha"]}, listAll("name desc"))
+	assert.Equal(t, []string{ids["Charlie"], ids["Alpha"], ids["Bravo"]}, listAll("price"))
+	assert.Equal(t, []string{ids["Bravo"], ids["Alpha"], ids["Charlie"]}, listAll("price desc, product_id desc"))
+
+	// Verify: Page tokens are bound to their order and filter
+	result, err := listQuery.Execute(testCtx, listproducts.Request{Category: &category, OrderBy: "name", PageSize: 1})
+	require.NoError(t, err)
+	require.NotEmpty(t, result.NextPageToken)
+	_, err = listQuery.Execute(testCtx, listproducts.Request{
+		Category:  &category,
+		OrderBy:   "price",
+		PageToken: result.NextPageToken,
+	})
+	assert.ErrorIs(t, err, contracts.ErrInvalidPageToken)
+
+	otherCategory := "other"
+	_, err = listQuery.Execute(testCtx, listproducts.Request{
+		Category:  &otherCategory,
+		OrderBy:   "name",
+		PageToken: result.NextPageToken,
+	})
+	assert.ErrorIs(t, err, contracts.ErrInvalidPageToken)
+
+	// Verify: Malformed tokens are rejected instead of restarting the listing
+	_, err = listQuery.Execute(testCtx, listproducts.Request{Category: &category, PageToken: "garbage"})
+	assert.ErrorIs(t, err, contracts.ErrInvalidPageToken)
+
+	// Verify: Unknown sort fields are rejected
+	_, err = listQuery.Execute(testCtx, listproducts.Request{OrderBy: "description"})
+	assert.ErrorIs(t, err, listproducts.ErrInvalidOrderBy)
+}
+
+func TestListProductsSnapshot(t *testing.T) {
+	setupTestDB(t)
+	defer teardownTestDB(t)
+
+	productRepo := repo.NewProductRepo(testDB, services.PricingCalculator{})
+	outboxRepo := repo.NewOutboxRepo()
+	categoryRepo := repo.NewCategoryRepo(testDB)
+	readModel := repo.NewReadModel(testDB)
+	pricing := services.PricingCalculator{}
+
+	createUsecase := createproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	activateUsecase := activateproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	deactivateUsecase := deactivateproduct.New(productRepo, outboxRepo, committer_, testClock)
+	listQuery := listproducts.New(readModel, pricing, testTokens)
+
+	ensureCategories(t, "snapshot-test")
+
+	// Setup: Two active products
+	category := "snapshot-test"
+	var ids []string
+	for _, name := range []string{"First", "Second"} {
+		id, err := createUsecase.Execute(testCtx, createproduct.Request{
+			Name:      name,
+			Category:  category,
+			BasePrice: "10.00",
+		})
+		require.NoError(t, err)
+		require.NoError(t, activateUsecase.Execute(testCtx, activateproduct.Request{ProductID: id}))
+		ids = append(ids, id)
+	}
+
+	first, err := listQuery.Execute(testCtx, listproducts.Request{Category: &category, OrderBy: "name", PageSize: 1})
+	require.NoError(t, err)
+	require.Len(t, first.Items, 1)
+	require.NotEmpty(t, first.NextPageToken)
+
+	// Execute: The second product is deactivated between pages
+	require.NoError(t, deactivateUsecase.Execute(testCtx, deactivateproduct.Request{ProductID: ids[1]}))
+
+	// Verify: The next page still sees the first page's snapshot
+	second, err := listQuery.Execute(testCtx, listproducts.Request{
+		Category:  &category,
+		OrderBy:   "name",
+		PageSize:  1,
+		PageToken: first.NextPageToken,
+	})
+	require.NoError(t, err)
+	require.Len(t, second.Items, 1)
+	assert.Equal(t, ids[1], second.Items[0].ID)
+
+	// Verify: Snapshots past the version GC window are rejected
+	_, err = readModel.ListProducts(
+		testCtx,
+		contracts.ProductFilter{Category: &category},
+		contracts.ProductOrder{},
+		1,
+		"",
+		time.Now().Add(-2*time.Hour),
+		contracts.AllProductFields,
+	)
+	assert.ErrorIs(t, err, contracts.ErrSnapshotExpired)
+}
+
+func TestListProductsPriceRange(t *testing.T) {
+	setupTestDB(t)
+	defer teardownTestDB(t)
+
+	productRepo := repo.NewProductRepo(testDB, services.PricingCalculator{})
+	outboxRepo := repo.NewOutboxRepo()
+	categoryRepo := repo.NewCategoryRepo(testDB)
+	readModel := repo.NewReadModel(testDB)
+	pricing := services.PricingCalculator{}
+
+	createUsecase := createproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	activateUsecase := activateproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	applyDiscountUsecase := applydiscount.New(productRepo, outboxRepo, committer_, testClock)
+	listQuery := listproducts.New(readModel, pricing, testTokens)
+
+	ensureCategories(t, "price-range-test")
+
+	// Setup: A 100.00 product at half price for the next hour, and two
+	// undiscounted products at 60.00 and 30.00
+	category := "price-range-test"
+	create := func(name, price string) string {
+		id, err := createUsecase.Execute(testCtx, createproduct.Request{
+			Name:      name,
+			Category:  category,
+			BasePrice: price,
+		})
+		require.NoError(t, err)
+		require.NoError(t, activateUsecase.Execute(testCtx, activateproduct.Request{ProductID: id}))
+		return id
+	}
+	discountedID := create("Discounted", "100.00")
+	midID := create("Mid", "60.00")
+	cheapID := create("Cheap", "30.00")
+
+	now := time.Now()
+	_, err := applyDiscountUsecase.Execute(testCtx, applydiscount.Request{
+		ProductID:             discountedID,
+		PercentageNumerator:   50,
+		PercentageDenominator: 100, // 50%
+		StartDate:             now.Add(-1 * time.Hour),
+		EndDate:               now.Add(1 * time.Hour),
+	})
+	require.NoError(t, err)
+
+	ids := func(result *listproducts.ListResultDTO) []string {
+		out := make([]string, 0, len(result.Items))
+		for _, item := range result.Items {
+			out = append(out, item.ID)
+		}
+		return out
+	}
+
+	// Verify: The range applies to the discounted price while the discount runs
+	result, err := listQuery.Execute(testCtx, listproducts.Request{
+		Category: &category,
+		MinPrice: "40",
+		MaxPrice: "70",
+		OrderBy:  "price",
+		Now:      now,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{discountedID, midID}, ids(result))
+
+	// Verify: After the discount ends the product is back at its base price
+	later := now.Add(2 * time.Hour)
+	result, err = listQuery.Execute(testCtx, listproducts.Request{
+		Category: &category,
+		MinPrice: "40",
+		MaxPrice: "70",
+		Now:      later,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{midID}, ids(result))
+
+	// Verify: Price order pages through the effective prices at as_of
+	var sorted []string
+	token := ""
+	for {
+		result, err = listQuery.Execute(testCtx, listproducts.Request{
+			Category:  &category,
+			OrderBy:   "price desc",
+			PageSize:  1,
+			PageToken: token,
+			Now:       later,
+		})
+		require.NoError(t, err)
+		sorted = append(sorted, ids(result)...)
+		if result.NextPageToken == "" {
+			break
+		}
+		token = result.NextPageToken
+	}
+	assert.Equal(t, []string{discountedID, midID, cheapID}, sorted)
+
+	// Verify: Inverted or malformed ranges are rejected
+	_, err = listQuery.Execute(testCtx, listproducts.Request{MinPrice: "70", MaxPrice: "40"})
+	assert.ErrorIs(t, err, listproducts.ErrInvalidPriceRange)
+	_, err = listQuery.Execute(testCtx, listproducts.Request{MinPrice: "-1"})
+	assert.ErrorIs(t, err, listproducts.ErrInvalidPriceRange)
+}
+
+func TestBackfillPricePeriods(t *testing.T) {
+	setupTestDB(t)
+	defer teardownTestDB(t)
+
+	productRepo := repo.NewProductRepo(testDB, services.PricingCalculator{})
+	outboxRepo := repo.NewOutboxRepo()
+	categoryRepo := repo.NewCategoryRepo(testDB)
+	readModel := repo.NewReadModel(testDB)
+	pricing := services.PricingCalculator{}
+
+	createUsecase := createproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	activateUsecase := activateproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	backfillUsecase := backfillpriceperiods.New(productRepo, committer_)
+	listQuery := listproducts.New(readModel, pricing, testTokens)
+
+	ensureCategories(t, "backfill-test")
+
+	// Setup: A product whose price timeline predates price periods
+	category := "backfill-test"
+	productID, err := createUsecase.Execute(testCtx, createproduct.Request{
+		Name:      "Legacy",
+		Category:  category,
+		BasePrice: "50.00",
+	})
+	require.NoError(t, err)
+	require.NoError(t, activateUsecase.Execute(testCtx, activateproduct.Request{ProductID: productID}))
+	_, err = testDB.Apply(testCtx, []*spanner.Mutation{
+		spanner.Delete("product_price_periods", spanner.Key{productID}.AsPrefix()),
+	})
+	require.NoError(t, err)
+
+	listed := func() int {
+		result, err := listQuery.Execute(testCtx, listproducts.Request{
+			Category: &category,
+			MinPrice: "40",
+			MaxPrice: "60",
+		})
+		require.NoError(t, err)
+		return len(result.Items)
+	}
+	assert.Equal(t, 0, listed())
+
+	// Test: Backfill the timelines
+	result, err := backfillUsecase.Execute(testCtx, backfillpriceperiods.Request{BatchSize: 2})
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, result.Products, 1)
+
+	// Verify: The product is priced by its timeline, and a rerun has nothing to do
+	assert.Equal(t, 1, listed())
+	result, err = backfillUsecase.Execute(testCtx, backfillpriceperiods.Request{})
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Products)
+}
+
+func TestSearchProducts(t *testing.T) {
+	setupTestDB(t)
+	defer teardownTestDB(t)
+
+	productRepo := repo.NewProductRepo(testDB, services.PricingCalculator{})
+	outboxRepo := repo.NewOutboxRepo()
+	categoryRepo := repo.NewCategoryRepo(testDB)
+	readModel := repo.NewReadModel(testDB)
+	pricing := services.PricingCalculator{}
+	searchIndex := repo.NewMemorySearch(testDB)
+
+	createUsecase := createproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	activateUsecase := activateproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	deactivateUsecase := deactivateproduct.New(productRepo, outboxRepo, committer_, testClock)
+	searchQuery := searchproducts.New(readModel, searchIndex, pricing, testTokens)
+
+	ensureCategories(t, "shoes")
+
+	// Setup: Products sharing a word unique to this run, matching it in the
+	// name or the description
+	tag := fmt.Sprintf("zq%d", time.Now().UnixNano())
+	create := func(name, description string) string {
+		id, err := createUsecase.Execute(testCtx, createproduct.Request{
+			Name:        name,
+			Description: description,
+			Category:    "shoes",
+			BasePrice:   "50.00",
+		})
+		require.NoError(t, err)
+		require.NoError(t, activateUsecase.Execute(testCtx, activateproduct.Request{ProductID: id}))
+		return id
+	}
+	nameID := create("Trail Running Shoes "+tag, "Grippy soles")
+	descriptionID := create("Leather Boots", "Pairs well with "+tag+" laces")
+	require.NoError(t, searchIndex.Load(testCtx))
+
+	// Verify: Name matches rank first and matched words are highlighted
+	result, err := searchQuery.Execute(testCtx, searchproducts.Request{Query: tag + " running"})
+	require.NoError(t, err)
+	require.Len(t, result.Results, 2)
+	assert.Equal(t, nameID, result.Results[0].Product.ID)
+	assert.Equal(t, descriptionID, result.Results[1].Product.ID)
+	assert.Equal(t, "Trail <em>Running</em> Shoes <em>"+tag+"</em>", result.Results[0].NameHighlight)
+	assert.Equal(t, "Pairs well with <em>"+tag+"</em> laces", result.Results[1].DescriptionHighlight)
+	assert.Equal(t, int64(5000), result.Results[0].Product.EffectivePriceMinorUnits)
+
+	// Verify: Pages continue at the next offset with the same query only
+	page, err := searchQuery.Execute(testCtx, searchproducts.Request{Query: tag, PageSize: 1})
+	require.NoError(t, err)
+	require.Len(t, page.Results, 1)
+	require.NotEmpty(t, page.NextPageToken)
+	next, err := searchQuery.Execute(testCtx, searchproducts.Request{Query: tag, PageSize: 1, PageToken: page.NextPageToken})
+	require.NoError(t, err)
+	require.Len(t, next.Results, 1)
+	assert.NotEqual(t, page.Results[0].Product.ID, next.Results[0].Product.ID)
+	assert.Empty(t, next.NextPageToken)
+	_, err = searchQuery.Execute(testCtx, searchproducts.Request{Query: "boots", PageToken: page.NextPageToken})
+	assert.ErrorIs(t, err, contracts.ErrInvalidPageToken)
+
+	// Verify: Sync follows the outbox, adding new and dropping deactivated products
+	require.NoError(t, deactivateUsecase.Execute(testCtx, deactivateproduct.Request{ProductID: nameID}))
+	newID := create(tag+" Sandals", "")
+	require.NoError(t, searchIndex.Sync(testCtx))
+
+	result, err = searchQuery.Execute(testCtx, searchproducts.Request{Query: tag})
+	require.NoError(t, err)
+	ids := []string{}
+	for _, r := range result.Results {
+		ids = append(ids, r.Product.ID)
+	}
+	assert.ElementsMatch(t, []string{descriptionID, newID}, ids)
+
+	// Verify: Queries without searchable words are rejected
+	_, err = searchQuery.Execute(testCtx, searchproducts.Request{Query: "the of"})
+	assert.ErrorIs(t, err, searchproducts.ErrInvalidQuery)
+}
+
+func TestSuggestProducts(t *testing.T) {
+	setupTestDB(t)
+	defer teardownTestDB(t)
+
+	productRepo := repo.NewProductRepo(testDB, services.PricingCalculator{})
+	outboxRepo := repo.NewOutboxRepo()
+	categoryRepo := repo.NewCategoryRepo(testDB)
+	suggestIndex := repo.NewMemorySuggest(testDB)
+
+	createUsecase := createproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	activateUsecase := activateproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	deactivateUsecase := deactivateproduct.New(productRepo, outboxRepo, committer_, testClock)
+	suggestQuery := suggestproducts.New(suggestIndex)
+
+	// Setup: Two active products in a category unique to this run
+	tag := fmt.Sprintf("zq%d", time.Now().UnixNano())
+	ensureCategories(t, tag)
+	create := func(name string) string {
+		id, err := createUsecase.Execute(testCtx, createproduct.Request{
+			Name:      name,
+			Category:  tag,
+			BasePrice: "10.00",
+		})
+		require.NoError(t, err)
+		require.NoError(t, activateUsecase.Execute(testCtx, activateproduct.Request{ProductID: id}))
+		return id
+	}
+	create(tag + " Trail Shoes")
+	roadID := create(tag + " Road Shoes")
+	require.NoError(t, suggestIndex.Load(testCtx))
+
+	// Verify: The category completes the prefix first, shared by both products
+	result, err := suggestQuery.Execute(testCtx, suggestproducts.Request{Prefix: tag})
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, len(result.Suggestions), 3)
+	assert.Equal(t, suggestproducts.SuggestionDTO{Text: tag, Kind: "category", ProductCount: 2}, result.Suggestions[0])
+	assert.Equal(t, tag+" Road Shoes", result.Suggestions[1].Text)
+	assert.Equal(t, tag+" Trail Shoes", result.Suggestions[2].Text)
+
+	// Verify: A typo is corrected
+	typo := tag[:2] + tag[3:4] + tag[2:3] + tag[4:]
+	result, err = suggestQuery.Execute(testCtx, suggestproducts.Request{Prefix: typo, Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, result.Suggestions, 1)
+	assert.Equal(t, tag, result.Suggestions[0].Text)
+	assert.True(t, result.Suggestions[0].Corrected)
+
+	// Verify: Sync follows product events and drops deactivated products
+	require.NoError(t, deactivateUsecase.Execute(testCtx, deactivateproduct.Request{ProductID: roadID}))
+	require.NoError(t, suggestIndex.Sync(testCtx))
+	result, err = suggestQuery.Execute(testCtx, suggestproducts.Request{Prefix: tag + " "})
+	require.NoError(t, err)
+	texts := []string{}
+	for _, s := range result.Suggestions {
+		texts = append(texts, s.Text)
+	}
+	assert.Contains(t, texts, tag+" Trail Shoes")
+	assert.NotContains(t, texts, tag+" Road Shoes")
+
+	// Verify: Blank prefixes are rejected
+	_, err = suggestQuery.Execute(testCtx, suggestproducts.Request{Prefix: "  "})
+	assert.ErrorIs(t, err, suggestproducts.ErrInvalidPrefix)
+}
+
+func TestListProductsFacets(t *testing.T) {
+	setupTestDB(t)
+	defer teardownTestDB(t)
+
+	productRepo := repo.NewProductRepo(testDB, services.PricingCalculator{})
+	outboxRepo := repo.NewOutboxRepo()
+	categoryRepo := repo.NewCategoryRepo(testDB)
+	readModel := repo.NewReadModel(testDB)
+	pricing := services.PricingCalculator{}
+
+	createUsecase := createproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	activateUsecase := activateproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	applyDiscountUsecase := applydiscount.New(productRepo, outboxRepo, committer_, testClock)
+	listQuery := listproducts.New(readModel, pricing, testTokens)
+
+	// Setup: Products in two categories unique to this run, one on sale
+	tag := fmt.Sprintf("zq%d", time.Now().UnixNano())
+	ensureCategories(t, tag+"-shoes", tag+"-hats")
+	create := func(category, price string) string {
+		id, err := createUsecase.Execute(testCtx, createproduct.Request{
+			Name:      "Facet " + tag,
+			Category:  category,
+			BasePrice: price,
+		})
+		require.NoError(t, err)
+		require.NoError(t, activateUsecase.Execute(testCtx, activateproduct.Request{ProductID: id}))
+		return id
+	}
+	saleID := create(tag+"-shoes", "40.00")
+	create(tag+"-shoes", "120.00")
+	create(tag+"-hats", "20.00")
+
+	now := time.Now()
+	_, err := applyDiscountUsecase.Execute(testCtx, applydiscount.Request{
+		ProductID:             saleID,
+		PercentageNumerator:   50,
+		PercentageDenominator: 100, // 40.00 -> 20.00
+		StartDate:             now.Add(-1 * time.Hour),
+		EndDate:               now.Add(24 * time.Hour),
+	})
+	require.NoError(t, err)
+
+	// Verify: Counts cover all matching products, not just the page
+	result, err := listQuery.Execute(testCtx, listproducts.Request{
+		Filter:   fmt.Sprintf(`name = "Facet %s"`, tag),
+		PageSize: 1,
+		Now:      now,
+		Facets: facets.Request{
+			Category:     true,
+			Discount:     true,
+			PriceBuckets: []string{"0", "25", "100"},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Items, 1)
+	require.NotNil(t, result.Facets)
+	assert.Equal(t, []facets.CountDTO{
+		{Value: tag + "-shoes", Count: 2},
+		{Value: tag + "-hats", Count: 1},
+	}, result.Facets.Categories)
+	assert.Equal(t, int64(1), result.Facets.Discounted)
+	assert.Equal(t, int64(2), result.Facets.NotDiscounted)
+	assert.Equal(t, []facets.PriceBucketDTO{
+		{From: "0", To: "25", Count: 2}, // the hat and the discounted shoe
+		{From: "25", To: "100", Count: 0},
+		{From: "100", Count: 1},
+	}, result.Facets.PriceBuckets)
+	assert.Empty(t, result.Facets.Statuses)
+
+	// Verify: Facets are only counted when requested
+	result, err = listQuery.Execute(testCtx, listproducts.Request{Filter: fmt.Sprintf(`name = "Facet %s"`, tag)})
+	require.NoError(t, err)
+	assert.Nil(t, result.Facets)
+
+	// Verify: Bad price buckets are rejected
+	_, err = listQuery.Execute(testCtx, listproducts.Request{Facets: facets.Request{PriceBuckets: []string{"50", "10"}}})
+	assert.ErrorIs(t, err, facets.ErrInvalidPriceBuckets)
+}
+
+func TestCategories(t *testing.T) {
+	setupTestDB(t)
+	defer teardownTestDB(t)
+
+	productRepo := repo.NewProductRepo(testDB, services.PricingCalculator{})
+	outboxRepo := repo.NewOutboxRepo()
+	categoryRepo := repo.NewCategoryRepo(testDB)
+	readModel := repo.NewReadModel(testDB)
+	pricing := services.PricingCalculator{}
+
+	createCategory := createcategory.New(categoryRepo, outboxRepo, committer_, testClock)
+	updateCategory := updatecategory.New(categoryRepo, outboxRepo, committer_, testClock)
+	deleteCategory := deletecategory.New(categoryRepo, outboxRepo, committer_, testClock)
+	getQuery := getcategory.New(readModel)
+	listCategoriesQuery := listcategories.New(readModel)
+	createUsecase := createproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	activateUsecase := activateproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	listQuery := listproducts.New(readModel, pricing, testTokens)
+
+	// Setup: A three-level tree unique to this run
+	tag := fmt.Sprintf("zq%d", time.Now().UnixNano())
+	rootID, err := createCategory.Execute(testCtx, createcategory.Request{Name: "Shoes " + tag})
+	require.NoError(t, err)
+	runningID, err := createCategory.Execute(testCtx, createcategory.Request{Name: "Running", Slug: tag + "-running", ParentID: rootID, SortOrder: 2})
+	require.NoError(t, err)
+	_, err = createCategory.Execute(testCtx, createcategory.Request{Name: "Boots", Slug: tag + "-boots", ParentID: rootID, SortOrder: 1})
+	require.NoError(t, err)
+	trailID, err := createCategory.Execute(testCtx, createcategory.Request{Name: "Trail", Slug: tag + "-trail", ParentID: runningID})
+	require.NoError(t, err)
+
+	events := getOutboxEvents(t, rootID)
+	require.Len(t, events, 1)
+	assert.Equal(t, "category.created", events[0].EventType)
+
+	// Verify: The slug is derived from the name and unique
+	root, err := getQuery.Execute(testCtx, getcategory.Request{CategoryID: rootID})
+	require.NoError(t, err)
+	assert.Equal(t, "shoes-"+tag, root.Slug)
+	_, err = createCategory.Execute(testCtx, createcategory.Request{Name: "Shoes " + tag})
+	assert.ErrorIs(t, err, domain.ErrCategorySlugTaken)
+
+	// Verify: The subtree is depth-first with siblings by sort order
+	tree, err := listCategoriesQuery.Execute(testCtx, listcategories.Request{ParentID: rootID})
+	require.NoError(t, err)
+	var slugs []string
+	var depths []int
+	for _, c := range tree.Categories {
+		slugs = append(slugs, c.Slug)
+		depths = append(depths, c.Depth)
+	}
+	assert.Equal(t, []string{tag + "-boots", tag + "-running", tag + "-trail"}, slugs)
+	assert.Equal(t, []int{1, 1, 2}, depths)
+
+	// Verify: A category cannot move under its own descendant
+	noParent := ""
+	err = updateCategory.Execute(testCtx, updatecategory.Request{CategoryID: rootID, ParentID: &trailID})
+	assert.ErrorIs(t, err, domain.ErrCategoryCycle)
+	err = updateCategory.Execute(testCtx, updatecategory.Request{CategoryID: trailID, ParentID: &noParent})
+	require.NoError(t, err)
+	trailCategory, err := getQuery.Execute(testCtx, getcategory.Request{Slug: tag + "-trail"})
+	require.NoError(t, err)
+	assert.Equal(t, 0, trailCategory.Depth)
+	require.NoError(t, updateCategory.Execute(testCtx, updatecategory.Request{CategoryID: trailID, ParentID: &runningID}))
+
+	// Verify: Products must reference an existing category
+	_, err = createUsecase.Execute(testCtx, createproduct.Request{
+		Name:      "Nowhere",
+		Category:  tag + "-missing",
+		BasePrice: "10.00",
+	})
+	assert.ErrorIs(t, err, domain.ErrUnknownCategory)
+
+	create := func(category string) string {
+		id, err := createUsecase.Execute(testCtx, createproduct.Request{
+			Name:      "Category " + tag,
+			Category:  category,
+			BasePrice: "10.00",
+		})
+		require.NoError(t, err)
+		require.NoError(t, activateUsecase.Execute(testCtx, activateproduct.Request{ProductID: id}))
+		return id
+	}
+	rootProductID := create(root.Slug)
+	create(tag + "-running")
+	create(tag + "-trail")
+
+	// Verify: Listing a category can include its descendants
+	result, err := listQuery.Execute(testCtx, listproducts.Request{Category: &root.Slug})
+	require.NoError(t, err)
+	require.Len(t, result.Items, 1)
+	assert.Equal(t, rootProductID, result.Items[0].ID)
+
+	result, err = listQuery.Execute(testCtx, listproducts.Request{Category: &root.Slug, IncludeDescendants: true})
+	require.NoError(t, err)
+	assert.Len(t, result.Items, 3)
+
+	running := tag + "-running"
+	result, err = listQuery.Execute(testCtx, listproducts.Request{Category: &running, IncludeDescendants: true})
+	require.NoError(t, err)
+	assert.Len(t, result.Items, 2)
+
+	// Verify: Categories in use cannot be deleted
+	err = deleteCategory.Execute(testCtx, deletecategory.Request{CategoryID: runningID})
+	assert.ErrorIs(t, err, domain.ErrCategoryInUse)
+	err = deleteCategory.Execute(testCtx, deletecategory.Request{CategoryID: trailID})
+	assert.ErrorIs(t, err, domain.ErrCategoryInUse)
+
+	bootsID := tree.Categories[0].ID
+	require.NoError(t, deleteCategory.Execute(testCtx, deletecategory.Request{CategoryID: bootsID}))
+	_, err = getQuery.Execute(testCtx, getcategory.Request{CategoryID: bootsID})
+	assert.ErrorIs(t, err, domain.ErrCategoryNotFound)
+}
+
+func TestCategoryAttributeSchema(t *testing.T) {
+	setupTestDB(t)
+	defer teardownTestDB(t)
+
+	productRepo := repo.NewProductRepo(testDB, services.PricingCalculator{})
+	outboxRepo := repo.NewOutboxRepo()
+	categoryRepo := repo.NewCategoryRepo(testDB)
+	readModel := repo.NewReadModel(testDB)
+
+	createCategory := createcategory.New(categoryRepo, outboxRepo, committer_, testClock)
+	updateCategory := updatecategory.New(categoryRepo, outboxRepo, committer_, testClock)
+	getCategoryQuery := getcategory.New(readModel)
+	createUsecase := createproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	updateUsecase := updateproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	activateUsecase := activateproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
+
+	def := func(key string, typ domain.AttributeType, required bool, allowed ...string) domain.AttributeDefinition {
+		d, err := domain.NewAttributeDefinition(key, typ, required, allowed)
+		require.NoError(t, err)
+		return d
+	}
+	attr := func(typ domain.AttributeType, value string) domain.AttributeValue {
+		v, err := domain.ParseAttribute(typ, value, "")
+		require.NoError(t, err)
+		return v
+	}
+
+	// Setup: A category whose products need a size from an enum
+	tag := fmt.Sprintf("zs%d", time.Now().UnixNano())
+	categoryID, err := createCategory.Execute(testCtx, createcategory.Request{
+		Name: "Shirts " + tag,
+		AttributeSchema: []domain.AttributeDefinition{
+			def("size", domain.AttributeEnum, true, "S", "M", "L"),
+			def("material", domain.AttributeString, false),
+		},
+	})
+	require.NoError(t, err)
+	slug := "shirts-" + tag
+
+	category, err := getCategoryQuery.Execute(testCtx, getcategory.Request{CategoryID: categoryID})
+	require.NoError(t, err)
+	require.Len(t, category.AttributeSchema, 2)
+	assert.Equal(t, "material", category.AttributeSchema[0].Key)
+	assert.Equal(t, []string{"S", "M", "L"}, category.AttributeSchema[1].AllowedValues)
+
+	// Verify: Create reports every violation by field
+	_, err = createUsecase.Execute(testCtx, createproduct.Request{
+		Name:       "Shirt",
+		Category:   slug,
+		BasePrice:  "25.00",
+		Attributes: map[string]domain.AttributeValue{"material": attr(domain.AttributeNumber, "100")},
+	})
+	var schemaErr *domain.AttributeSchemaError
+	require.ErrorAs(t, err, &schemaErr)
+	require.Len(t, schemaErr.Violations, 2)
+	assert.Equal(t, "attributes.material", schemaErr.Violations[0].Field)
+	assert.Equal(t, "attributes.size", schemaErr.Violations[1].Field)
+
+	// Test: Create a conforming product
+	productID, err := createUsecase.Execute(testCtx, createproduct.Request{
+		Name:       "Shirt",
+		Category:   slug,
+		BasePrice:  "25.00",
+		Attributes: map[string]domain.AttributeValue{"size": attr(domain.AttributeEnum, "M")},
+	})
+	require.NoError(t, err)
+
+	// Verify: Updates are checked against the schema
+	err = updateUsecase.Execute(testCtx, updateproduct.Request{
+		ProductID:  productID,
+		Attributes: map[string]domain.AttributeValue{"size": attr(domain.AttributeEnum, "XL")},
+	})
+	assert.ErrorIs(t, err, domain.ErrAttributeSchemaViolation)
+
+	// Test: Tighten the schema after the product was created
+	schema := []domain.AttributeDefinition{
+		def("size", domain.AttributeEnum, true, "S", "M", "L"),
+		def("material", domain.AttributeString, true),
+	}
+	err = updateCategory.Execute(testCtx, updatecategory.Request{CategoryID: categoryID, AttributeSchema: &schema})
+	require.NoError(t, err)
+
+	// Verify: Activation checks the current schema
+	err = activateUsecase.Execute(testCtx, activateproduct.Request{ProductID: productID})
+	require.ErrorAs(t, err, &schemaErr)
+	require.Len(t, schemaErr.Violations, 1)
+	assert.Equal(t, "attributes.material", schemaErr.Violations[0].Field)
+	assert.Equal(t, "is required", schemaErr.Violations[0].Description)
+
+	err = updateUsecase.Execute(testCtx, updateproduct.Request{
+		ProductID:  productID,
+		Attributes: map[string]domain.AttributeValue{"material": attr(domain.AttributeString, "cotton")},
+	})
+	require.NoError(t, err)
+	require.NoError(t, activateUsecase.Execute(testCtx, activateproduct.Request{ProductID: productID}))
+}
+
+func TestRecategorizeProducts(t *testing.T) {
+	setupTestDB(t)
+	defer teardownTestDB(t)
+
+	productRepo := repo.NewProductRepo(testDB, services.PricingCalculator{})
+	outboxRepo := repo.NewOutboxRepo()
+	categoryRepo := repo.NewCategoryRepo(testDB)
+	recategorizationRepo := repo.NewRecategorizationRepo(testDB)
+	readModel := repo.NewReadModel(testDB)
+	pricing := services.PricingCalculator{}
+
+	createUsecase := createproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	activateUsecase := activateproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	recategorizeUsecase := recategorizeproducts.New(recategorizationRepo, categoryRepo, outboxRepo, committer_, testClock)
+	runUsecase := runrecategorizations.New(recategorizationRepo, productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	getJobQuery := getrecategorization.New(readModel)
+	getQuery := getproduct.New(readModel, pricing)
+
+	// Setup: Three products, one inactive, in a category being merged away
+	tag := fmt.Sprintf("zq%d", time.Now().UnixNano())
+	ensureCategories(t, tag+"-footwear", tag+"-shoes")
+	var productIDs []string
+	for i := 0; i < 3; i++ {
+		id, err := createUsecase.Execute(testCtx, createproduct.Request{
+			Name:      fmt.Sprintf("Recategorize %s %d", tag, i),
+			Category:  tag + "-footwear",
+			BasePrice: "10.00",
+		})
+		require.NoError(t, err)
+		if i < 2 {
+			require.NoError(t, activateUsecase.Execute(testCtx, activateproduct.Request{ProductID: id}))
+		}
+		productIDs = append(productIDs, id)
+	}
+
+	// Verify: The target must be an existing category
+	_, err := recategorizeUsecase.Execute(testCtx, recategorizeproducts.Request{
+		FromCategory: tag + "-footwear",
+		ToCategory:   tag + "-missing",
+	})
+	assert.ErrorIs(t, err, domain.ErrUnknownCategory)
+
+	jobID, err := recategorizeUsecase.Execute(testCtx, recategorizeproducts.Request{
+		FromCategory: tag + "-footwear",
+		ToCategory:   tag + "-shoes",
+	})
+	require.NoError(t, err)
+
+	job, err := getJobQuery.Execute(testCtx, getrecategorization.Request{JobID: jobID})
+	require.NoError(t, err)
+	assert.Equal(t, "running", job.Status)
+	assert.Equal(t, int64(0), job.MovedProducts)
+	assert.Equal(t, int64(3), job.RemainingProducts)
+
+	// Test: Run the job in batches of two
+	_, err = runUsecase.Execute(testCtx, runrecategorizations.Request{BatchSize: 2})
+	require.NoError(t, err)
+
+	// Verify: Every product moved, inactive ones included
+	job, err = getJobQuery.Execute(testCtx, getrecategorization.Request{JobID: jobID})
+	require.NoError(t, err)
+	assert.Equal(t, "succeeded", job.Status)
+	assert.Equal(t, int64(3), job.MovedProducts)
+	assert.Equal(t, int64(0), job.RemainingProducts)
+	require.NotNil(t, job.FinishedAt)
+
+	for _, id := range productIDs {
+		product, err := getQuery.Execute(testCtx, getproduct.Request{ProductID: id})
+		require.NoError(t, err)
+		assert.Equal(t, tag+"-shoes", product.Category)
+
+		events := getOutboxEvents(t, id)
+		require.NotEmpty(t, events)
+		assert.Equal(t, "product.updated", events[len(events)-1].EventType)
+	}
+
+	jobEvents := getOutboxEvents(t, jobID)
+	require.Len(t, jobEvents, 2)
+	assert.Equal(t, "recategorization.started", jobEvents[0].EventType)
+	assert.Equal(t, "recategorization.completed", jobEvents[1].EventType)
+
+	// Verify: Running again leaves a finished job alone
+	_, err = runUsecase.Execute(testCtx, runrecategorizations.Request{BatchSize: 2})
+	require.NoError(t, err)
+	job, err = getJobQuery.Execute(testCtx, getrecategorization.Request{JobID: jobID})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), job.MovedProducts)
+	assert.Len(t, getOutboxEvents(t, jobID), 2)
+
+	_, err = getJobQuery.Execute(testCtx, getrecategorization.Request{JobID: tag})
+	assert.ErrorIs(t, err, domain.ErrRecategorizationNotFound)
+}
+
+func TestRecategorizeProductsSchemaMismatch(t *testing.T) {
+	setupTestDB(t)
+	defer teardownTestDB(t)
+
+	productRepo := repo.NewProductRepo(testDB, services.PricingCalculator{})
+	outboxRepo := repo.NewOutboxRepo()
+	categoryRepo := repo.NewCategoryRepo(testDB)
+	recategorizationRepo := repo.NewRecategorizationRepo(testDB)
+	readModel := repo.NewReadModel(testDB)
+
+	createCategory := createcategory.New(categoryRepo, outboxRepo, committer_, testClock)
+	createUsecase := createproduct.New(productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	recategorizeUsecase := recategorizeproducts.New(recategorizationRepo, categoryRepo, outboxRepo, committer_, testClock)
+	runUsecase := runrecategorizations.New(recategorizationRepo, productRepo, categoryRepo, outboxRepo, committer_, testClock)
+	getJobQuery := getrecategorization.New(readModel)
+	getQuery := getproduct.New(readModel, services.PricingCalculator{})
+
+	// Setup: Products without attributes and a target requiring a size
+	tag := fmt.Sprintf("zr%d", time.Now().UnixNano())
+	ensureCategories(t, tag+"-plain")
+	size, err := domain.NewAttributeDefinition("size", domain.AttributeEnum, true, []string{"S", "M", "L"})
+	require.NoError(t, err)
+	_, err = createCategory.Execute(testCtx, createcategory.Request{
+		Name:            "Sized " + tag,
+		Slug:            tag + "-sized",
+		AttributeSchema: []domain.AttributeDefinition{size},
+	})
+	require.NoError(t, err)
+
+	var productIDs []string
+	for i := 0; i < 2; i++ {
+		id, err := createUsecase.Execute(testCtx, createproduct.Request{
+			Name:      fmt.Sprintf("Mismatch %s %d", tag, i),
+			Category:  tag + "-plain",
+			BasePrice: "10.00",
+		})
+		require.NoError(t, err)
+		productIDs = append(productIDs, id)
+	}
+
+	jobID, err := recategorizeUsecase.Execute(testCtx, recategorizeproducts.Request{
+		FromCategory: tag + "-plain",
+		ToCategory:   tag + "-sized",
+	})
+	require.NoError(t, err)
+
+	// Test: Run the job
+	result, err := runUsecase.Execute(testCtx, runrecategorizations.Request{BatchSize: 2})
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, result.FailedJobs, 1)
+
+	// Verify: The job failed without moving any product
+	job, err := getJobQuery.Execute(testCtx, getrecategorization.Request{JobID: jobID})
+	require.NoError(t, err)
+	assert.Equal(t, "failed", job.Status)
+	assert.Contains(t, job.Failure, domain.ErrAttributeSchemaViolation.Error())
+	assert.Equal(t, int64(0), job.MovedProducts)
+	assert.Equal(t, int64(2), job.RemainingProducts)
+	require.NotNil(t, job.FinishedAt)
+
+	for _, id := range productIDs {
+		product, err := getQuery.Execute(testCtx, getproduct.Request{ProductID: id})
+		require.NoError(t, err)
+		assert.Equal(t, tag+"-plain", product.Category)
+	}
+
+	jobEvents := getOutboxEvents(t, jobID)
+	require.Len(t, jobEvents, 2)
+	assert.Equal(t, "recategorization.failed", jobEvents[1].EventType)
+}
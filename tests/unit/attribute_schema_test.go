@@ -0,0 +1,114 @@
+package unit
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"product-catalog-service/internal/app/product/domain"
+)
+
+func TestAttributeDefinition(t *testing.T) {
+	t.Run("Enums need allowed values, other types take none", func(t *testing.T) {
+		_, err := domain.NewAttributeDefinition("size", domain.AttributeEnum, true, nil)
+		assert.ErrorIs(t, err, domain.ErrInvalidCategory)
+
+		_, err = domain.NewAttributeDefinition("color", domain.AttributeString, false, []string{"red"})
+		assert.ErrorIs(t, err, domain.ErrInvalidCategory)
+
+		_, err = domain.NewAttributeDefinition("size", domain.AttributeEnum, true, []string{"S", "S"})
+		assert.ErrorIs(t, err, domain.ErrInvalidCategory)
+
+		d, err := domain.NewAttributeDefinition("size", domain.AttributeEnum, true, []string{"S", "M"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"S", "M"}, d.AllowedValues())
+	})
+
+	t.Run("Keys and types are checked", func(t *testing.T) {
+		_, err := domain.NewAttributeDefinition("Screen Size", domain.AttributeNumber, false, nil)
+		assert.ErrorIs(t, err, domain.ErrInvalidCategory)
+
+		_, err = domain.NewAttributeDefinition("released", domain.AttributeType("date"), false, nil)
+		assert.ErrorIs(t, err, domain.ErrInvalidCategory)
+	})
+}
+
+func TestCategoryAttributeSchema(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	def := func(key string, typ domain.AttributeType, required bool, allowed ...string) domain.AttributeDefinition {
+		d, err := domain.NewAttributeDefinition(key, typ, required, allowed)
+		require.NoError(t, err)
+		return d
+	}
+	size := def("size", domain.AttributeEnum, true, "S", "M", "L")
+	material := def("material", domain.AttributeString, false)
+
+	t.Run("Only changed definitions are marked dirty", func(t *testing.T) {
+		c := domain.RehydrateCategory("c1", "Shirts", "shirts", "", 0, []domain.AttributeDefinition{size, material}, now, now)
+		later := now.Add(time.Minute)
+
+		err := c.SetAttributeSchema([]domain.AttributeDefinition{
+			def("weight", domain.AttributeMeasurement, false),
+			size,
+		}, later)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"attribute_schema.material", "attribute_schema.weight"},
+			c.Changes().DirtyWithPrefix("attribute_schema."))
+		assert.True(t, c.Changes().Dirty(domain.FieldAttributeSchema))
+		schema := c.AttributeSchema()
+		require.Len(t, schema, 2)
+		assert.Equal(t, "size", schema[0].Key())
+		assert.Equal(t, later, c.UpdatedAt())
+		require.Len(t, c.DomainEvents(), 1)
+		assert.IsType(t, domain.CategoryUpdatedEvent{}, c.DomainEvents()[0])
+	})
+
+	t.Run("Duplicate keys are rejected", func(t *testing.T) {
+		c := domain.RehydrateCategory("c1", "Shirts", "shirts", "", 0, nil, now, now)
+
+		err := c.SetAttributeSchema([]domain.AttributeDefinition{size, size}, now)
+		assert.ErrorIs(t, err, domain.ErrInvalidCategory)
+		assert.Empty(t, c.AttributeSchema())
+	})
+
+	t.Run("All violations are reported by field", func(t *testing.T) {
+		c := domain.RehydrateCategory("c1", "Shirts", "shirts", "", 0, []domain.AttributeDefinition{
+			size,
+			material,
+			def("sleeve_length", domain.AttributeMeasurement, true),
+		}, now, now)
+		xl, err := domain.NewEnumAttribute("XL")
+		require.NoError(t, err)
+		weight, err := domain.ParseAttribute(domain.AttributeNumber, "3", "")
+		require.NoError(t, err)
+
+		err = c.ValidateAttributes(map[string]domain.AttributeValue{
+			"size":     xl,
+			"material": weight,
+			"brand":    xl,
+		})
+		require.ErrorIs(t, err, domain.ErrAttributeSchemaViolation)
+		var schemaErr *domain.AttributeSchemaError
+		require.True(t, errors.As(err, &schemaErr))
+		assert.Equal(t, "shirts", schemaErr.Category)
+		require.Len(t, schemaErr.Violations, 3)
+		assert.Equal(t, "attributes.material", schemaErr.Violations[0].Field)
+		assert.Equal(t, "must be of type string, got number", schemaErr.Violations[0].Description)
+		assert.Equal(t, "attributes.size", schemaErr.Violations[1].Field)
+		assert.Equal(t, `must be one of S, M, L, got "XL"`, schemaErr.Violations[1].Description)
+		assert.Equal(t, "attributes.sleeve_length", schemaErr.Violations[2].Field)
+		assert.Equal(t, "is required", schemaErr.Violations[2].Description)
+	})
+
+	t.Run("Conforming attributes pass", func(t *testing.T) {
+		c := domain.RehydrateCategory("c1", "Shirts", "shirts", "", 0, []domain.AttributeDefinition{size, material}, now, now)
+		m, err := domain.NewEnumAttribute("M")
+		require.NoError(t, err)
+
+		assert.NoError(t, c.ValidateAttributes(map[string]domain.AttributeValue{"size": m}))
+	})
+}
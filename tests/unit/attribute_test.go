@@ -0,0 +1,98 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"product-catalog-service/internal/app/product/domain"
+)
+
+func TestParseAttribute(t *testing.T) {
+	t.Run("Values keep their type", func(t *testing.T) {
+		v, err := domain.ParseAttribute(domain.AttributeMeasurement, "42.5", "cm")
+		require.NoError(t, err)
+		assert.Equal(t, domain.AttributeMeasurement, v.Type())
+		assert.Equal(t, "85/2", v.Number().RatString())
+		assert.Equal(t, "42.5 cm", v.String())
+
+		v, err = domain.ParseAttribute(domain.AttributeBool, "false", "")
+		require.NoError(t, err)
+		assert.False(t, v.Bool())
+		assert.Equal(t, "false", v.String())
+	})
+
+	t.Run("Invalid values are rejected", func(t *testing.T) {
+		for _, tc := range []struct {
+			typ         domain.AttributeType
+			value, unit string
+		}{
+			{domain.AttributeString, "", ""},
+			{domain.AttributeEnum, "  ", ""},
+			{domain.AttributeBool, "yes", ""},
+			{domain.AttributeNumber, "12abc", ""},
+			{domain.AttributeNumber, "0.0000000001", ""},
+			{domain.AttributeMeasurement, "10", ""},
+			{domain.AttributeType("date"), "2026-01-01", ""},
+		} {
+			_, err := domain.ParseAttribute(tc.typ, tc.value, tc.unit)
+			assert.ErrorIs(t, err, domain.ErrInvalidAttribute, tc)
+		}
+	})
+}
+
+func TestUpdateAttributes(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	newProduct := func(attrs map[string]domain.AttributeValue) *domain.Product {
+		basePrice, _ := domain.NewMoneyFromFraction(1000, 100)
+		return domain.RehydrateProduct("p1", "Shoe", "", "shoes", basePrice, nil, nil, attrs, nil,
+			domain.ProductStatusActive, nil, now, now)
+	}
+	text := func(s string) domain.AttributeValue {
+		v, err := domain.NewStringAttribute(s)
+		require.NoError(t, err)
+		return v
+	}
+
+	t.Run("Only changed attributes are marked dirty", func(t *testing.T) {
+		p := newProduct(map[string]domain.AttributeValue{"color": text("red"), "material": text("leather")})
+		later := now.Add(time.Minute)
+
+		err := p.UpdateAttributes(map[string]domain.AttributeValue{
+			"color":   text("red"),
+			"pattern": text("striped"),
+		}, []string{"material", "unknown"}, later)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"attributes.material", "attributes.pattern"}, p.Changes().DirtyWithPrefix("attributes."))
+		assert.True(t, p.Changes().Dirty(domain.FieldAttributes))
+		assert.Len(t, p.Attributes(), 2)
+		assert.Equal(t, later, p.UpdatedAt())
+		require.Len(t, p.DomainEvents(), 1)
+		assert.IsType(t, domain.ProductUpdatedEvent{}, p.DomainEvents()[0])
+	})
+
+	t.Run("Unchanged attributes record nothing", func(t *testing.T) {
+		p := newProduct(map[string]domain.AttributeValue{"color": text("red")})
+
+		require.NoError(t, p.UpdateAttributes(map[string]domain.AttributeValue{"color": text("red")}, nil, now))
+		assert.False(t, p.Changes().Dirty(domain.FieldAttributes))
+		assert.Empty(t, p.DomainEvents())
+	})
+
+	t.Run("Invalid updates are rejected", func(t *testing.T) {
+		p := newProduct(nil)
+
+		err := p.UpdateAttributes(map[string]domain.AttributeValue{"Color": text("red")}, nil, now)
+		assert.ErrorIs(t, err, domain.ErrInvalidAttribute)
+
+		err = p.UpdateAttributes(map[string]domain.AttributeValue{"color": text("red")}, []string{"color"}, now)
+		assert.ErrorIs(t, err, domain.ErrInvalidAttribute)
+
+		err = p.UpdateAttributes(map[string]domain.AttributeValue{"color": {}}, nil, now)
+		assert.ErrorIs(t, err, domain.ErrInvalidAttribute)
+		assert.Empty(t, p.Attributes())
+	})
+}
@@ -0,0 +1,121 @@
+package unit
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"product-catalog-service/internal/app/product/contracts"
+	"product-catalog-service/internal/app/product/domain"
+	categorytree "product-catalog-service/internal/app/product/queries/category_tree"
+)
+
+func TestCategory(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Slug is derived from the name when empty", func(t *testing.T) {
+		c, err := domain.NewCategory("c1", "  Home & Garden ", "", "", 0, now)
+		require.NoError(t, err)
+		assert.Equal(t, "Home & Garden", c.Name())
+		assert.Equal(t, "home-garden", c.Slug())
+		require.Len(t, c.DomainEvents(), 1)
+		assert.IsType(t, domain.CategoryCreatedEvent{}, c.DomainEvents()[0])
+	})
+
+	t.Run("Invalid names and slugs are rejected", func(t *testing.T) {
+		for _, tc := range []struct{ name, slug string }{
+			{"", "shoes"},
+			{strings.Repeat("x", domain.MaxCategoryNameLength+1), ""},
+			{"Shoes", "Shoes"},
+			{"Shoes", "shoes--boots"},
+			{"Shoes", "-shoes"},
+			{"Обувь", ""},
+		} {
+			_, err := domain.NewCategory("c1", tc.name, tc.slug, "", 0, now)
+			assert.ErrorIs(t, err, domain.ErrInvalidCategory, tc)
+		}
+	})
+
+	t.Run("Slugify", func(t *testing.T) {
+		assert.Equal(t, "mens-shoes-2026", domain.Slugify("Men's Shoes (2026)"))
+		assert.Equal(t, "caf-cr-me", domain.Slugify("Café Crème"))
+		long := domain.Slugify(strings.Repeat("ab ", 60))
+		assert.Len(t, long, domain.MaxCategorySlugLength)
+		assert.True(t, domain.ValidSlug(long))
+	})
+
+	t.Run("Update marks only changed fields", func(t *testing.T) {
+		c := domain.RehydrateCategory("c1", "Shoes", "shoes", "", 5, nil, now, now)
+		require.NoError(t, c.UpdateDetails("Shoes", 5, now))
+		assert.Empty(t, c.DomainEvents())
+
+		require.NoError(t, c.UpdateDetails("", 7, now))
+		assert.True(t, c.Changes().Dirty(domain.FieldSortOrder))
+		assert.False(t, c.Changes().Dirty(domain.FieldName))
+		assert.Equal(t, "Shoes", c.Name())
+		assert.Len(t, c.DomainEvents(), 1)
+	})
+
+	t.Run("Move rejects cycles", func(t *testing.T) {
+		c := domain.RehydrateCategory("c1", "Shoes", "shoes", "", 0, nil, now, now)
+		assert.ErrorIs(t, c.MoveTo("c1", nil, now), domain.ErrCategoryCycle)
+		assert.ErrorIs(t, c.MoveTo("c3", []string{"c2", "c1"}, now), domain.ErrCategoryCycle)
+		assert.Empty(t, c.DomainEvents())
+
+		require.NoError(t, c.MoveTo("c9", []string{"c8"}, now))
+		assert.Equal(t, "c9", c.ParentID())
+		assert.True(t, c.Changes().Dirty(domain.FieldParentID))
+		require.Len(t, c.DomainEvents(), 1)
+		moved := c.DomainEvents()[0].(domain.CategoryMovedEvent)
+		assert.Equal(t, "", moved.OldParentID)
+		assert.Equal(t, "c9", moved.NewParentID)
+
+		// The committing transaction re-checks the parent after the move
+		assert.NoError(t, c.ValidateParent("c9", []string{"c8"}))
+		assert.ErrorIs(t, c.ValidateParent("c9", []string{"c1"}), domain.ErrCategoryCycle)
+	})
+}
+
+func TestCategoryTree(t *testing.T) {
+	tree := categorytree.Build([]*contracts.CategoryRecord{
+		{CategoryID: "shoes", Slug: "shoes", Name: "Shoes", SortOrder: 1},
+		{CategoryID: "hats", Slug: "hats", Name: "Hats", SortOrder: 0},
+		{CategoryID: "running", Slug: "running", Name: "Running", ParentID: "shoes", SortOrder: 1},
+		{CategoryID: "boots", Slug: "boots", Name: "Boots", ParentID: "shoes", SortOrder: 1},
+		{CategoryID: "trail", Slug: "trail", Name: "Trail", ParentID: "running"},
+		{CategoryID: "orphan", Slug: "orphan", Name: "Orphan", ParentID: "deleted", SortOrder: 9},
+	})
+
+	t.Run("Walk is depth-first by sort order then name", func(t *testing.T) {
+		var got []string
+		for _, n := range tree.Walk("") {
+			got = append(got, strings.Repeat(">", n.Depth)+n.Record.Slug)
+		}
+		assert.Equal(t, []string{"hats", "shoes", ">boots", ">running", ">>trail", "orphan"}, got)
+	})
+
+	t.Run("Walk of a subtree keeps absolute depths", func(t *testing.T) {
+		nodes := tree.Walk("running")
+		require.Len(t, nodes, 1)
+		assert.Equal(t, "trail", nodes[0].Record.Slug)
+		assert.Equal(t, 2, nodes[0].Depth)
+	})
+
+	t.Run("Subtree slugs include the category and its descendants", func(t *testing.T) {
+		assert.Equal(t, []string{"shoes", "boots", "running", "trail"}, tree.SubtreeSlugs("shoes"))
+		assert.Equal(t, []string{"legacy"}, tree.SubtreeSlugs("legacy"))
+	})
+
+	t.Run("Cycles do not loop", func(t *testing.T) {
+		cyclic := categorytree.Build([]*contracts.CategoryRecord{
+			{CategoryID: "a", Slug: "a", ParentID: "b"},
+			{CategoryID: "b", Slug: "b", ParentID: "a"},
+		})
+		assert.Empty(t, cyclic.Walk(""))
+		assert.Equal(t, []string{"a", "b"}, cyclic.SubtreeSlugs("a"))
+		assert.GreaterOrEqual(t, cyclic.Depth("a"), 0)
+	})
+}
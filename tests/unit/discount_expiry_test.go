@@ -0,0 +1,70 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"product-catalog-service/internal/app/product/domain"
+	"product-catalog-service/internal/app/product/domain/services"
+)
+
+func TestDiscountExpiry(t *testing.T) {
+	now := time.Date(2026, 3, 3, 12, 0, 0, 0, time.UTC)
+	calc := services.PricingCalculator{Policy: services.PolicySequential}
+
+	t.Run("Expired discounts are cleared", func(t *testing.T) {
+		product := newStackedProduct(t, now,
+			mustDiscount(t, "a", 10, now, 0, false),
+			mustDiscount(t, "b", 20, now, 0, false),
+		)
+		later := now.Add(2 * time.Hour)
+
+		expired := product.ExpireDiscounts(later)
+		require.Len(t, expired, 2)
+		assert.Empty(t, product.Discounts())
+		assert.True(t, product.Changes().Dirty(domain.FieldDiscount))
+
+		events := product.DomainEvents()
+		require.Len(t, events, 2)
+		assert.Equal(t, "a", events[0].(domain.DiscountExpiredEvent).DiscountID)
+		assert.Equal(t, "b", events[1].(domain.DiscountExpiredEvent).DiscountID)
+	})
+
+	t.Run("Running discounts are kept", func(t *testing.T) {
+		product := newStackedProduct(t, now, mustDiscount(t, "a", 10, now, 0, false))
+
+		assert.Empty(t, product.ExpireDiscounts(now))
+		assert.Len(t, product.Discounts(), 1)
+		assert.Empty(t, product.DomainEvents())
+		assert.False(t, product.Changes().Dirty(domain.FieldDiscount))
+	})
+
+	t.Run("Price change is recorded with old and new price", func(t *testing.T) {
+		product := newStackedProduct(t, now, mustDiscount(t, "a", 10, now, 0, false))
+		later := now.Add(2 * time.Hour)
+
+		oldPrice := calc.EffectivePrice(product, now)
+		product.ExpireDiscounts(later)
+		newPrice := calc.EffectivePrice(product, later)
+		product.RecordEffectivePriceChange(oldPrice, newPrice, later)
+
+		events := product.DomainEvents()
+		require.Len(t, events, 2)
+		changed, ok := events[1].(domain.EffectivePriceChangedEvent)
+		require.True(t, ok)
+		assert.Equal(t, "90", changed.OldPrice)
+		assert.Equal(t, "100", changed.NewPrice)
+		assert.Equal(t, "USD", changed.Currency)
+	})
+
+	t.Run("Unchanged price records nothing", func(t *testing.T) {
+		product := newStackedProduct(t, now)
+		price := calc.EffectivePrice(product, now)
+
+		product.RecordEffectivePriceChange(price, price, now)
+		assert.Empty(t, product.DomainEvents())
+	})
+}
@@ -0,0 +1,91 @@
+package unit
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"product-catalog-service/internal/app/product/domain"
+	"product-catalog-service/internal/app/product/domain/services"
+)
+
+func TestDiscountKinds(t *testing.T) {
+	now := time.Date(2026, 3, 3, 12, 0, 0, 0, time.UTC)
+	start, end := now.Add(-time.Hour), now.Add(time.Hour)
+
+	fiveOff, _ := domain.NewMoneyFromFraction(500, 100)
+	twoHundredOff, _ := domain.NewMoneyFromFraction(20000, 100)
+	override, _ := domain.NewMoneyFromFraction(4999, 100)
+
+	t.Run("Fixed amount must be positive", func(t *testing.T) {
+		zero, _ := domain.NewMoneyFromFraction(0, 1)
+		_, err := domain.NewFixedAmountDiscount("a", zero, start, end, 0, false)
+		require.Error(t, err)
+	})
+
+	t.Run("Override must not be negative", func(t *testing.T) {
+		negative, _ := domain.NewMoneyFromFraction(-1, 1)
+		_, err := domain.NewPriceOverrideDiscount("a", negative, start, end, 0, false)
+		require.Error(t, err)
+	})
+
+	t.Run("Fixed amount subtracts from price", func(t *testing.T) {
+		d, err := domain.NewFixedAmountDiscount("five", fiveOff, start, end, 0, false)
+		require.NoError(t, err)
+		product := newStackedProduct(t, now, d)
+
+		effective := services.PricingCalculator{}.EffectivePrice(product, now)
+		assert.Equal(t, 0, effective.Rat().Cmp(big.NewRat(95, 1)))
+	})
+
+	t.Run("Fixed amount is clamped at zero", func(t *testing.T) {
+		d, _ := domain.NewFixedAmountDiscount("big", twoHundredOff, start, end, 0, false)
+		product := newStackedProduct(t, now, d)
+
+		effective := services.PricingCalculator{}.EffectivePrice(product, now)
+		assert.Equal(t, 0, effective.Rat().Sign())
+	})
+
+	t.Run("Override sets price", func(t *testing.T) {
+		d, err := domain.NewPriceOverrideDiscount("promo", override, start, end, 0, false)
+		require.NoError(t, err)
+		product := newStackedProduct(t, now, d)
+
+		effective := services.PricingCalculator{}.EffectivePrice(product, now)
+		assert.Equal(t, 0, effective.Rat().Cmp(big.NewRat(4999, 100)))
+	})
+
+	t.Run("Best-of compares kinds by resulting price", func(t *testing.T) {
+		five, _ := domain.NewFixedAmountDiscount("five", fiveOff, start, end, 0, false)
+		tenPercent := mustDiscount(t, "ten", 10, now, 0, false)
+		product := newStackedProduct(t, now, five, tenPercent)
+
+		applicable := services.PricingCalculator{}.ApplicableDiscounts(product, now)
+		require.Len(t, applicable, 1)
+		assert.Equal(t, "ten", applicable[0].ID())
+	})
+
+	t.Run("Sequential applies kinds in priority order", func(t *testing.T) {
+		five, _ := domain.NewFixedAmountDiscount("five", fiveOff, start, end, 1, false)
+		tenPercent := mustDiscount(t, "ten", 10, now, 2, false)
+		product := newStackedProduct(t, now, five, tenPercent)
+
+		calc := services.PricingCalculator{Policy: services.PolicySequential}
+		// (100 * 0.9) - 5 = 85
+		assert.Equal(t, 0, calc.EffectivePrice(product, now).Rat().Cmp(big.NewRat(85, 1)))
+	})
+
+	t.Run("Additive subtracts summed amounts after percentages", func(t *testing.T) {
+		five, _ := domain.NewFixedAmountDiscount("five", fiveOff, start, end, 0, false)
+		tenPercent := mustDiscount(t, "ten", 10, now, 0, false)
+		twenty := mustDiscount(t, "twenty", 20, now, 0, false)
+		product := newStackedProduct(t, now, five, tenPercent, twenty)
+
+		calc := services.PricingCalculator{Policy: services.PolicyAdditiveCapped}
+		// 100 * (1 - 0.3) - 5 = 65
+		assert.Equal(t, 0, calc.EffectivePrice(product, now).Rat().Cmp(big.NewRat(65, 1)))
+	})
+}
@@ -18,7 +18,8 @@ func TestMoneyCalculations(t *testing.T) {
 		require.NoError(t, err)
 		assert.NotNil(t, money)
 
-		num, den := money.Fraction()
+		num, den, err := money.Fraction()
+		require.NoError(t, err)
 		assert.Equal(t, int64(1999), num)
 		assert.Equal(t, int64(100), den)
 	})
@@ -35,7 +36,8 @@ func TestMoneyCalculations(t *testing.T) {
 		result := money.MultiplyBy(discount)
 		assert.NotNil(t, result)
 
-		num, den := result.Fraction()
+		num, den, err := result.Fraction()
+		require.NoError(t, err)
 		assert.Equal(t, int64(2000), num)
 		assert.Equal(t, int64(100), den) // $20.00
 	})
@@ -47,7 +49,8 @@ func TestMoneyCalculations(t *testing.T) {
 		result := money1.Subtract(money2)
 		assert.NotNil(t, result)
 
-		num, den := result.Fraction()
+		num, den, err := result.Fraction()
+		require.NoError(t, err)
 		assert.Equal(t, int64(8000), num)
 		assert.Equal(t, int64(100), den) // $80.00
 	})
@@ -145,6 +148,9 @@ func TestPricingCalculator(t *testing.T) {
 			"test",
 			basePrice,
 			nil, // no discount
+			nil,
+			nil,
+			nil,
 			domain.ProductStatusActive,
 			nil,
 			time.Now(),
@@ -154,7 +160,8 @@ func TestPricingCalculator(t *testing.T) {
 		effective := calculator.EffectivePrice(product, time.Now())
 		assert.NotNil(t, effective)
 
-		num, den := effective.Fraction()
+		num, den, err := effective.Fraction()
+		require.NoError(t, err)
 		assert.Equal(t, int64(10000), num)
 		assert.Equal(t, int64(100), den) // Same as base price
 	})
@@ -173,7 +180,10 @@ func TestPricingCalculator(t *testing.T) {
 			"Test",
 			"test",
 			basePrice,
-			discount,
+			[]*domain.Discount{discount},
+			nil,
+			nil,
+			nil,
 			domain.ProductStatusActive,
 			nil,
 			time.Now(),
@@ -183,7 +193,8 @@ func TestPricingCalculator(t *testing.T) {
 		effective := calculator.EffectivePrice(product, time.Now())
 		assert.NotNil(t, effective)
 
-		num, den := effective.Fraction()
+		num, den, err := effective.Fraction()
+		require.NoError(t, err)
 		assert.Equal(t, int64(8000), num)
 		assert.Equal(t, int64(100), den) // $80.00 (20% off)
 	})
@@ -202,7 +213,10 @@ func TestPricingCalculator(t *testing.T) {
 			"Test",
 			"test",
 			basePrice,
-			discount,
+			[]*domain.Discount{discount},
+			nil,
+			nil,
+			nil,
 			domain.ProductStatusActive,
 			nil,
 			time.Now(),
@@ -213,7 +227,8 @@ func TestPricingCalculator(t *testing.T) {
 		assert.NotNil(t, effective)
 
 		// Should return base price since discount is expired
-		num, den := effective.Fraction()
+		num, den, err := effective.Fraction()
+		require.NoError(t, err)
 		assert.Equal(t, int64(10000), num)
 		assert.Equal(t, int64(100), den)
 	})
@@ -233,7 +248,10 @@ func TestPricingCalculator(t *testing.T) {
 			"Test",
 			"test",
 			basePrice,
-			discount,
+			[]*domain.Discount{discount},
+			nil,
+			nil,
+			nil,
 			domain.ProductStatusActive,
 			nil,
 			time.Now(),
@@ -245,7 +263,8 @@ func TestPricingCalculator(t *testing.T) {
 
 		// $99.99 * 0.85 = $84.9915
 		// Using big.Rat preserves precision
-		num, den := effective.Fraction()
+		num, den, err := effective.Fraction()
+		require.NoError(t, err)
 		expected := big.NewRat(9999, 100)
 		expected.Mul(expected, big.NewRat(85, 100))
 		expectedNum, expectedDen := expected.Num().Int64(), expected.Denom().Int64()
@@ -380,7 +399,7 @@ func TestStateMachineTransitions(t *testing.T) {
 
 		err := product.ApplyDiscount(discount, time.Now())
 		assert.NoError(t, err)
-		assert.NotNil(t, product.Discount())
+		assert.Len(t, product.Discounts(), 1)
 		assert.True(t, product.Changes().Dirty(domain.FieldDiscount))
 	})
 }
@@ -0,0 +1,58 @@
+package unit
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"product-catalog-service/internal/app/product/contracts"
+	"product-catalog-service/internal/app/product/queries/facets"
+)
+
+func TestFacetsParse(t *testing.T) {
+	t.Run("Price buckets parse as ascending decimals", func(t *testing.T) {
+		parsed, err := facets.Parse(facets.Request{Category: true, PriceBuckets: []string{"0", "24.99", "50"}})
+		require.NoError(t, err)
+		assert.True(t, parsed.Category)
+		require.Len(t, parsed.PriceBuckets, 3)
+		assert.Equal(t, big.NewRat(2499, 100), parsed.PriceBuckets[1])
+	})
+
+	t.Run("Invalid price buckets are rejected", func(t *testing.T) {
+		for _, buckets := range [][]string{
+			{"10", "10"},
+			{"50", "25"},
+			{"-1"},
+			{"1/3"},
+			{"1e3"},
+			{"cheap"},
+		} {
+			_, err := facets.Parse(facets.Request{PriceBuckets: buckets})
+			assert.ErrorIs(t, err, facets.ErrInvalidPriceBuckets, buckets)
+		}
+	})
+
+	t.Run("Empty request selects nothing", func(t *testing.T) {
+		assert.True(t, facets.Request{}.Empty())
+		assert.False(t, facets.Request{Discount: true}.Empty())
+	})
+
+	t.Run("Counts convert with exact bucket bounds", func(t *testing.T) {
+		dto := facets.ToDTO(&contracts.FacetCounts{
+			Categories: []contracts.FacetCount{{Value: "shoes", Count: 124}},
+			Discounted: 37,
+			PriceBuckets: []contracts.PriceBucketCount{
+				{From: big.NewRat(0, 1), To: big.NewRat(2499, 100), Count: 3},
+				{From: big.NewRat(2499, 100), Count: 1},
+			},
+		})
+		assert.Equal(t, []facets.CountDTO{{Value: "shoes", Count: 124}}, dto.Categories)
+		assert.Equal(t, int64(37), dto.Discounted)
+		assert.Equal(t, []facets.PriceBucketDTO{
+			{From: "0", To: "24.99", Count: 3},
+			{From: "24.99", Count: 1},
+		}, dto.PriceBuckets)
+	})
+}
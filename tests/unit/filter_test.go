@@ -0,0 +1,122 @@
+package unit
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"product-catalog-service/internal/pkg/filter"
+)
+
+func TestFilterParse(t *testing.T) {
+	schema := filter.Schema{
+		"category":     filter.TypeString,
+		"price":        filter.TypeNumber,
+		"has_discount": filter.TypeBool,
+		"updated_at":   filter.TypeTimestamp,
+	}
+
+	t.Run("Empty filter matches all", func(t *testing.T) {
+		expr, err := filter.Parse("  ", schema)
+		require.NoError(t, err)
+		assert.Nil(t, expr)
+	})
+
+	t.Run("Typed comparisons joined by AND", func(t *testing.T) {
+		expr, err := filter.Parse(`category = "shoes" AND price < 49.99 AND has_discount = true AND updated_at > "2026-01-01"`, schema)
+		require.NoError(t, err)
+
+		and, ok := expr.(filter.And)
+		require.True(t, ok)
+		updated, ok := and.Right.(filter.Comparison)
+		require.True(t, ok)
+		assert.Equal(t, filter.OpGreater, updated.Op)
+		assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), updated.Value.Time)
+
+		and, ok = and.Left.(filter.And)
+		require.True(t, ok)
+		hasDiscount := and.Right.(filter.Comparison)
+		assert.True(t, hasDiscount.Value.Bool)
+
+		and = and.Left.(filter.And)
+		category := and.Left.(filter.Comparison)
+		assert.Equal(t, "shoes", category.Value.String)
+		price := and.Right.(filter.Comparison)
+		assert.Equal(t, 0, price.Value.Number.Cmp(big.NewRat(4999, 100)))
+	})
+
+	t.Run("OR binds tighter than AND", func(t *testing.T) {
+		expr, err := filter.Parse(`price > 10 AND category = "a" OR category = "b"`, schema)
+		require.NoError(t, err)
+
+		and, ok := expr.(filter.And)
+		require.True(t, ok)
+		_, ok = and.Right.(filter.Or)
+		assert.True(t, ok)
+	})
+
+	t.Run("NOT and parentheses", func(t *testing.T) {
+		expr, err := filter.Parse(`NOT (category = "a" AND price >= -1)`, schema)
+		require.NoError(t, err)
+
+		not, ok := expr.(filter.Not)
+		require.True(t, ok)
+		_, ok = not.Expr.(filter.And)
+		assert.True(t, ok)
+	})
+
+	t.Run("Errors report the position", func(t *testing.T) {
+		cases := map[string]int{
+			`sku = "x"`:                0,
+			`price < "10"`:             8,
+			`category < "a"`:           9,
+			`has_discount = yes`:       15,
+			`updated_at > "yesterday"`: 13,
+			`category = "a" AND`:       18,
+			`(category = "a"`:          15,
+			`category = "a" price > 1`: 15,
+			`category : "a"`:           9,
+			`category = "unterminated`: 11,
+		}
+		for input, pos := range cases {
+			_, err := filter.Parse(input, schema)
+			var filterErr *filter.Error
+			require.ErrorAs(t, err, &filterErr, input)
+			assert.Equal(t, pos, filterErr.Pos, input)
+		}
+	})
+
+	t.Run("Wildcard fields take the literal type", func(t *testing.T) {
+		attrs := filter.Schema{"attributes.*": filter.TypeAny}
+
+		expr, err := filter.Parse(`attributes.color = "red" AND attributes.size >= 42 AND attributes.organic = true`, attrs)
+		require.NoError(t, err)
+		and := expr.(filter.And)
+		organic := and.Right.(filter.Comparison)
+		assert.Equal(t, "attributes.organic", organic.Field)
+		assert.Equal(t, filter.TypeBool, organic.Value.Type)
+		and = and.Left.(filter.And)
+		assert.Equal(t, filter.TypeString, and.Left.(filter.Comparison).Value.Type)
+		size := and.Right.(filter.Comparison)
+		assert.Equal(t, filter.TypeNumber, size.Value.Type)
+		assert.Equal(t, 0, size.Value.Number.Cmp(big.NewRat(42, 1)))
+
+		cases := map[string]int{
+			`attributes = "red"`:         0,
+			`attributes. = "red"`:        0,
+			`attributes.a.b = "red"`:     0,
+			`attributes.color < "red"`:   17,
+			`attributes.color = red`:     19,
+			`attributes.organic > false`: 19,
+		}
+		for input, pos := range cases {
+			_, err := filter.Parse(input, attrs)
+			var filterErr *filter.Error
+			require.ErrorAs(t, err, &filterErr, input)
+			assert.Equal(t, pos, filterErr.Pos, input)
+		}
+	})
+}
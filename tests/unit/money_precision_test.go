@@ -0,0 +1,81 @@
+package unit
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"product-catalog-service/internal/app/product/domain"
+	"product-catalog-service/internal/app/product/domain/services"
+)
+
+func TestMoneyPrecision(t *testing.T) {
+	t.Run("Fraction reports overflow instead of truncating", func(t *testing.T) {
+		huge, ok := new(big.Rat).SetString("123456789012345678901234567/1000")
+		require.True(t, ok)
+
+		_, _, err := domain.NewMoneyFromRat(huge).Fraction()
+		assert.ErrorIs(t, err, domain.ErrMoneyOverflow)
+	})
+
+	t.Run("Parse decimal and fraction strings", func(t *testing.T) {
+		decimal, err := domain.NewMoneyFromString("15.99")
+		require.NoError(t, err)
+		assert.Equal(t, 0, decimal.Rat().Cmp(big.NewRat(1599, 100)))
+
+		fraction, err := domain.NewMoneyFromString("1/3")
+		require.NoError(t, err)
+		assert.Equal(t, 0, fraction.Rat().Cmp(big.NewRat(1, 3)))
+
+		_, err = domain.NewMoneyFromString("abc")
+		assert.Error(t, err)
+	})
+
+	t.Run("Exact string", func(t *testing.T) {
+		assert.Equal(t, "15.992", domain.ExactString(big.NewRat(15992, 1000)))
+		assert.Equal(t, "20", domain.ExactString(big.NewRat(2000, 100)))
+		assert.Equal(t, "1/3", domain.ExactString(big.NewRat(1, 3)))
+	})
+
+	t.Run("Storable values", func(t *testing.T) {
+		assert.NoError(t, domain.CheckDecimalScale(big.NewRat(1999, 100)))
+		assert.NoError(t, domain.CheckDecimalScale(big.NewRat(1, 1_000_000_000)))
+		assert.ErrorIs(t, domain.CheckDecimalScale(big.NewRat(1, 10_000_000_000)), domain.ErrPriceScale)
+		assert.ErrorIs(t, domain.CheckDecimalScale(big.NewRat(1, 3)), domain.ErrPriceScale)
+
+		tooLarge, ok := new(big.Rat).SetString("100000000000000000000000000000")
+		require.True(t, ok)
+		assert.ErrorIs(t, domain.CheckDecimalScale(tooLarge), domain.ErrMoneyOverflow)
+	})
+
+	t.Run("Repeated discounts keep an exact price", func(t *testing.T) {
+		now := time.Date(2026, 3, 3, 12, 0, 0, 0, time.UTC)
+		discounts := make([]*domain.Discount, 0, 25)
+		for i := 0; i < 25; i++ {
+			d, err := domain.NewStackedDiscount(
+				string(rune('a'+i)),
+				big.NewRat(1, 7),
+				now.Add(-time.Hour),
+				now.Add(time.Hour),
+				0,
+				false,
+			)
+			require.NoError(t, err)
+			discounts = append(discounts, d)
+		}
+		product := newStackedProduct(t, now, discounts...)
+		calc := services.PricingCalculator{Policy: services.PolicySequential}
+
+		effective := calc.EffectivePrice(product, now)
+		// 100 * (6/7)^25 has a denominator far beyond int64
+		_, _, err := effective.Fraction()
+		assert.ErrorIs(t, err, domain.ErrMoneyOverflow)
+
+		decimal, minor := calc.RoundedPrice(effective)
+		assert.Equal(t, "2.12", decimal)
+		assert.Equal(t, int64(212), minor.Int64())
+	})
+}
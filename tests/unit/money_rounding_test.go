@@ -0,0 +1,73 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"product-catalog-service/internal/app/product/domain"
+	"product-catalog-service/internal/app/product/domain/services"
+)
+
+func TestMoneyRounding(t *testing.T) {
+	cases := []struct {
+		name     string
+		num, den int64
+		currency domain.Currency
+		mode     domain.RoundingMode
+		decimal  string
+		minor    int64
+	}{
+		{"half-even tie rounds to even", 12345, 1000, "USD", domain.RoundHalfEven, "12.34", 1234},
+		{"half-even tie rounds up to even", 12355, 1000, "USD", domain.RoundHalfEven, "12.36", 1236},
+		{"half-up tie rounds away", 12345, 1000, "USD", domain.RoundHalfUp, "12.35", 1235},
+		{"down truncates", 12349, 1000, "USD", domain.RoundDown, "12.34", 1234},
+		{"discounted fraction", 7996, 500, "USD", domain.RoundHalfEven, "15.99", 1599},
+		{"negative half-up", -12345, 1000, "USD", domain.RoundHalfUp, "-12.35", -1235},
+		{"zero minor units", 15995, 10, "JPY", domain.RoundHalfEven, "1600", 1600},
+		{"three minor units", 12345, 10000, "BHD", domain.RoundHalfUp, "1.235", 1235},
+		{"leading zeros", 5, 100, "USD", domain.RoundHalfEven, "0.05", 5},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := domain.NewMoneyFromFraction(tc.num, tc.den)
+			require.NoError(t, err)
+			m = m.WithCurrency(tc.currency)
+
+			assert.Equal(t, tc.decimal, m.Decimal(tc.mode))
+			assert.Equal(t, tc.minor, m.MinorUnits(tc.mode).Int64())
+		})
+	}
+}
+
+func TestCurrencyParsing(t *testing.T) {
+	t.Run("Empty defaults to USD", func(t *testing.T) {
+		c, err := domain.ParseCurrency("")
+		require.NoError(t, err)
+		assert.Equal(t, domain.DefaultCurrency, c)
+	})
+
+	t.Run("Lower case is normalized", func(t *testing.T) {
+		c, err := domain.ParseCurrency("eur")
+		require.NoError(t, err)
+		assert.Equal(t, domain.Currency("EUR"), c)
+	})
+
+	t.Run("Unknown currency", func(t *testing.T) {
+		_, err := domain.ParseCurrency("XXX")
+		assert.ErrorIs(t, err, domain.ErrUnsupportedCurrency)
+	})
+}
+
+func TestPricingCalculatorRoundedPrice(t *testing.T) {
+	price, _ := domain.NewMoneyFromFraction(12345, 1000)
+
+	decimal, minor := services.PricingCalculator{}.RoundedPrice(price)
+	assert.Equal(t, "12.34", decimal)
+	assert.Equal(t, int64(1234), minor.Int64())
+
+	decimal, _ = services.PricingCalculator{Rounding: domain.RoundHalfUp}.RoundedPrice(price)
+	assert.Equal(t, "12.35", decimal)
+}
@@ -0,0 +1,76 @@
+package unit
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"product-catalog-service/internal/pkg/pagetoken"
+)
+
+func TestPageToken(t *testing.T) {
+	codec := pagetoken.NewCodec([]byte("secret"), time.Hour)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	token := pagetoken.Token{
+		Cursor:     "cursor",
+		FilterHash: "hash",
+		Sort:       "name desc",
+		ReadTime:   now.Add(-time.Minute),
+		IssuedAt:   now,
+	}
+
+	encoded, err := codec.Encode(token)
+	require.NoError(t, err)
+
+	t.Run("Round trip", func(t *testing.T) {
+		decoded, err := codec.Check(encoded, now.Add(time.Minute), "hash", "name desc")
+		require.NoError(t, err)
+		assert.Equal(t, token.Cursor, decoded.Cursor)
+		assert.True(t, token.ReadTime.Equal(decoded.ReadTime))
+	})
+
+	t.Run("Cursor is encoded, not plain JSON", func(t *testing.T) {
+		assert.NotContains(t, encoded, "cursor")
+	})
+
+	t.Run("Tampered tokens are malformed", func(t *testing.T) {
+		last := encoded[len(encoded)-1]
+		replacement := "A"
+		if last == 'A' {
+			replacement = "B"
+		}
+		tampered := encoded[:len(encoded)-1] + replacement
+		_, err := codec.Decode(tampered, now)
+		assert.ErrorIs(t, err, pagetoken.ErrMalformed)
+
+		_, err = codec.Decode("not a token", now)
+		assert.ErrorIs(t, err, pagetoken.ErrMalformed)
+	})
+
+	t.Run("Tokens signed with another key are malformed", func(t *testing.T) {
+		other := pagetoken.NewCodec([]byte("other"), time.Hour)
+		_, err := other.Decode(encoded, now)
+		assert.ErrorIs(t, err, pagetoken.ErrMalformed)
+	})
+
+	t.Run("Old tokens expire", func(t *testing.T) {
+		_, err := codec.Decode(encoded, now.Add(2*time.Hour))
+		assert.ErrorIs(t, err, pagetoken.ErrExpired)
+	})
+
+	t.Run("Tokens are bound to filter and sort", func(t *testing.T) {
+		_, err := codec.Check(encoded, now, "other", "name desc")
+		assert.ErrorIs(t, err, pagetoken.ErrMismatch)
+
+		_, err = codec.Check(encoded, now, "hash", "name")
+		assert.ErrorIs(t, err, pagetoken.ErrMismatch)
+	})
+
+	t.Run("Unknown versions are malformed", func(t *testing.T) {
+		_, err := codec.Decode(strings.Repeat("A", len(encoded)), now)
+		assert.ErrorIs(t, err, pagetoken.ErrMalformed)
+	})
+}
@@ -0,0 +1,45 @@
+package unit
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"product-catalog-service/internal/app/product/domain/services"
+)
+
+func TestPriceBreakdown(t *testing.T) {
+	now := time.Date(2026, 3, 3, 12, 0, 0, 0, time.UTC)
+	calc := services.PricingCalculator{Policy: services.PolicySequential}
+
+	t.Run("Breakdown lists the applied discounts", func(t *testing.T) {
+		product := newStackedProduct(t, now,
+			mustDiscount(t, "loyalty", 10, now, 5, false),
+			mustDiscount(t, "clearance", 20, now, 10, false),
+		)
+		breakdown := calc.Breakdown(product, now)
+		require.NotNil(t, breakdown)
+
+		assert.Equal(t, now, breakdown.At)
+		assert.Equal(t, 0, breakdown.BasePrice.Rat().Cmp(big.NewRat(100, 1)))
+		// 100 * 0.8 * 0.9 = 72
+		assert.Equal(t, 0, breakdown.EffectivePrice.Rat().Cmp(big.NewRat(72, 1)))
+		assert.Equal(t, 0, breakdown.DiscountAmount.Rat().Cmp(big.NewRat(28, 1)))
+		require.Len(t, breakdown.Discounts, 2)
+		assert.Equal(t, "clearance", breakdown.Discounts[0].ID())
+		assert.Equal(t, "loyalty", breakdown.Discounts[1].ID())
+	})
+
+	t.Run("Breakdown outside discount windows", func(t *testing.T) {
+		product := newStackedProduct(t, now, mustDiscount(t, "sale", 20, now, 0, false))
+		breakdown := calc.Breakdown(product, now.Add(2*time.Hour))
+		require.NotNil(t, breakdown)
+
+		assert.Empty(t, breakdown.Discounts)
+		assert.Equal(t, 0, breakdown.DiscountAmount.Rat().Sign())
+		assert.Equal(t, 0, breakdown.EffectivePrice.Compare(breakdown.BasePrice))
+	})
+}
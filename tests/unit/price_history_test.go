@@ -0,0 +1,56 @@
+package unit
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"product-catalog-service/internal/app/product/domain/services"
+)
+
+func TestPriceTimeline(t *testing.T) {
+	now := time.Date(2026, 3, 3, 12, 0, 0, 0, time.UTC)
+	calc := services.PricingCalculator{Policy: services.PolicySequential}
+
+	// Created two days ago at $100; a 20% discount around now was added a day ago.
+	snapshots := []services.PriceSnapshot{
+		{RecordedAt: now.Add(-24 * time.Hour), Product: newStackedProduct(t, now, mustDiscount(t, "sale", 20, now, 0, false))},
+		{RecordedAt: now.Add(-48 * time.Hour), Product: newStackedProduct(t, now)},
+	}
+
+	t.Run("Periods follow snapshots and discount windows", func(t *testing.T) {
+		periods := calc.Timeline(snapshots, now.Add(-72*time.Hour), now.Add(2*time.Hour))
+		require.Len(t, periods, 3)
+
+		assert.Equal(t, now.Add(-48*time.Hour), periods[0].From)
+		assert.Equal(t, now.Add(-time.Hour), periods[0].To)
+		assert.Equal(t, 0, periods[0].EffectivePrice.Rat().Cmp(big.NewRat(100, 1)))
+
+		assert.Equal(t, now.Add(-time.Hour), periods[1].From)
+		assert.Equal(t, now.Add(time.Hour+time.Nanosecond), periods[1].To)
+		assert.Equal(t, 0, periods[1].EffectivePrice.Rat().Cmp(big.NewRat(80, 1)))
+		assert.Equal(t, 0, periods[1].BasePrice.Rat().Cmp(big.NewRat(100, 1)))
+
+		assert.Equal(t, now.Add(time.Hour+time.Nanosecond), periods[2].From)
+		assert.Equal(t, now.Add(2*time.Hour), periods[2].To)
+		assert.Equal(t, 0, periods[2].EffectivePrice.Rat().Cmp(big.NewRat(100, 1)))
+	})
+
+	t.Run("Lowest price over the lookback", func(t *testing.T) {
+		lowest := calc.LowestPrice(snapshots, now.Add(-30*24*time.Hour), now.Add(time.Nanosecond))
+		require.NotNil(t, lowest)
+		assert.Equal(t, 0, lowest.Rat().Cmp(big.NewRat(80, 1)))
+
+		lowest = calc.LowestPrice(snapshots, now.Add(2*time.Hour), now.Add(3*time.Hour))
+		require.NotNil(t, lowest)
+		assert.Equal(t, 0, lowest.Rat().Cmp(big.NewRat(100, 1)))
+	})
+
+	t.Run("No price before the first snapshot", func(t *testing.T) {
+		assert.Empty(t, calc.Timeline(snapshots, now.Add(-96*time.Hour), now.Add(-72*time.Hour)))
+		assert.Nil(t, calc.LowestPrice(snapshots, now.Add(-96*time.Hour), now.Add(-72*time.Hour)))
+	})
+}
@@ -0,0 +1,85 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"product-catalog-service/internal/app/product/domain"
+)
+
+func TestRecategorization(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Categories must differ", func(t *testing.T) {
+		for _, tc := range []struct{ from, to string }{
+			{"", "shoes"},
+			{"shoes", ""},
+			{"shoes", "shoes"},
+		} {
+			_, err := domain.NewRecategorization("j1", tc.from, tc.to, now)
+			assert.ErrorIs(t, err, domain.ErrInvalidRecategorization, tc)
+		}
+	})
+
+	t.Run("New job is running and emits started", func(t *testing.T) {
+		r, err := domain.NewRecategorization("j1", "footwear", "shoes", now)
+		require.NoError(t, err)
+		assert.Equal(t, domain.RecategorizationRunning, r.Status())
+		assert.Nil(t, r.FinishedAt())
+		require.Len(t, r.DomainEvents(), 1)
+		assert.IsType(t, domain.RecategorizationStartedEvent{}, r.DomainEvents()[0])
+	})
+
+	t.Run("Batches are numbered and counted", func(t *testing.T) {
+		r := domain.RehydrateRecategorization("j1", "footwear", "shoes", domain.RecategorizationRunning, 2, 1000, "", now, now, nil)
+		later := now.Add(time.Minute)
+
+		batch, err := r.RecordBatch(300, later)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), batch)
+		assert.Equal(t, int64(1300), r.MovedProducts())
+		assert.Equal(t, later, r.UpdatedAt())
+		assert.True(t, r.Changes().Dirty(domain.FieldProgress))
+		assert.Empty(t, r.DomainEvents())
+	})
+
+	t.Run("Complete emits completed once", func(t *testing.T) {
+		r := domain.RehydrateRecategorization("j1", "footwear", "shoes", domain.RecategorizationRunning, 1, 5, "", now, now, nil)
+		later := now.Add(time.Minute)
+
+		r.Complete(later)
+		r.Complete(later.Add(time.Minute))
+		assert.Equal(t, domain.RecategorizationSucceeded, r.Status())
+		require.NotNil(t, r.FinishedAt())
+		assert.Equal(t, later, *r.FinishedAt())
+		require.Len(t, r.DomainEvents(), 1)
+		completed, ok := r.DomainEvents()[0].(domain.RecategorizationCompletedEvent)
+		require.True(t, ok)
+		assert.Equal(t, int64(5), completed.MovedProducts)
+
+		_, err := r.RecordBatch(1, later)
+		assert.ErrorIs(t, err, domain.ErrRecategorizationFinished)
+	})
+	t.Run("Fail records the failure and stops the job", func(t *testing.T) {
+		r := domain.RehydrateRecategorization("j1", "footwear", "shoes", domain.RecategorizationRunning, 1, 5, "", now, now, nil)
+		later := now.Add(time.Minute)
+
+		r.Fail("product p1: attributes do not match category schema", later)
+		r.Complete(later.Add(time.Minute))
+		assert.Equal(t, domain.RecategorizationFailed, r.Status())
+		assert.Equal(t, "product p1: attributes do not match category schema", r.Failure())
+		require.NotNil(t, r.FinishedAt())
+		assert.Equal(t, later, *r.FinishedAt())
+		assert.True(t, r.Changes().Dirty(domain.FieldStatus))
+		require.Len(t, r.DomainEvents(), 1)
+		failed, ok := r.DomainEvents()[0].(domain.RecategorizationFailedEvent)
+		require.True(t, ok)
+		assert.Equal(t, int64(5), failed.MovedProducts)
+
+		_, err := r.RecordBatch(1, later)
+		assert.ErrorIs(t, err, domain.ErrRecategorizationFinished)
+	})
+}
@@ -0,0 +1,96 @@
+package unit
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"product-catalog-service/internal/app/product/domain"
+	"product-catalog-service/internal/app/product/domain/services"
+)
+
+func mustScheduledPrice(t *testing.T, id, price string, effectiveFrom time.Time) *domain.ScheduledPrice {
+	t.Helper()
+	money, err := domain.NewMoneyFromString(price)
+	require.NoError(t, err)
+	scheduled, err := domain.NewScheduledPrice(id, money, effectiveFrom)
+	require.NoError(t, err)
+	return scheduled
+}
+
+func TestScheduledPrices(t *testing.T) {
+	now := time.Date(2026, 3, 3, 12, 0, 0, 0, time.UTC)
+	nextMonth := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	calc := services.PricingCalculator{Policy: services.PolicySequential}
+
+	t.Run("Effective price uses the base price in force", func(t *testing.T) {
+		product := newStackedProduct(t, now)
+		require.NoError(t, product.SchedulePrice(mustScheduledPrice(t, "april", "120", nextMonth), now))
+
+		assert.Equal(t, 0, calc.EffectivePrice(product, now).Rat().Cmp(big.NewRat(100, 1)))
+		assert.Equal(t, 0, calc.EffectivePrice(product, nextMonth).Rat().Cmp(big.NewRat(120, 1)))
+	})
+
+	t.Run("Discounts apply to the scheduled price", func(t *testing.T) {
+		discount, err := domain.NewStackedDiscount("d", big.NewRat(10, 100), now, nextMonth.AddDate(0, 1, 0), 0, false)
+		require.NoError(t, err)
+		product := newStackedProduct(t, now, discount)
+		require.NoError(t, product.SchedulePrice(mustScheduledPrice(t, "april", "120", nextMonth), now))
+
+		assert.Equal(t, 0, calc.EffectivePrice(product, nextMonth).Rat().Cmp(big.NewRat(108, 1)))
+	})
+
+	t.Run("Past effective time is rejected", func(t *testing.T) {
+		product := newStackedProduct(t, now)
+		err := product.SchedulePrice(mustScheduledPrice(t, "late", "120", now.Add(-time.Hour)), now)
+		assert.ErrorIs(t, err, domain.ErrInvalidPriceSchedule)
+	})
+
+	t.Run("Archived product is rejected", func(t *testing.T) {
+		product := newStackedProduct(t, now)
+		product.Archive(now)
+		err := product.SchedulePrice(mustScheduledPrice(t, "april", "120", nextMonth), now)
+		assert.ErrorIs(t, err, domain.ErrProductArchived)
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		product := newStackedProduct(t, now)
+		require.NoError(t, product.SchedulePrice(mustScheduledPrice(t, "april", "120", nextMonth), now))
+		require.NoError(t, product.CancelScheduledPrice("april", now))
+
+		assert.Empty(t, product.ScheduledPrices())
+		assert.Equal(t, 0, calc.EffectivePrice(product, nextMonth).Rat().Cmp(big.NewRat(100, 1)))
+		assert.ErrorIs(t, product.CancelScheduledPrice("april", now), domain.ErrScheduledPriceNotFound)
+	})
+
+	t.Run("Due prices become the base price in order", func(t *testing.T) {
+		product := newStackedProduct(t, now)
+		require.NoError(t, product.SchedulePrice(mustScheduledPrice(t, "may", "130", nextMonth.AddDate(0, 1, 0)), now))
+		require.NoError(t, product.SchedulePrice(mustScheduledPrice(t, "april", "120", nextMonth), now))
+		product.ClearDomainEvents()
+
+		applied := product.ApplyDueScheduledPrices(nextMonth.AddDate(0, 2, 0))
+		require.Len(t, applied, 2)
+		assert.Equal(t, "april", applied[0].ID())
+		assert.Equal(t, "130", product.BasePrice().String())
+		assert.Empty(t, product.ScheduledPrices())
+		assert.True(t, product.Changes().Dirty(domain.FieldBasePrice))
+
+		events := product.DomainEvents()
+		require.Len(t, events, 2)
+		first := events[0].(domain.ScheduledPriceEffectiveEvent)
+		assert.Equal(t, "100", first.OldPrice)
+		assert.Equal(t, "120", first.NewPrice)
+	})
+
+	t.Run("Nothing due", func(t *testing.T) {
+		product := newStackedProduct(t, now)
+		require.NoError(t, product.SchedulePrice(mustScheduledPrice(t, "april", "120", nextMonth), now))
+
+		assert.Empty(t, product.ApplyDueScheduledPrices(now))
+		assert.Len(t, product.ScheduledPrices(), 1)
+	})
+}
@@ -0,0 +1,154 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"product-catalog-service/internal/pkg/search"
+)
+
+func TestSearchText(t *testing.T) {
+	t.Run("Stemming joins word forms", func(t *testing.T) {
+		for word, stem := range map[string]string{
+			"running": "run",
+			"runs":    "run",
+			"shoes":   "shoe",
+			"boxes":   "box",
+			"berries": "berry",
+			"dressed": "dress",
+			"glass":   "glass",
+			"bus":     "bus",
+			"red":     "red",
+		} {
+			assert.Equal(t, stem, search.Stem(word), word)
+		}
+	})
+
+	t.Run("Tokenize drops stop words and keeps offsets", func(t *testing.T) {
+		tokens := search.Tokenize("The Running-Shoes, for trails")
+		require.Len(t, tokens, 3)
+		assert.Equal(t, search.Token{Term: "run", Start: 4, End: 11}, tokens[0])
+		assert.Equal(t, search.Token{Term: "shoe", Start: 12, End: 17}, tokens[1])
+		assert.Equal(t, "trail", tokens[2].Term)
+	})
+
+	t.Run("Terms are distinct and in query order", func(t *testing.T) {
+		assert.Equal(t, []string{"shoe", "red"}, search.Terms("Shoes red shoe"))
+		assert.Empty(t, search.Terms("the of and"))
+	})
+
+	t.Run("Highlight wraps matched words as written", func(t *testing.T) {
+		got := search.Highlight("Red Running Shoes", search.Terms("run shoe"), "<em>", "</em>")
+		assert.Equal(t, "Red <em>Running</em> <em>Shoes</em>", got)
+	})
+
+	t.Run("Highlight escapes the text around and in matches", func(t *testing.T) {
+		got := search.Highlight(`<script>alert(1)</script> Shoes & "Socks"`, search.Terms("script shoes"), "<em>", "</em>")
+		assert.Equal(t, "&lt;<em>script</em>&gt;alert(1)&lt;/<em>script</em>&gt; <em>Shoes</em> &amp; &#34;Socks&#34;", got)
+	})
+}
+
+func TestSearchIndex(t *testing.T) {
+	newIndex := func() *search.Index {
+		idx := search.NewIndex(map[string]float64{"name": 3, "description": 1})
+		idx.Upsert("p1", map[string]string{"name": "Trail Running Shoes", "description": "Grippy soles"})
+		idx.Upsert("p2", map[string]string{"name": "Leather Boots", "description": "Good for running errands"})
+		idx.Upsert("p3", map[string]string{"name": "Wool Hat", "description": "Warm"})
+		return idx
+	}
+
+	t.Run("Name matches rank above description matches", func(t *testing.T) {
+		hits, more := newIndex().Search(search.Terms("running"), 0, 10)
+		require.Len(t, hits, 2)
+		assert.False(t, more)
+		assert.Equal(t, "p1", hits[0].ID)
+		assert.Equal(t, "p2", hits[1].ID)
+		assert.Greater(t, hits[0].Score, hits[1].Score)
+	})
+
+	t.Run("Any term matches; more terms rank higher", func(t *testing.T) {
+		hits, _ := newIndex().Search(search.Terms("leather shoes boots"), 0, 10)
+		require.Len(t, hits, 2)
+		assert.Equal(t, "p2", hits[0].ID)
+	})
+
+	t.Run("Pages by offset and limit", func(t *testing.T) {
+		idx := newIndex()
+		hits, more := idx.Search(search.Terms("running"), 0, 1)
+		require.Len(t, hits, 1)
+		assert.True(t, more)
+		hits, more = idx.Search(search.Terms("running"), 1, 1)
+		require.Len(t, hits, 1)
+		assert.Equal(t, "p2", hits[0].ID)
+		assert.False(t, more)
+	})
+
+	t.Run("Upsert replaces and Delete removes a document", func(t *testing.T) {
+		idx := newIndex()
+		idx.Upsert("p3", map[string]string{"name": "Running Hat"})
+		hits, _ := idx.Search(search.Terms("wool"), 0, 10)
+		assert.Empty(t, hits)
+
+		idx.Delete("p1")
+		hits, _ = idx.Search(search.Terms("running"), 0, 10)
+		require.Len(t, hits, 2)
+		assert.Equal(t, "p3", hits[0].ID)
+		assert.Equal(t, 2, idx.Len())
+	})
+}
+
+func TestSuggester(t *testing.T) {
+	newSuggester := func() *search.Suggester {
+		s := search.NewSuggester()
+		s.Set("p1", map[string]string{"name": "Trail Running Shoes", "category": "shoes"})
+		s.Set("p2", map[string]string{"name": "Running Socks", "category": "socks"})
+		s.Set("p3", map[string]string{"name": "Leather Shoes", "category": "shoes"})
+		return s
+	}
+	texts := func(suggestions []search.Suggestion) []string {
+		out := []string{}
+		for _, s := range suggestions {
+			out = append(out, s.Text)
+		}
+		return out
+	}
+
+	t.Run("Phrase starts rank above word starts", func(t *testing.T) {
+		got := newSuggester().Suggest("Run", 10)
+		assert.Equal(t, []string{"Running Socks", "Trail Running Shoes"}, texts(got))
+		assert.Equal(t, "name", got[0].Kind)
+	})
+
+	t.Run("Phrases shared by more documents rank higher", func(t *testing.T) {
+		got := newSuggester().Suggest("sho", 10)
+		require.NotEmpty(t, got)
+		assert.Equal(t, search.Suggestion{Text: "shoes", Kind: "category", Count: 2}, got[0])
+		assert.ElementsMatch(t, []string{"shoes", "Leather Shoes", "Trail Running Shoes"}, texts(got))
+	})
+
+	t.Run("Typos are tolerated in longer prefixes", func(t *testing.T) {
+		got := newSuggester().Suggest("runnign", 10)
+		assert.Equal(t, []string{"Running Socks", "Trail Running Shoes"}, texts(got))
+		assert.Equal(t, 1, got[0].Typos)
+
+		assert.Empty(t, newSuggester().Suggest("rnu", 10))
+	})
+
+	t.Run("Trailing space completes the next word", func(t *testing.T) {
+		got := newSuggester().Suggest("trail ", 10)
+		assert.Equal(t, []string{"Trail Running Shoes"}, texts(got))
+	})
+
+	t.Run("Limit and deletes apply", func(t *testing.T) {
+		s := newSuggester()
+		assert.Len(t, s.Suggest("s", 2), 2)
+
+		s.Delete("p3")
+		got := s.Suggest("shoes", 10)
+		require.NotEmpty(t, got)
+		assert.Equal(t, 1, got[0].Count)
+		assert.NotContains(t, texts(got), "Leather Shoes")
+	})
+}
@@ -0,0 +1,158 @@
+package unit
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"product-catalog-service/internal/app/product/domain"
+	"product-catalog-service/internal/app/product/domain/services"
+)
+
+func newStackedProduct(t *testing.T, now time.Time, discounts ...*domain.Discount) *domain.Product {
+	t.Helper()
+	basePrice, err := domain.NewMoneyFromFraction(10000, 100) // $100.00
+	require.NoError(t, err)
+	return domain.RehydrateProduct(
+		"test-id",
+		"Test",
+		"Test",
+		"test",
+		basePrice,
+		discounts,
+		nil,
+		nil,
+		nil,
+		domain.ProductStatusActive,
+		nil,
+		now,
+		now,
+	)
+}
+
+func mustDiscount(t *testing.T, id string, percent int64, now time.Time, priority int, exclusive bool) *domain.Discount {
+	t.Helper()
+	d, err := domain.NewStackedDiscount(
+		id,
+		big.NewRat(percent, 100),
+		now.Add(-time.Hour),
+		now.Add(time.Hour),
+		priority,
+		exclusive,
+	)
+	require.NoError(t, err)
+	return d
+}
+
+func TestStackedDiscountPolicies(t *testing.T) {
+	now := time.Date(2026, 3, 3, 12, 0, 0, 0, time.UTC)
+	clearance := mustDiscount(t, "clearance", 20, now, 10, false)
+	loyalty := mustDiscount(t, "loyalty", 10, now, 5, false)
+
+	t.Run("Best-of applies the largest discount", func(t *testing.T) {
+		product := newStackedProduct(t, now, loyalty, clearance)
+		effective := services.PricingCalculator{}.EffectivePrice(product, now)
+		assert.Equal(t, 0, effective.Rat().Cmp(big.NewRat(80, 1)))
+	})
+
+	t.Run("Sequential multiplies discounts", func(t *testing.T) {
+		product := newStackedProduct(t, now, clearance, loyalty)
+		calc := services.PricingCalculator{Policy: services.PolicySequential}
+		// 100 * 0.8 * 0.9 = 72
+		assert.Equal(t, 0, calc.EffectivePrice(product, now).Rat().Cmp(big.NewRat(72, 1)))
+	})
+
+	t.Run("Additive sums discounts", func(t *testing.T) {
+		product := newStackedProduct(t, now, clearance, loyalty)
+		calc := services.PricingCalculator{Policy: services.PolicyAdditiveCapped}
+		// 100 * (1 - 0.3) = 70
+		assert.Equal(t, 0, calc.EffectivePrice(product, now).Rat().Cmp(big.NewRat(70, 1)))
+	})
+
+	t.Run("Additive respects cap", func(t *testing.T) {
+		product := newStackedProduct(t, now, clearance, loyalty)
+		calc := services.PricingCalculator{
+			Policy:      services.PolicyAdditiveCapped,
+			AdditiveCap: big.NewRat(25, 100),
+		}
+		assert.Equal(t, 0, calc.EffectivePrice(product, now).Rat().Cmp(big.NewRat(75, 1)))
+	})
+
+	t.Run("Top-priority exclusive discount applies alone", func(t *testing.T) {
+		staff := mustDiscount(t, "staff", 15, now, 20, true)
+		product := newStackedProduct(t, now, clearance, loyalty, staff)
+		calc := services.PricingCalculator{Policy: services.PolicySequential}
+
+		applicable := calc.ApplicableDiscounts(product, now)
+		require.Len(t, applicable, 1)
+		assert.Equal(t, "staff", applicable[0].ID())
+		assert.Equal(t, 0, calc.EffectivePrice(product, now).Rat().Cmp(big.NewRat(85, 1)))
+	})
+
+	t.Run("Lower-priority exclusive discount is ignored", func(t *testing.T) {
+		staff := mustDiscount(t, "staff", 50, now, 1, true)
+		product := newStackedProduct(t, now, clearance, loyalty, staff)
+		calc := services.PricingCalculator{Policy: services.PolicySequential}
+		assert.Equal(t, 0, calc.EffectivePrice(product, now).Rat().Cmp(big.NewRat(72, 1)))
+	})
+
+	t.Run("Expired discounts are skipped", func(t *testing.T) {
+		product := newStackedProduct(t, now, clearance, loyalty)
+		calc := services.PricingCalculator{Policy: services.PolicySequential}
+		later := now.Add(2 * time.Hour)
+		assert.Equal(t, 0, calc.EffectivePrice(product, later).Rat().Cmp(big.NewRat(100, 1)))
+	})
+}
+
+func TestProductDiscountCollection(t *testing.T) {
+	now := time.Date(2026, 3, 3, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Applying keeps existing discounts", func(t *testing.T) {
+		product := newStackedProduct(t, now)
+		require.NoError(t, product.ApplyDiscount(mustDiscount(t, "a", 10, now, 0, false), now))
+		require.NoError(t, product.ApplyDiscount(mustDiscount(t, "b", 20, now, 0, false), now))
+		assert.Len(t, product.Discounts(), 2)
+		assert.True(t, product.Changes().Dirty(domain.FieldDiscount))
+		assert.Equal(t, []string{domain.DiscountField("a"), domain.DiscountField("b")},
+			product.Changes().DirtyWithPrefix(domain.DiscountField("")))
+	})
+
+	t.Run("Applying the same ID replaces the discount", func(t *testing.T) {
+		product := newStackedProduct(t, now, mustDiscount(t, "a", 10, now, 0, false))
+		require.NoError(t, product.ApplyDiscount(mustDiscount(t, "a", 30, now, 0, false), now))
+		require.Len(t, product.Discounts(), 1)
+		assert.Equal(t, 0, product.Discounts()[0].Percentage().Cmp(big.NewRat(30, 100)))
+	})
+
+	t.Run("Remove by ID", func(t *testing.T) {
+		product := newStackedProduct(t, now,
+			mustDiscount(t, "a", 10, now, 0, false),
+			mustDiscount(t, "b", 20, now, 0, false),
+		)
+		require.NoError(t, product.RemoveDiscount("a", now))
+		require.Len(t, product.Discounts(), 1)
+		assert.Equal(t, "b", product.Discounts()[0].ID())
+		assert.Equal(t, []string{domain.DiscountField("a")}, product.Changes().DirtyWithPrefix(domain.DiscountField("")))
+
+		events := product.DomainEvents()
+		require.Len(t, events, 1)
+		assert.Equal(t, "a", events[0].(domain.DiscountRemovedEvent).DiscountID)
+	})
+
+	t.Run("Remove unknown ID", func(t *testing.T) {
+		product := newStackedProduct(t, now, mustDiscount(t, "a", 10, now, 0, false))
+		assert.ErrorIs(t, product.RemoveDiscount("missing", now), domain.ErrDiscountNotFound)
+	})
+
+	t.Run("Remove all", func(t *testing.T) {
+		product := newStackedProduct(t, now,
+			mustDiscount(t, "a", 10, now, 0, false),
+			mustDiscount(t, "b", 20, now, 0, false),
+		)
+		require.NoError(t, product.RemoveDiscount("", now))
+		assert.Empty(t, product.Discounts())
+	})
+}
@@ -0,0 +1,139 @@
+package unit
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"product-catalog-service/internal/app/product/domain"
+	"product-catalog-service/internal/app/product/domain/services"
+)
+
+func mustVariant(t *testing.T, id, sku string, options map[string]string, priceOverride string) *domain.Variant {
+	t.Helper()
+	var price *domain.Money
+	if priceOverride != "" {
+		var err error
+		price, err = domain.NewMoneyFromString(priceOverride)
+		require.NoError(t, err)
+	}
+	v, err := domain.NewVariant(id, sku, options, price, "")
+	require.NoError(t, err)
+	return v
+}
+
+func TestNewVariant(t *testing.T) {
+	t.Run("Invalid variants are rejected", func(t *testing.T) {
+		negative, err := domain.NewMoneyFromString("-1")
+		require.NoError(t, err)
+
+		for _, tc := range []struct {
+			name    string
+			sku     string
+			options map[string]string
+			price   *domain.Money
+			status  domain.VariantStatus
+		}{
+			{"empty sku", "", map[string]string{"size": "M"}, nil, ""},
+			{"sku with spaces", "TEE M", map[string]string{"size": "M"}, nil, ""},
+			{"no options", "TEE-M", nil, nil, ""},
+			{"bad option name", "TEE-M", map[string]string{"Size": "M"}, nil, ""},
+			{"empty option value", "TEE-M", map[string]string{"size": " "}, nil, ""},
+			{"negative price", "TEE-M", map[string]string{"size": "M"}, negative, ""},
+			{"unknown status", "TEE-M", map[string]string{"size": "M"}, nil, "sold_out"},
+		} {
+			_, err := domain.NewVariant("v1", tc.sku, tc.options, tc.price, tc.status)
+			assert.ErrorIs(t, err, domain.ErrInvalidVariant, tc.name)
+		}
+	})
+
+	t.Run("Options have a canonical key", func(t *testing.T) {
+		v := mustVariant(t, "v1", "TEE-M-RED", map[string]string{"size": "M", "color": "red"}, "")
+		assert.Equal(t, "color=red;size=M", v.OptionKey())
+		assert.True(t, v.IsActive())
+		assert.Nil(t, v.PriceOverride())
+	})
+}
+
+func TestProductVariants(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	newProduct := func() *domain.Product {
+		basePrice, _ := domain.NewMoneyFromFraction(2000, 100)
+		return domain.RehydrateProduct("p1", "Tee", "", "shirts", basePrice, nil, nil, nil, nil,
+			domain.ProductStatusActive, nil, now, now)
+	}
+
+	t.Run("Adding a variant records an event", func(t *testing.T) {
+		p := newProduct()
+		later := now.Add(time.Minute)
+
+		err := p.AddVariant(mustVariant(t, "v1", "TEE-M", map[string]string{"size": "M"}, "24.99"), later)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"variants.v1"}, p.Changes().DirtyWithPrefix("variants."))
+		assert.Equal(t, later, p.UpdatedAt())
+		require.Len(t, p.Variants(), 1)
+		assert.Equal(t, p.Currency(), p.Variants()[0].PriceOverride().Currency())
+		require.Len(t, p.DomainEvents(), 1)
+		event, ok := p.DomainEvents()[0].(domain.VariantAddedEvent)
+		require.True(t, ok)
+		assert.Equal(t, "TEE-M", event.SKU)
+		assert.Equal(t, "24.99", event.PriceOverride)
+	})
+
+	t.Run("SKUs and options are unique within the product", func(t *testing.T) {
+		p := newProduct()
+		require.NoError(t, p.AddVariant(mustVariant(t, "v1", "TEE-M", map[string]string{"size": "M"}, ""), now))
+
+		err := p.AddVariant(mustVariant(t, "v2", "TEE-M", map[string]string{"size": "L"}, ""), now)
+		assert.ErrorIs(t, err, domain.ErrSKUTaken)
+
+		err = p.AddVariant(mustVariant(t, "v2", "TEE-M2", map[string]string{"size": "M"}, ""), now)
+		assert.ErrorIs(t, err, domain.ErrInvalidVariant)
+		assert.Len(t, p.Variants(), 1)
+	})
+
+	t.Run("Unchanged variants record nothing", func(t *testing.T) {
+		v := mustVariant(t, "v1", "TEE-M", map[string]string{"size": "M"}, "")
+		p := domain.RehydrateProduct("p1", "Tee", "", "shirts", newProduct().BasePrice(), nil, nil, nil,
+			[]*domain.Variant{v}, domain.ProductStatusActive, nil, now, now)
+
+		require.NoError(t, p.UpdateVariant(mustVariant(t, "v1", "TEE-M", map[string]string{"size": "M"}, ""), now))
+		assert.False(t, p.Changes().Dirty(domain.FieldVariants))
+		assert.Empty(t, p.DomainEvents())
+
+		assert.ErrorIs(t, p.RemoveVariant("v2", now), domain.ErrVariantNotFound)
+		require.NoError(t, p.RemoveVariant("v1", now))
+		assert.Empty(t, p.Variants())
+		require.Len(t, p.DomainEvents(), 1)
+		assert.IsType(t, domain.VariantRemovedEvent{}, p.DomainEvents()[0])
+	})
+}
+
+func TestVariantPrice(t *testing.T) {
+	now := time.Date(2026, 3, 3, 12, 0, 0, 0, time.UTC)
+	product := newStackedProduct(t, now, mustDiscount(t, "sale", 20, now, 0, false))
+	require.NoError(t, product.AddVariant(mustVariant(t, "small", "TEE-S", map[string]string{"size": "S"}, ""), now))
+	require.NoError(t, product.AddVariant(mustVariant(t, "xxl", "TEE-XXL", map[string]string{"size": "XXL"}, "120"), now))
+	calc := services.PricingCalculator{}
+
+	t.Run("Variants without override use the product price", func(t *testing.T) {
+		price := calc.VariantPrice(product, "small", now)
+		require.NotNil(t, price)
+		assert.Equal(t, 0, price.Rat().Cmp(big.NewRat(80, 1)))
+	})
+
+	t.Run("Discounts apply to the override", func(t *testing.T) {
+		price := calc.VariantPrice(product, "xxl", now)
+		require.NotNil(t, price)
+		assert.Equal(t, 0, price.Rat().Cmp(big.NewRat(96, 1)))
+		assert.Equal(t, product.Currency(), price.Currency())
+	})
+
+	t.Run("Unknown variants have no price", func(t *testing.T) {
+		assert.Nil(t, calc.VariantPrice(product, "missing", now))
+	})
+}